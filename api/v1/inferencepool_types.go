@@ -50,6 +50,8 @@ type InferencePoolList struct {
 }
 
 // InferencePoolSpec defines the desired state of InferencePool
+//
+// +kubebuilder:validation:XValidation:rule="has(self.targetPortNumber) != has(self.targetPorts)",message="exactly one of targetPortNumber and targetPorts must be set"
 type InferencePoolSpec struct {
 	// Selector defines a map of labels to watch model server Pods
 	// that should be included in the InferencePool.
@@ -63,11 +65,24 @@ type InferencePoolSpec struct {
 
 	// TargetPortNumber defines the port number to access the selected model server Pods.
 	// The number must be in the range 1 to 65535.
+	// Exactly one of TargetPortNumber and TargetPorts must be set. TargetPortNumber was
+	// required before TargetPorts was added, and is omitted from the pools setting TargetPorts.
 	//
+	// +optional
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Maximum=65535
-	// +kubebuilder:validation:Required
-	TargetPortNumber int32 `json:"targetPortNumber"`
+	TargetPortNumber int32 `json:"targetPortNumber,omitempty"`
+
+	// TargetPorts defines the port numbers to access the selected model server Pods, for the
+	// model servers which serve one data parallel rank per port. Every port of a Pod is an
+	// endpoint of its own, scheduled independently of the other ranks of the Pod.
+	// Exactly one of TargetPortNumber and TargetPorts must be set.
+	//
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=8
+	TargetPorts []PortNumber `json:"targetPorts,omitempty"`
 
 	// EndpointPickerConfig specifies the configuration needed by the proxy to discover and connect to the endpoint
 	// picker service that picks endpoints for the requests routed to this pool.
@@ -181,6 +181,11 @@ func (in *InferencePoolSpec) DeepCopyInto(out *InferencePoolSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.TargetPorts != nil {
+		in, out := &in.TargetPorts, &out.TargetPorts
+		*out = make([]PortNumber, len(*in))
+		copy(*out, *in)
+	}
 	in.EndpointPickerConfig.DeepCopyInto(&out.EndpointPickerConfig)
 }
 
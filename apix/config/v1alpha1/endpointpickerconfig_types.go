@@ -19,6 +19,8 @@ package v1alpha1
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -39,13 +41,31 @@ type EndpointPickerConfig struct {
 	// SchedulingProfiles is the list of named SchedulingProfiles
 	// that will be created.
 	SchedulingProfiles []SchedulingProfile `json:"schedulingProfiles"`
+
+	// +optional
+	// SaturationDetector configures the detection of the saturation of the
+	// pool, under which the non-critical requests are dropped. If omitted,
+	// the thresholds are read from the SD_* environment variables.
+	SaturationDetector *SaturationDetectorConfig `json:"saturationDetector,omitempty"`
+
+	// +optional
+	// FlowControl configures the priority bands of the flow control layer.
+	FlowControl *FlowControlConfig `json:"flowControl,omitempty"`
 }
 
 func (cfg EndpointPickerConfig) String() string {
+	var sections string
+	if cfg.SaturationDetector != nil {
+		sections += fmt.Sprintf(", SaturationDetector: %v", *cfg.SaturationDetector)
+	}
+	if cfg.FlowControl != nil {
+		sections += fmt.Sprintf(", FlowControl: %v", *cfg.FlowControl)
+	}
 	return fmt.Sprintf(
-		"{Plugins: %v, SchedulingProfiles: %v}",
+		"{Plugins: %v, SchedulingProfiles: %v%s}",
 		cfg.Plugins,
 		cfg.SchedulingProfiles,
+		sections,
 	)
 }
 
@@ -118,3 +138,128 @@ func (sp SchedulingPlugin) String() string {
 	}
 	return fmt.Sprintf("{PluginRef: %s%s}", sp.PluginRef, weight)
 }
+
+// SaturationDetectorConfig contains the thresholds above which the pool is
+// considered saturated.
+type SaturationDetectorConfig struct {
+	// +optional
+	// QueueDepthThreshold is the number of waiting requests above which a
+	// pod is saturated. It must be positive. Defaults to 5.
+	QueueDepthThreshold *int `json:"queueDepthThreshold,omitempty"`
+
+	// +optional
+	// KVCacheUtilThreshold is the KV cache utilization above which a pod is
+	// saturated. It must be between 0 and 1, exclusive. Defaults to 0.8.
+	KVCacheUtilThreshold *float64 `json:"kvCacheUtilThreshold,omitempty"`
+
+	// +optional
+	// MetricsStalenessThreshold is the age above which the metrics of a pod
+	// are stale, and the pod is considered saturated. It must be positive.
+	// Defaults to 200ms.
+	MetricsStalenessThreshold *metav1.Duration `json:"metricsStalenessThreshold,omitempty"`
+
+	// +optional
+	// BypassCriticality is the criticality from which the requests are
+	// admitted even if the pool is saturated. Defaults to 2.
+	BypassCriticality *int `json:"bypassCriticality,omitempty"`
+}
+
+func (sd SaturationDetectorConfig) String() string {
+	var fields []string
+	if sd.QueueDepthThreshold != nil {
+		fields = append(fields, fmt.Sprintf("QueueDepthThreshold: %d", *sd.QueueDepthThreshold))
+	}
+	if sd.KVCacheUtilThreshold != nil {
+		fields = append(fields, fmt.Sprintf("KVCacheUtilThreshold: %g", *sd.KVCacheUtilThreshold))
+	}
+	if sd.MetricsStalenessThreshold != nil {
+		fields = append(fields, fmt.Sprintf("MetricsStalenessThreshold: %s", sd.MetricsStalenessThreshold.Duration))
+	}
+	if sd.BypassCriticality != nil {
+		fields = append(fields, fmt.Sprintf("BypassCriticality: %d", *sd.BypassCriticality))
+	}
+	return "{" + strings.Join(fields, ", ") + "}"
+}
+
+// FlowControlConfig contains the configuration of the flow control layer.
+type FlowControlConfig struct {
+	// +optional
+	// MaxBytes is the maximum total size of the queued requests across all
+	// the priority bands. Defaults to 0, which means no global limit.
+	MaxBytes uint64 `json:"maxBytes,omitempty"`
+
+	// +required
+	// +kubebuilder:validation:Required
+	// PriorityBands is the list of priority bands. Each band serves the
+	// requests whose criticality is in its range. The ranges must not
+	// overlap, and must cover all the criticalities. The band with the
+	// highest criticalities has the highest priority.
+	PriorityBands []PriorityBand `json:"priorityBands"`
+}
+
+func (fc FlowControlConfig) String() string {
+	return fmt.Sprintf("{MaxBytes: %d, PriorityBands: %v}", fc.MaxBytes, fc.PriorityBands)
+}
+
+// PriorityBand describes a priority band of the flow control layer.
+type PriorityBand struct {
+	// +required
+	// +kubebuilder:validation:Required
+	// Name is the unique name of the priority band, e.g. "Critical".
+	Name string `json:"name"`
+
+	// +required
+	// +kubebuilder:validation:Required
+	// Criticality is the range of the criticalities of the InferenceObjectives
+	// whose requests are served by the band.
+	Criticality CriticalityRange `json:"criticality"`
+
+	// +optional
+	// MaxBytes is the maximum total size of the queued requests of the band.
+	// Defaults to 0, which applies the default capacity of the flow registry.
+	MaxBytes uint64 `json:"maxBytes,omitempty"`
+
+	// +optional
+	// Queue is the registered name of the queue of the flows of the band,
+	// e.g. "ListQueue" or "MaxMinHeap". Defaults to "ListQueue".
+	Queue string `json:"queue,omitempty"`
+
+	// +optional
+	// IntraFlowDispatchPolicy is the registered name of the policy selecting
+	// the next request of a flow, e.g. "FCFS". Defaults to "FCFS".
+	IntraFlowDispatchPolicy string `json:"intraFlowDispatchPolicy,omitempty"`
+
+	// +optional
+	// InterFlowDispatchPolicy is the registered name of the policy selecting
+	// the next flow of the band, e.g. "BestHead" or "RoundRobin". Defaults to
+	// "BestHead".
+	InterFlowDispatchPolicy string `json:"interFlowDispatchPolicy,omitempty"`
+}
+
+func (pb PriorityBand) String() string {
+	return fmt.Sprintf("{Name: %s, Criticality: %v, MaxBytes: %d, Queue: %s, IntraFlowDispatchPolicy: %s, InterFlowDispatchPolicy: %s}",
+		pb.Name, pb.Criticality, pb.MaxBytes, pb.Queue, pb.IntraFlowDispatchPolicy, pb.InterFlowDispatchPolicy)
+}
+
+// CriticalityRange is an inclusive range of criticalities.
+type CriticalityRange struct {
+	// +optional
+	// Min is the lowest criticality of the range. If omitted, the range has
+	// no lower bound.
+	Min *int `json:"min,omitempty"`
+
+	// +optional
+	// Max is the highest criticality of the range. If omitted, the range has
+	// no upper bound.
+	Max *int `json:"max,omitempty"`
+}
+
+func (cr CriticalityRange) String() string {
+	bound := func(value *int, unbounded string) string {
+		if value == nil {
+			return unbounded
+		}
+		return strconv.Itoa(*value)
+	}
+	return fmt.Sprintf("[%s, %s]", bound(cr.Min, "-inf"), bound(cr.Max, "+inf"))
+}
@@ -22,9 +22,66 @@ package v1alpha1
 
 import (
 	"encoding/json"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BodyBasedRoutingConfig) DeepCopyInto(out *BodyBasedRoutingConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]PluginSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BodyBasedRoutingConfig.
+func (in *BodyBasedRoutingConfig) DeepCopy() *BodyBasedRoutingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BodyBasedRoutingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BodyBasedRoutingConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CriticalityRange) DeepCopyInto(out *CriticalityRange) {
+	*out = *in
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		*out = new(int)
+		**out = **in
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CriticalityRange.
+func (in *CriticalityRange) DeepCopy() *CriticalityRange {
+	if in == nil {
+		return nil
+	}
+	out := new(CriticalityRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EndpointPickerConfig) DeepCopyInto(out *EndpointPickerConfig) {
 	*out = *in
@@ -43,6 +100,16 @@ func (in *EndpointPickerConfig) DeepCopyInto(out *EndpointPickerConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SaturationDetector != nil {
+		in, out := &in.SaturationDetector, &out.SaturationDetector
+		*out = new(SaturationDetectorConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FlowControl != nil {
+		in, out := &in.FlowControl, &out.FlowControl
+		*out = new(FlowControlConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointPickerConfig.
@@ -63,6 +130,28 @@ func (in *EndpointPickerConfig) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlowControlConfig) DeepCopyInto(out *FlowControlConfig) {
+	*out = *in
+	if in.PriorityBands != nil {
+		in, out := &in.PriorityBands, &out.PriorityBands
+		*out = make([]PriorityBand, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowControlConfig.
+func (in *FlowControlConfig) DeepCopy() *FlowControlConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FlowControlConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PluginSpec) DeepCopyInto(out *PluginSpec) {
 	*out = *in
@@ -83,6 +172,57 @@ func (in *PluginSpec) DeepCopy() *PluginSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PriorityBand) DeepCopyInto(out *PriorityBand) {
+	*out = *in
+	in.Criticality.DeepCopyInto(&out.Criticality)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PriorityBand.
+func (in *PriorityBand) DeepCopy() *PriorityBand {
+	if in == nil {
+		return nil
+	}
+	out := new(PriorityBand)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SaturationDetectorConfig) DeepCopyInto(out *SaturationDetectorConfig) {
+	*out = *in
+	if in.QueueDepthThreshold != nil {
+		in, out := &in.QueueDepthThreshold, &out.QueueDepthThreshold
+		*out = new(int)
+		**out = **in
+	}
+	if in.KVCacheUtilThreshold != nil {
+		in, out := &in.KVCacheUtilThreshold, &out.KVCacheUtilThreshold
+		*out = new(float64)
+		**out = **in
+	}
+	if in.MetricsStalenessThreshold != nil {
+		in, out := &in.MetricsStalenessThreshold, &out.MetricsStalenessThreshold
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.BypassCriticality != nil {
+		in, out := &in.BypassCriticality, &out.BypassCriticality
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SaturationDetectorConfig.
+func (in *SaturationDetectorConfig) DeepCopy() *SaturationDetectorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SaturationDetectorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SchedulingPlugin) DeepCopyInto(out *SchedulingPlugin) {
 	*out = *in
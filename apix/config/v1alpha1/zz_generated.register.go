@@ -61,6 +61,7 @@ func init() {
 // Adds the list of known types to Scheme.
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
+		&BodyBasedRoutingConfig{},
 		&EndpointPickerConfig{},
 	)
 	// AddToGroupVersion allows the serialization of client types like ListOptions.
@@ -28,6 +28,8 @@ import (
 // +kubebuilder:printcolumn:name="Model Name",type=string,JSONPath=`.spec.modelName`
 // +kubebuilder:printcolumn:name="Inference Pool",type=string,JSONPath=`.spec.poolRef.name`
 // +kubebuilder:printcolumn:name="Criticality",type=string,JSONPath=`.spec.criticality`
+// +kubebuilder:printcolumn:name="Latency Met",type=boolean,JSONPath=`.status.latency.met`,priority=1
+// +kubebuilder:printcolumn:name="Last Request",type=date,JSONPath=`.status.lastRequestTime`,priority=1
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +genclient
 type InferenceObjective struct {
@@ -79,12 +81,88 @@ type InferenceObjectiveSpec struct {
 	// +optional
 	Criticality *int `json:"criticality,omitempty"`
 
+	// Latency defines the latency goals of the requests of this objective. The Endpoint Picker
+	// passes them to the scheduling plugins, and reports how well they are met in the status.
+	//
+	// +optional
+	Latency *LatencyObjective `json:"latency,omitempty"`
+
+	// RateLimit caps the rate of requests and tokens of this objective. Requests exceeding it are
+	// rejected by the Endpoint Picker with a 429.
+	//
+	// +optional
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+
 	// PoolRef is a reference to the inference pool, the pool must exist in the same namespace.
 	//
 	// +kubebuilder:validation:Required
 	PoolRef PoolObjectReference `json:"poolRef"`
 }
 
+// LatencyObjective defines latency targets to be met by a percentile of the requests.
+//
+// +kubebuilder:validation:XValidation:message="at least one of targetTTFT or targetTPOT must be set",rule="has(self.targetTTFT) || has(self.targetTPOT)"
+type LatencyObjective struct {
+	// TargetTTFT is the target time to first token, measured from the reception of the request by
+	// the gateway to the first byte of the response body.
+	//
+	// +optional
+	TargetTTFT *metav1.Duration `json:"targetTTFT,omitempty"`
+
+	// TargetTPOT is the target time per output token, after the first one. It is only observed on
+	// streamed responses reporting their token usage.
+	//
+	// +optional
+	TargetTPOT *metav1.Duration `json:"targetTPOT,omitempty"`
+
+	// Percentile is the percentage of requests expected to meet the targets.
+	//
+	// +optional
+	// +kubebuilder:default=90
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Percentile *int32 `json:"percentile,omitempty"`
+}
+
+// RateLimitKey determines which requests share a rate limit.
+//
+// +kubebuilder:validation:Enum=Objective;FairnessID
+type RateLimitKey string
+
+const (
+	// RateLimitKeyObjective applies the rate limit to all the requests of the objective.
+	RateLimitKeyObjective RateLimitKey = "Objective"
+	// RateLimitKeyFairnessID applies the rate limit to the requests of each fairness ID of the
+	// objective separately.
+	RateLimitKeyFairnessID RateLimitKey = "FairnessID"
+)
+
+// RateLimit defines request and token rate limits. The limits are enforced with token buckets
+// holding one minute worth of the rate, so short bursts up to the per minute limit are allowed.
+//
+// +kubebuilder:validation:XValidation:message="at least one of requestsPerMinute or tokensPerMinute must be set",rule="has(self.requestsPerMinute) || has(self.tokensPerMinute)"
+type RateLimit struct {
+	// RequestsPerMinute is the maximum number of requests per minute.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	RequestsPerMinute *int64 `json:"requestsPerMinute,omitempty"`
+
+	// TokensPerMinute is the maximum number of prompt and completion tokens per minute. The prompt
+	// tokens are estimated when the request is received, and corrected along with the charge of the
+	// completion tokens when the response reports its usage.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TokensPerMinute *int64 `json:"tokensPerMinute,omitempty"`
+
+	// Key determines which requests share the limits. Defaults to Objective.
+	//
+	// +optional
+	// +kubebuilder:default=Objective
+	Key RateLimitKey `json:"key,omitempty"`
+}
+
 // PoolObjectReference identifies an API object within the namespace of the
 // referrer.
 type PoolObjectReference struct {
@@ -113,13 +191,59 @@ type InferenceObjectiveStatus struct {
 	// Known condition types are:
 	//
 	// * "Accepted"
+	// * "ResolvedRefs"
 	//
 	// +optional
 	// +listType=map
 	// +listMapKey=type
 	// +kubebuilder:validation:MaxItems=8
-	// +kubebuilder:default={{type: "Ready", status: "Unknown", reason:"Pending", message:"Waiting for controller", lastTransitionTime: "1970-01-01T00:00:00Z"}}
+	// +kubebuilder:default={{type: "Accepted", status: "Unknown", reason:"Pending", message:"Waiting for controller", lastTransitionTime: "1970-01-01T00:00:00Z"}}
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// LastRequestTime is the last time the Endpoint Picker received a request for this objective.
+	// It is updated at the status sync interval of the Endpoint Picker.
+	//
+	// +optional
+	LastRequestTime *metav1.Time `json:"lastRequestTime,omitempty"`
+
+	// Latency is the latency observed by the Endpoint Picker for the requests of this objective,
+	// set when the objective defines latency targets.
+	//
+	// +optional
+	Latency *LatencyAttainment `json:"latency,omitempty"`
+}
+
+// LatencyAttainment is the observed latency of the recent requests of an objective, compared to
+// its targets.
+type LatencyAttainment struct {
+	// ObservedTTFT is the time to first token at the objective percentile.
+	//
+	// +optional
+	ObservedTTFT *metav1.Duration `json:"observedTTFT,omitempty"`
+
+	// ObservedTPOT is the time per output token at the objective percentile.
+	//
+	// +optional
+	ObservedTPOT *metav1.Duration `json:"observedTPOT,omitempty"`
+
+	// TTFTAttainment is the percentage of requests which met the target time to first token.
+	//
+	// +optional
+	TTFTAttainment *int32 `json:"ttftAttainment,omitempty"`
+
+	// TPOTAttainment is the percentage of requests which met the target time per output token.
+	//
+	// +optional
+	TPOTAttainment *int32 `json:"tpotAttainment,omitempty"`
+
+	// Met is true when the observed latencies meet all the targets.
+	Met bool `json:"met"`
+
+	// SampleCount is the number of requests the attainment is computed from.
+	SampleCount int32 `json:"sampleCount"`
+
+	// LastUpdateTime is the last time the attainment was computed.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
 }
 
 // InferenceObjectiveConditionType is a type of condition for the InferenceObjective.
@@ -138,6 +262,7 @@ const (
 	// Possible reasons for this condition to be False are:
 	//
 	// * "ModelNameInUse"
+	// * "PoolNotFound"
 	//
 	// Possible reasons for this condition to be Unknown are:
 	//
@@ -154,4 +279,22 @@ const (
 
 	// ObjectiveReasonPending is the initial state, and indicates that the controller has not yet reconciled the InferenceObjective.
 	ObjectiveReasonPending InferenceObjectiveConditionReason = "Pending"
+
+	// ObjectiveConditionResolvedRefs indicates whether the pool referenced by the objective exists.
+	//
+	// Possible reasons for this condition to be True are:
+	//
+	// * "ResolvedRefs"
+	//
+	// Possible reasons for this condition to be False are:
+	//
+	// * "PoolNotFound"
+	//
+	ObjectiveConditionResolvedRefs InferenceObjectiveConditionType = "ResolvedRefs"
+
+	// ObjectiveReasonResolvedRefs is used when the referenced pool exists.
+	ObjectiveReasonResolvedRefs InferenceObjectiveConditionReason = "ResolvedRefs"
+
+	// ObjectiveReasonPoolNotFound is used when the referenced pool doesn't exist.
+	ObjectiveReasonPoolNotFound InferenceObjectiveConditionReason = "PoolNotFound"
 )
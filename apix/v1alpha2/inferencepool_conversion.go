@@ -0,0 +1,171 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+// ConvertTo converts this InferencePool to the v1 hub version. The type meta of the destination is
+// left to the caller.
+func (src *InferencePool) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1.InferencePool)
+	if !ok {
+		return fmt.Errorf("expected a v1 InferencePool, got %T", dstRaw)
+	}
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	dst.Spec.Selector = nil
+	if src.Spec.Selector != nil {
+		dst.Spec.Selector = make(map[v1.LabelKey]v1.LabelValue, len(src.Spec.Selector))
+		for key, value := range src.Spec.Selector {
+			dst.Spec.Selector[v1.LabelKey(key)] = v1.LabelValue(value)
+		}
+	}
+	dst.Spec.TargetPortNumber = src.Spec.TargetPortNumber
+	dst.Spec.TargetPorts = nil
+	if src.Spec.TargetPorts != nil {
+		dst.Spec.TargetPorts = make([]v1.PortNumber, len(src.Spec.TargetPorts))
+		for i, port := range src.Spec.TargetPorts {
+			dst.Spec.TargetPorts[i] = v1.PortNumber(port)
+		}
+	}
+	dst.Spec.ExtensionRef = nil
+	if ext := src.Spec.ExtensionRef; ext != nil {
+		dst.Spec.ExtensionRef = &v1.Extension{
+			ExtensionReference: v1.ExtensionReference{
+				Group:      convertPtr[Group, v1.Group](ext.Group),
+				Kind:       convertPtr[Kind, v1.Kind](ext.Kind),
+				Name:       v1.ObjectName(ext.Name),
+				PortNumber: convertPortPtr[PortNumber, v1.PortNumber](ext.PortNumber),
+			},
+			ExtensionConnection: v1.ExtensionConnection{
+				FailureMode: convertPtr[ExtensionFailureMode, v1.ExtensionFailureMode](ext.FailureMode),
+			},
+		}
+	}
+
+	dst.Status.Parents = nil
+	if src.Status.Parents != nil {
+		dst.Status.Parents = make([]v1.PoolStatus, len(src.Status.Parents))
+		for i, parent := range src.Status.Parents {
+			dst.Status.Parents[i] = v1.PoolStatus{
+				GatewayRef: v1.ParentGatewayReference{
+					Group:     convertPtr[Group, v1.Group](parent.GatewayRef.Group),
+					Kind:      convertPtr[Kind, v1.Kind](parent.GatewayRef.Kind),
+					Name:      v1.ObjectName(parent.GatewayRef.Name),
+					Namespace: convertPtr[Namespace, v1.Namespace](parent.GatewayRef.Namespace),
+				},
+				Conditions: convertConditions(parent.Conditions),
+			}
+		}
+	}
+	return nil
+}
+
+// ConvertFrom converts the v1 hub version to this InferencePool. The type meta of this
+// InferencePool is left to the caller.
+func (dst *InferencePool) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1.InferencePool)
+	if !ok {
+		return fmt.Errorf("expected a v1 InferencePool, got %T", srcRaw)
+	}
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	dst.Spec.Selector = nil
+	if src.Spec.Selector != nil {
+		dst.Spec.Selector = make(map[LabelKey]LabelValue, len(src.Spec.Selector))
+		for key, value := range src.Spec.Selector {
+			dst.Spec.Selector[LabelKey(key)] = LabelValue(value)
+		}
+	}
+	dst.Spec.TargetPortNumber = src.Spec.TargetPortNumber
+	dst.Spec.TargetPorts = nil
+	if src.Spec.TargetPorts != nil {
+		dst.Spec.TargetPorts = make([]PortNumber, len(src.Spec.TargetPorts))
+		for i, port := range src.Spec.TargetPorts {
+			dst.Spec.TargetPorts[i] = PortNumber(port)
+		}
+	}
+	dst.Spec.ExtensionRef = nil
+	if ext := src.Spec.ExtensionRef; ext != nil {
+		dst.Spec.ExtensionRef = &Extension{
+			ExtensionReference: ExtensionReference{
+				Group:      convertPtr[v1.Group, Group](ext.Group),
+				Kind:       convertPtr[v1.Kind, Kind](ext.Kind),
+				Name:       ObjectName(ext.Name),
+				PortNumber: convertPortPtr[v1.PortNumber, PortNumber](ext.PortNumber),
+			},
+			ExtensionConnection: ExtensionConnection{
+				FailureMode: convertPtr[v1.ExtensionFailureMode, ExtensionFailureMode](ext.FailureMode),
+			},
+		}
+	}
+
+	dst.Status.Parents = nil
+	if src.Status.Parents != nil {
+		dst.Status.Parents = make([]PoolStatus, len(src.Status.Parents))
+		for i, parent := range src.Status.Parents {
+			dst.Status.Parents[i] = PoolStatus{
+				GatewayRef: ParentGatewayReference{
+					Group:     convertPtr[v1.Group, Group](parent.GatewayRef.Group),
+					Kind:      convertPtr[v1.Kind, Kind](parent.GatewayRef.Kind),
+					Name:      ObjectName(parent.GatewayRef.Name),
+					Namespace: convertPtr[v1.Namespace, Namespace](parent.GatewayRef.Namespace),
+				},
+				Conditions: convertConditions(parent.Conditions),
+			}
+		}
+	}
+	return nil
+}
+
+// convertPtr converts a pointer to a string of a named type to a pointer to a copy of the string
+// in another named type.
+func convertPtr[S ~string, D ~string](src *S) *D {
+	if src == nil {
+		return nil
+	}
+	dst := D(*src)
+	return &dst
+}
+
+// convertPortPtr converts a pointer to a port number of a named type to a pointer to a copy of the
+// port number in another named type.
+func convertPortPtr[S ~int32, D ~int32](src *S) *D {
+	if src == nil {
+		return nil
+	}
+	dst := D(*src)
+	return &dst
+}
+
+func convertConditions(conditions []metav1.Condition) []metav1.Condition {
+	if conditions == nil {
+		return nil
+	}
+	converted := make([]metav1.Condition, len(conditions))
+	for i := range conditions {
+		conditions[i].DeepCopyInto(&converted[i])
+	}
+	return converted
+}
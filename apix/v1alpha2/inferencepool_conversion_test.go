@@ -0,0 +1,130 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/randfill"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+// fillPools fills a v1alpha2 and a v1 InferencePool with random values. The type meta is left
+// empty, since the conversions leave it to the caller.
+func fillPools(filler *randfill.Filler) (*InferencePool, *v1.InferencePool) {
+	filler = filler.NilChance(0.2).NumElements(0, 3).Funcs(
+		func(meta *metav1.TypeMeta, _ randfill.Continue) {},
+		func(fields *metav1.FieldsV1, c randfill.Continue) {
+			fields.Raw = []byte(`{"f:spec":{}}`)
+		},
+		// The serialized times have a second precision.
+		func(t *metav1.Time, c randfill.Continue) {
+			*t = metav1.Unix(c.Int63n(1<<32), 0)
+		},
+	)
+	spoke := &InferencePool{}
+	filler.Fill(spoke)
+	hub := &v1.InferencePool{}
+	filler.Fill(hub)
+	return spoke, hub
+}
+
+// assertRoundTrip asserts that the pools are unchanged by a round trip through the other version,
+// and that the converted pools have the same serialization as the original ones.
+func assertRoundTrip(t *testing.T, spoke *InferencePool, hub *v1.InferencePool) {
+	t.Helper()
+	convertedHub := &v1.InferencePool{}
+	if err := spoke.ConvertTo(convertedHub); err != nil {
+		t.Fatalf("Failed to convert to v1: %v", err)
+	}
+	roundTripSpoke := &InferencePool{}
+	if err := roundTripSpoke.ConvertFrom(convertedHub); err != nil {
+		t.Fatalf("Failed to convert from v1: %v", err)
+	}
+	if diff := cmp.Diff(spoke, roundTripSpoke); diff != "" {
+		t.Errorf("Unexpected v1alpha2 round trip (-want +got): %s", diff)
+	}
+	assertSameJSON(t, spoke, convertedHub)
+
+	convertedSpoke := &InferencePool{}
+	if err := convertedSpoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("Failed to convert from v1: %v", err)
+	}
+	roundTripHub := &v1.InferencePool{}
+	if err := convertedSpoke.ConvertTo(roundTripHub); err != nil {
+		t.Fatalf("Failed to convert to v1: %v", err)
+	}
+	if diff := cmp.Diff(hub, roundTripHub); diff != "" {
+		t.Errorf("Unexpected v1 round trip (-want +got): %s", diff)
+	}
+	assertSameJSON(t, hub, convertedSpoke)
+}
+
+// assertSameJSON asserts that both pools are serialized the same way, since both versions share
+// the same schema.
+func assertSameJSON(t *testing.T, want, got any) {
+	t.Helper()
+	wantJSON, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("Failed to marshal %T: %v", want, err)
+	}
+	gotJSON, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("Failed to marshal %T: %v", got, err)
+	}
+	if string(wantJSON) != string(gotJSON) {
+		t.Errorf("Unexpected serialization of the converted %T\nwant: %s\ngot:  %s", got, wantJSON, gotJSON)
+	}
+}
+
+func TestInferencePoolConversionRoundTrip(t *testing.T) {
+	for seed := range int64(1000) {
+		spoke, hub := fillPools(randfill.NewWithSeed(seed))
+		assertRoundTrip(t, spoke, hub)
+	}
+}
+
+func TestInferencePoolConversionEmpty(t *testing.T) {
+	assertRoundTrip(t, &InferencePool{}, &v1.InferencePool{})
+}
+
+func TestInferencePoolConversionWrongHub(t *testing.T) {
+	other := &otherHub{}
+	if err := (&InferencePool{}).ConvertTo(other); err == nil {
+		t.Error("Expected an error converting to an unknown hub")
+	}
+	if err := (&InferencePool{}).ConvertFrom(other); err == nil {
+		t.Error("Expected an error converting from an unknown hub")
+	}
+}
+
+type otherHub struct {
+	v1.InferencePool
+}
+
+func FuzzInferencePoolConversion(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte("inference pool"))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		spoke, hub := fillPools(randfill.NewFromGoFuzz(data))
+		assertRoundTrip(t, spoke, hub)
+	})
+}
@@ -179,6 +179,16 @@ func (in *InferenceObjectiveSpec) DeepCopyInto(out *InferenceObjectiveSpec) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.Latency != nil {
+		in, out := &in.Latency, &out.Latency
+		*out = new(LatencyObjective)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimit)
+		(*in).DeepCopyInto(*out)
+	}
 	out.PoolRef = in.PoolRef
 }
 
@@ -202,6 +212,15 @@ func (in *InferenceObjectiveStatus) DeepCopyInto(out *InferenceObjectiveStatus)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastRequestTime != nil {
+		in, out := &in.LastRequestTime, &out.LastRequestTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Latency != nil {
+		in, out := &in.Latency, &out.Latency
+		*out = new(LatencyAttainment)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceObjectiveStatus.
@@ -283,6 +302,11 @@ func (in *InferencePoolSpec) DeepCopyInto(out *InferencePoolSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.TargetPorts != nil {
+		in, out := &in.TargetPorts, &out.TargetPorts
+		*out = make([]PortNumber, len(*in))
+		copy(*out, *in)
+	}
 	in.EndpointPickerConfig.DeepCopyInto(&out.EndpointPickerConfig)
 }
 
@@ -318,6 +342,72 @@ func (in *InferencePoolStatus) DeepCopy() *InferencePoolStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LatencyAttainment) DeepCopyInto(out *LatencyAttainment) {
+	*out = *in
+	if in.ObservedTTFT != nil {
+		in, out := &in.ObservedTTFT, &out.ObservedTTFT
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ObservedTPOT != nil {
+		in, out := &in.ObservedTPOT, &out.ObservedTPOT
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.TTFTAttainment != nil {
+		in, out := &in.TTFTAttainment, &out.TTFTAttainment
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TPOTAttainment != nil {
+		in, out := &in.TPOTAttainment, &out.TPOTAttainment
+		*out = new(int32)
+		**out = **in
+	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LatencyAttainment.
+func (in *LatencyAttainment) DeepCopy() *LatencyAttainment {
+	if in == nil {
+		return nil
+	}
+	out := new(LatencyAttainment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LatencyObjective) DeepCopyInto(out *LatencyObjective) {
+	*out = *in
+	if in.TargetTTFT != nil {
+		in, out := &in.TargetTTFT, &out.TargetTTFT
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.TargetTPOT != nil {
+		in, out := &in.TargetTPOT, &out.TargetTPOT
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Percentile != nil {
+		in, out := &in.Percentile, &out.Percentile
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LatencyObjective.
+func (in *LatencyObjective) DeepCopy() *LatencyObjective {
+	if in == nil {
+		return nil
+	}
+	out := new(LatencyObjective)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ParentGatewayReference) DeepCopyInto(out *ParentGatewayReference) {
 	*out = *in
@@ -385,3 +475,28 @@ func (in *PoolStatus) DeepCopy() *PoolStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimit) DeepCopyInto(out *RateLimit) {
+	*out = *in
+	if in.RequestsPerMinute != nil {
+		in, out := &in.RequestsPerMinute, &out.RequestsPerMinute
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TokensPerMinute != nil {
+		in, out := &in.TokensPerMinute, &out.TokensPerMinute
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimit.
+func (in *RateLimit) DeepCopy() *RateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
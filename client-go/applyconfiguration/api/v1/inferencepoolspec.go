@@ -27,6 +27,7 @@ import (
 type InferencePoolSpecApplyConfiguration struct {
 	Selector                               map[apiv1.LabelKey]apiv1.LabelValue `json:"selector,omitempty"`
 	TargetPortNumber                       *int32                              `json:"targetPortNumber,omitempty"`
+	TargetPorts                            []apiv1.PortNumber                  `json:"targetPorts,omitempty"`
 	EndpointPickerConfigApplyConfiguration `json:",inline"`
 }
 
@@ -58,6 +59,16 @@ func (b *InferencePoolSpecApplyConfiguration) WithTargetPortNumber(value int32)
 	return b
 }
 
+// WithTargetPorts adds the given value to the TargetPorts field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the TargetPorts field.
+func (b *InferencePoolSpecApplyConfiguration) WithTargetPorts(values ...apiv1.PortNumber) *InferencePoolSpecApplyConfiguration {
+	for i := range values {
+		b.TargetPorts = append(b.TargetPorts, values[i])
+	}
+	return b
+}
+
 // WithExtensionRef sets the ExtensionRef field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ExtensionRef field is set to the value of the last call.
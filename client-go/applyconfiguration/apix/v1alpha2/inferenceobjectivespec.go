@@ -22,6 +22,8 @@ package v1alpha2
 // with apply.
 type InferenceObjectiveSpecApplyConfiguration struct {
 	Criticality *int                                   `json:"criticality,omitempty"`
+	Latency     *LatencyObjectiveApplyConfiguration    `json:"latency,omitempty"`
+	RateLimit   *RateLimitApplyConfiguration           `json:"rateLimit,omitempty"`
 	PoolRef     *PoolObjectReferenceApplyConfiguration `json:"poolRef,omitempty"`
 }
 
@@ -39,6 +41,22 @@ func (b *InferenceObjectiveSpecApplyConfiguration) WithCriticality(value int) *I
 	return b
 }
 
+// WithLatency sets the Latency field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Latency field is set to the value of the last call.
+func (b *InferenceObjectiveSpecApplyConfiguration) WithLatency(value *LatencyObjectiveApplyConfiguration) *InferenceObjectiveSpecApplyConfiguration {
+	b.Latency = value
+	return b
+}
+
+// WithRateLimit sets the RateLimit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RateLimit field is set to the value of the last call.
+func (b *InferenceObjectiveSpecApplyConfiguration) WithRateLimit(value *RateLimitApplyConfiguration) *InferenceObjectiveSpecApplyConfiguration {
+	b.RateLimit = value
+	return b
+}
+
 // WithPoolRef sets the PoolRef field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the PoolRef field is set to the value of the last call.
@@ -19,13 +19,16 @@ limitations under the License.
 package v1alpha2
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
 )
 
 // InferenceObjectiveStatusApplyConfiguration represents a declarative configuration of the InferenceObjectiveStatus type for use
 // with apply.
 type InferenceObjectiveStatusApplyConfiguration struct {
-	Conditions []v1.ConditionApplyConfiguration `json:"conditions,omitempty"`
+	Conditions      []v1.ConditionApplyConfiguration     `json:"conditions,omitempty"`
+	LastRequestTime *metav1.Time                         `json:"lastRequestTime,omitempty"`
+	Latency         *LatencyAttainmentApplyConfiguration `json:"latency,omitempty"`
 }
 
 // InferenceObjectiveStatusApplyConfiguration constructs a declarative configuration of the InferenceObjectiveStatus type for use with
@@ -46,3 +49,19 @@ func (b *InferenceObjectiveStatusApplyConfiguration) WithConditions(values ...*v
 	}
 	return b
 }
+
+// WithLastRequestTime sets the LastRequestTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastRequestTime field is set to the value of the last call.
+func (b *InferenceObjectiveStatusApplyConfiguration) WithLastRequestTime(value metav1.Time) *InferenceObjectiveStatusApplyConfiguration {
+	b.LastRequestTime = &value
+	return b
+}
+
+// WithLatency sets the Latency field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Latency field is set to the value of the last call.
+func (b *InferenceObjectiveStatusApplyConfiguration) WithLatency(value *LatencyAttainmentApplyConfiguration) *InferenceObjectiveStatusApplyConfiguration {
+	b.Latency = value
+	return b
+}
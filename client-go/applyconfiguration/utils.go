@@ -74,12 +74,18 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apixv1alpha2.InferencePoolSpecApplyConfiguration{}
 	case v1alpha2.SchemeGroupVersion.WithKind("InferencePoolStatus"):
 		return &apixv1alpha2.InferencePoolStatusApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("LatencyAttainment"):
+		return &apixv1alpha2.LatencyAttainmentApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("LatencyObjective"):
+		return &apixv1alpha2.LatencyObjectiveApplyConfiguration{}
 	case v1alpha2.SchemeGroupVersion.WithKind("ParentGatewayReference"):
 		return &apixv1alpha2.ParentGatewayReferenceApplyConfiguration{}
 	case v1alpha2.SchemeGroupVersion.WithKind("PoolObjectReference"):
 		return &apixv1alpha2.PoolObjectReferenceApplyConfiguration{}
 	case v1alpha2.SchemeGroupVersion.WithKind("PoolStatus"):
 		return &apixv1alpha2.PoolStatusApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("RateLimit"):
+		return &apixv1alpha2.RateLimitApplyConfiguration{}
 
 	}
 	return nil
@@ -17,24 +17,38 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/go-logr/logr"
 	uberzap "go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc"
 	healthPb "google.golang.org/grpc/health/grpc_health_v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	"sigs.k8s.io/gateway-api-inference-extension/internal/runnable"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/aliases"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/config/loader"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/metrics"
+	bbrplugins "sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/plugins/modelextractor"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/plugins/requestvalidator"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/rules"
 	runserver "sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/server"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 )
 
@@ -51,6 +65,20 @@ var (
 		"metrics-port", 9090, "The metrics port")
 	streaming = flag.Bool(
 		"streaming", false, "Enables streaming support for Envoy full-duplex streaming mode")
+	configFile = flag.String(
+		"config-file", "", "The path to the BodyBasedRoutingConfig file configuring the plugins.")
+	configText = flag.String(
+		"config-text", "", "The BodyBasedRoutingConfig configuring the plugins, as text.")
+	rulesFile = flag.String(
+		"rules-file", "", "Path of a YAML or JSON file with the body-to-header extraction rules. "+
+			"Defaults to copying the model name into the X-Gateway-Model-Name header.")
+	aliasesFile = flag.String(
+		"aliases-file", "", "Path of a YAML or JSON file with the model alias table. The file is reloaded when it changes.")
+	aliasesConfigMap = flag.String(
+		"aliases-configmap", "", "Namespace and name, as <namespace>/<name>, of a ConfigMap holding the model alias table. "+
+			"The ConfigMap is watched for changes.")
+	aliasesConfigMapKey = flag.String(
+		"aliases-configmap-key", aliases.DefaultConfigMapKey, "Key of the aliases ConfigMap holding the model alias table.")
 	logVerbosity = flag.Int("v", logging.DEFAULT, "number for the log level verbosity")
 
 	setupLog = ctrl.Log.WithName("setup")
@@ -68,6 +96,26 @@ func run() error {
 	flag.Parse()
 	initLogging(&opts)
 
+	if err := validateFlags(); err != nil {
+		setupLog.Error(err, "Invalid flags")
+		return err
+	}
+	if *aliasesFile != "" && *aliasesConfigMap != "" {
+		err := fmt.Errorf("both the %q and %q flags can not be set at the same time", "aliases-file", "aliases-configmap")
+		setupLog.Error(err, "Invalid flags")
+		return err
+	}
+	var aliasesConfigMapName types.NamespacedName
+	if *aliasesConfigMap != "" {
+		namespace, name, ok := strings.Cut(*aliasesConfigMap, "/")
+		if !ok || namespace == "" || name == "" {
+			err := fmt.Errorf("invalid %q flag - must be <namespace>/<name>", "aliases-configmap")
+			setupLog.Error(err, "Invalid flags")
+			return err
+		}
+		aliasesConfigMapName = types.NamespacedName{Namespace: namespace, Name: name}
+	}
+
 	// Print all flag values
 	flags := make(map[string]any)
 	flag.VisitAll(func(f *flag.Flag) {
@@ -93,7 +141,19 @@ func run() error {
 		BindAddress:    fmt.Sprintf(":%d", *metricsPort),
 		FilterProvider: filters.WithAuthenticationAndAuthorization,
 	}
-	mgr, err := ctrl.NewManager(cfg, ctrl.Options{Metrics: metricsServerOptions})
+	mgrOpts := ctrl.Options{Metrics: metricsServerOptions}
+	if *aliasesConfigMap != "" {
+		// Only cache the aliases ConfigMap.
+		mgrOpts.Cache = cache.Options{
+			ByObject: map[client.Object]cache.ByObject{
+				&corev1.ConfigMap{}: {
+					Namespaces: map[string]cache.Config{aliasesConfigMapName.Namespace: {}},
+					Field:      fields.OneTermEqualSelector("metadata.name", aliasesConfigMapName.Name),
+				},
+			},
+		}
+	}
+	mgr, err := ctrl.NewManager(cfg, mgrOpts)
 	if err != nil {
 		setupLog.Error(err, "Failed to create manager", "config", cfg)
 		return err
@@ -103,6 +163,39 @@ func run() error {
 
 	// Setup runner.
 	serverRunner := runserver.NewDefaultExtProcServerRunner(*grpcPort, *streaming)
+	requestBodyPlugins, err := loadRequestBodyPlugins(ctx)
+	if err != nil {
+		setupLog.Error(err, "Failed to load plugins")
+		return err
+	}
+	serverRunner.RequestBodyPlugins = requestBodyPlugins
+
+	// Setup model aliases.
+	if *aliasesFile != "" {
+		serverRunner.Aliases = aliases.NewResolver()
+		watcher := aliases.NewFileWatcher(*aliasesFile, aliases.DefaultFilePollInterval, serverRunner.Aliases)
+		if err := watcher.Load(ctx); err != nil {
+			setupLog.Error(err, "Failed to load aliases", "aliasesFile", *aliasesFile)
+			return err
+		}
+		if err := mgr.Add(watcher); err != nil {
+			setupLog.Error(err, "Failed to register aliases file watcher")
+			return err
+		}
+	}
+	if *aliasesConfigMap != "" {
+		serverRunner.Aliases = aliases.NewResolver()
+		reconciler := &aliases.ConfigMapReconciler{
+			Reader:    mgr.GetClient(),
+			ConfigMap: aliasesConfigMapName,
+			Key:       *aliasesConfigMapKey,
+			Resolver:  serverRunner.Aliases,
+		}
+		if err := reconciler.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "Failed to setup aliases ConfigMap reconciler")
+			return err
+		}
+	}
 
 	// Register health server.
 	if err := registerHealthServer(mgr, ctrl.Log.WithName("health"), *grpcHealthPort); err != nil {
@@ -125,6 +218,48 @@ func run() error {
 	return nil
 }
 
+func validateFlags() error {
+	if *configText != "" && *configFile != "" {
+		return fmt.Errorf("both the %q and %q flags can not be set at the same time", "config-text", "config-file")
+	}
+	if *rulesFile != "" && (*configText != "" || *configFile != "") {
+		return fmt.Errorf("the %q flag can not be set with a configuration, use the %q plugin parameters instead", "rules-file", modelextractor.ModelExtractorType)
+	}
+	return nil
+}
+
+func registerInTreePlugins() {
+	bbrplugins.Register(modelextractor.ModelExtractorType, modelextractor.ModelExtractorFactory)
+	bbrplugins.Register(requestvalidator.RequestValidatorType, requestvalidator.RequestValidatorFactory)
+}
+
+// loadRequestBodyPlugins returns the request body plugins of the configuration, if any. Otherwise,
+// it returns the model extractor with the rules of the rules file, if any.
+func loadRequestBodyPlugins(ctx context.Context) ([]bbrplugins.RequestBodyPlugin, error) {
+	if *configText == "" && *configFile == "" {
+		if *rulesFile == "" {
+			return nil, nil
+		}
+		ruleSet, err := rules.LoadFile(*rulesFile)
+		if err != nil {
+			return nil, err
+		}
+		return []bbrplugins.RequestBodyPlugin{modelextractor.New(ruleSet)}, nil
+	}
+
+	configBytes := []byte(*configText)
+	if *configFile != "" {
+		var err error
+		configBytes, err = os.ReadFile(*configFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load config from a file '%s' - %w", *configFile, err)
+		}
+	}
+
+	registerInTreePlugins()
+	return loader.LoadConfig(configBytes, plugins.NewEppHandle(ctx), setupLog)
+}
+
 // registerHealthServer adds the Health gRPC server as a Runnable to the given manager.
 func registerHealthServer(mgr manager.Manager, logger logr.Logger, port int) error {
 	srv := grpc.NewServer()
@@ -18,6 +18,7 @@ package runner
 
 import (
 	"context"
+	"time"
 
 	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
 	"github.com/go-logr/logr"
@@ -25,13 +26,23 @@ import (
 	healthPb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
 
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/handlers"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 )
 
+// livenessService is the service name of the liveness checks. Any other service name checks the
+// readiness of the EPP.
+const livenessService = "liveness"
+
 type healthServer struct {
 	logger    logr.Logger
 	datastore datastore.Datastore
+	// drainer reports NOT_SERVING readiness once the EPP is shutting down.
+	drainer *handlers.Drainer
+	// stalenessThreshold is the maximum age of the metrics of a pod for the EPP to be ready.
+	stalenessThreshold time.Duration
 }
 
 func (s *healthServer) Check(ctx context.Context, in *healthPb.HealthCheckRequest) (*healthPb.HealthCheckResponse, error) {
@@ -42,14 +53,36 @@ func (s *healthServer) Check(ctx context.Context, in *healthPb.HealthCheckReques
 	// 	return &healthPb.HealthCheckResponse{Status: healthPb.HealthCheckResponse_SERVICE_UNKNOWN}, nil
 	// }
 
-	if !s.datastore.PoolHasSynced() {
-		s.logger.V(logutil.DEFAULT).Info("gRPC health check not serving", "service", in.Service)
+	if in.Service == livenessService {
+		// The EPP is live as long as it answers, including while draining.
+		return &healthPb.HealthCheckResponse{Status: healthPb.HealthCheckResponse_SERVING}, nil
+	}
+	if reason := s.notReadyReason(); reason != "" {
+		s.logger.V(logutil.DEFAULT).Info("gRPC health check not serving", "service", in.Service, "reason", reason)
 		return &healthPb.HealthCheckResponse{Status: healthPb.HealthCheckResponse_NOT_SERVING}, nil
 	}
 	s.logger.V(logutil.TRACE).Info("gRPC health check serving", "service", in.Service)
 	return &healthPb.HealthCheckResponse{Status: healthPb.HealthCheckResponse_SERVING}, nil
 }
 
+// notReadyReason returns why the EPP isn't ready to serve requests, or an empty string if it is.
+func (s *healthServer) notReadyReason() string {
+	if s.drainer != nil && s.drainer.Draining() {
+		return "draining"
+	}
+	if !s.datastore.PoolHasSynced() {
+		return "InferencePool not synced"
+	}
+	fresh := s.datastore.PodList(func(pm backendmetrics.PodMetrics) bool {
+		metrics := pm.GetMetrics()
+		return metrics != nil && time.Since(metrics.UpdateTime) <= s.stalenessThreshold
+	})
+	if len(fresh) == 0 {
+		return "no pod with fresh metrics"
+	}
+	return ""
+}
+
 func (s *healthServer) List(ctx context.Context, _ *healthPb.HealthListRequest) (*healthPb.HealthListResponse, error) {
 	// currently only the ext_proc service is provided
 	serviceHealthResponse, err := s.Check(ctx, &healthPb.HealthCheckRequest{Service: extProcPb.ExternalProcessor_ServiceDesc.ServiceName})
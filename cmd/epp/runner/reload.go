@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/config"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/config/loader"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling"
+)
+
+// configReloader applies the changes of the configuration file to the director. Its reload method
+// is called by a single watcher, hence it isn't safe for concurrent use.
+type configReloader struct {
+	director *requestcontrol.Director
+	// baseRequestControlConfig is the request control config set through code, to which the
+	// plugins of the configuration file are added.
+	baseRequestControlConfig *requestcontrol.Config
+	current                  *config.Config
+	version                  int
+	// flowControlEnabled is set by the --experimental-flow-control flag.
+	flowControlEnabled bool
+}
+
+// reload loads the new content of the configuration file, and atomically swaps the scheduler and
+// the request control plugins of the director. The plugins whose name, type and parameters did not
+// change are kept along with their state. An invalid configuration is rejected, and the director
+// keeps the previous one.
+func (c *configReloader) reload(ctx context.Context, content []byte) error {
+	logger := log.FromContext(ctx)
+	handle := plugins.NewEppHandle(ctx)
+	loaded, err := loader.ReloadConfig(content, handle, c.current, logger)
+	if err != nil {
+		metrics.RecordConfigReload(false)
+		return fmt.Errorf("failed to reload the configuration - %w", err)
+	}
+	if err := checkFlowControl(loaded, c.flowControlEnabled); err != nil {
+		metrics.RecordConfigReload(false)
+		return err
+	}
+
+	// The saturation detector and flow control are created at startup, and aren't reloaded.
+	if !reflect.DeepEqual(loaded.SaturationDetectorConfig, c.current.SaturationDetectorConfig) {
+		logger.Info("The saturationDetector section changed, restart the EPP to apply it")
+	}
+	if !reflect.DeepEqual(loaded.FlowControlConfig, c.current.FlowControlConfig) {
+		logger.Info("The flowControl section changed, restart the EPP to apply it")
+	}
+
+	requestControlConfig := c.baseRequestControlConfig.Clone()
+	requestControlConfig.AddPlugins(handle.GetAllPlugins()...)
+	c.director.UpdatePlugins(scheduling.NewSchedulerWithConfig(loaded.SchedulerConfig), requestControlConfig)
+	c.current = loaded
+	c.version++
+	metrics.RecordConfigReload(true)
+	metrics.RecordConfigVersion(c.version, configHash(content))
+	logger.Info("Reloaded the configuration", "version", c.version)
+	return nil
+}
+
+// checkFlowControl rejects the flowControl section unless the experimental flow control is enabled,
+// since its priority bands aren't enforced yet.
+func checkFlowControl(cfg *config.Config, enabled bool) error {
+	if cfg.FlowControlConfig != nil && !enabled {
+		return fmt.Errorf("the flowControl section is experimental and requires the %q flag", "experimental-flow-control")
+	}
+	return nil
+}
+
+// configHash returns the hex encoded SHA-256 of the configuration, which identifies it in metrics.
+func configHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/config/loader"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const reloadTestConfig = `
+apiVersion: inference.networking.x-k8s.io/v1alpha1
+kind: EndpointPickerConfig
+plugins:
+- type: prefix-cache-scorer
+  parameters:
+    hashBlockSize: 32
+- type: queue-scorer
+schedulingProfiles:
+- name: default
+  plugins:
+  - pluginRef: prefix-cache-scorer
+    weight: %d
+  - pluginRef: queue-scorer
+`
+
+const reloadTestFlowControl = `
+flowControl:
+  priorityBands:
+  - name: Standard
+`
+
+func TestConfigReloader(t *testing.T) {
+	(&Runner{}).registerInTreePlugins()
+	ctx := context.Background()
+
+	initial, err := loader.LoadConfig([]byte(reloadTestConfigWithWeight(1)), plugins.NewEppHandle(ctx), logutil.NewTestLogger())
+	require.NoError(t, err)
+	director := requestcontrol.NewDirectorWithConfig(nil, scheduling.NewSchedulerWithConfig(initial.SchedulerConfig), nil, requestcontrol.NewConfig())
+	reloader := &configReloader{
+		director:                 director,
+		baseRequestControlConfig: requestcontrol.NewConfig(),
+		current:                  initial,
+		version:                  1,
+	}
+
+	// A new weight keeps the prefix cache plugin and its index.
+	require.NoError(t, reloader.reload(ctx, []byte(reloadTestConfigWithWeight(5))))
+	assert.Equal(t, 2, reloader.version)
+	assert.Same(t, initial.Plugins["prefix-cache-scorer"].Plugin, reloader.current.Plugins["prefix-cache-scorer"].Plugin)
+
+	// An invalid configuration is rejected.
+	reloaded := reloader.current
+	assert.Error(t, reloader.reload(ctx, []byte("plugins: [")))
+	assert.Equal(t, 2, reloader.version)
+	assert.Same(t, reloaded, reloader.current)
+
+	// The flowControl section is rejected unless the experimental flow control is enabled.
+	withFlowControl := reloadTestConfigWithWeight(5) + reloadTestFlowControl
+	assert.Error(t, reloader.reload(ctx, []byte(withFlowControl)))
+	assert.Equal(t, 2, reloader.version)
+	reloader.flowControlEnabled = true
+	require.NoError(t, reloader.reload(ctx, []byte(withFlowControl)))
+	assert.Equal(t, 3, reloader.version)
+	assert.NotNil(t, reloader.current.FlowControlConfig)
+}
+
+func reloadTestConfigWithWeight(weight int) string {
+	return fmt.Sprintf(reloadTestConfig, weight)
+}
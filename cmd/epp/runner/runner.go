@@ -25,6 +25,8 @@ import (
 	"net/http"
 	"net/http/pprof"
 	"os"
+	"slices"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
@@ -34,22 +36,33 @@ import (
 	healthPb "google.golang.org/grpc/health/grpc_health_v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
-	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
 	"sigs.k8s.io/gateway-api-inference-extension/internal/runnable"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/common"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/common/validation"
 	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/config"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/config/loader"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datalayer"
+	dlmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datalayer/metrics"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/handlers"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics/collectors"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/objectives"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/outlierdetection"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/ratelimit"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol/plugins/redactor"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol/plugins/servedby"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/saturationdetector"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/multi/prefix"
@@ -58,7 +71,10 @@ import (
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/scorer"
 	testfilter "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/test/filter"
 	runserver "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/server"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/standalone"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/filewatch"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/tracing"
 	"sigs.k8s.io/gateway-api-inference-extension/version"
 )
 
@@ -82,7 +98,8 @@ var (
 	poolName = flag.String(
 		"pool-name",
 		runserver.DefaultPoolName,
-		"Name of the InferencePool this Endpoint Picker is associated with.")
+		"Name of the InferencePool this Endpoint Picker is associated with. "+
+			"Required unless the pool is read from the standalone configuration file.")
 	poolGroup = flag.String(
 		"pool-group",
 		runserver.DefaultPoolGroup,
@@ -110,10 +127,20 @@ var (
 			"are assumed to be named tls.crt and tls.key, respectively. If not set, and secureServing is enabled, "+
 			"then a self-signed certificate is used.")
 	// metric flags
+	modelServerType = flag.String(
+		"model-server-type",
+		runserver.DefaultModelServerType,
+		"Type of the model servers in the pool, used to set all the metric flags from a preset. "+
+			"Valid types are "+fmt.Sprint(backendmetrics.ModelServerTypes())+". Metric flags that are explicitly set take "+
+			"precedence over the preset. Pods can override the type with the '"+backendmetrics.ModelServerTypeAnnotation+"' annotation.")
 	totalQueuedRequestsMetric = flag.String(
 		"total-queued-requests-metric",
 		runserver.DefaultTotalQueuedRequestsMetric,
 		"Prometheus metric for the number of queued requests.")
+	totalRunningRequestsMetric = flag.String(
+		"total-running-requests-metric",
+		runserver.DefaultTotalRunningRequestsMetric,
+		"Prometheus metric for the number of running requests.")
 	kvCacheUsagePercentageMetric = flag.String(
 		"kv-cache-usage-percentage-metric",
 		runserver.DefaultKvCacheUsagePercentageMetric,
@@ -123,12 +150,100 @@ var (
 		"lora-info-metric",
 		runserver.DefaultLoraInfoMetric,
 		"Prometheus metric for the LoRA info metrics (must be in vLLM label format).")
+	// Cache info metrics
+	cacheInfoMetric = flag.String(
+		"cache-info-metric",
+		runserver.DefaultCacheInfoMetric,
+		"Prometheus metric for the KV-cache capacity. If the metric has the vLLM cache config info labels, "+
+			"the capacity in tokens is computed as num_gpu_blocks*block_size, otherwise the metric value is used.")
 
 	// metrics related flags
 	refreshMetricsInterval = flag.Duration(
 		"refresh-metrics-interval",
 		runserver.DefaultRefreshMetricsInterval,
 		"interval to refresh metrics")
+	metricsScrapeTimeout = flag.Duration(
+		"metrics-scrape-timeout",
+		runserver.DefaultMetricsScrapeTimeout,
+		"Timeout of a single model server metrics scrape.")
+	metricsScrapeWorkers = flag.Int(
+		"metrics-scrape-workers",
+		runserver.DefaultMetricsScrapeWorkers,
+		"Maximum number of concurrent model server metrics scrapes.")
+	metricsScrapeJitter = flag.Float64(
+		"metrics-scrape-jitter",
+		runserver.DefaultMetricsScrapeJitter,
+		"Fraction of the refresh interval, in [0, 1], by which scrapes are randomly shifted to spread the load over time.")
+	metricsScrapeMaxBackoff = flag.Duration(
+		"metrics-scrape-max-backoff",
+		runserver.DefaultMetricsScrapeMaxBackoff,
+		"Maximum interval between scrapes of a pod whose scrapes keep failing. The interval doubles on each consecutive failure.")
+	metricsScrapeMaxInterval = flag.Duration(
+		"metrics-scrape-max-interval",
+		runserver.DefaultMetricsScrapeMaxInterval,
+		"Maximum interval between scrapes of a pod whose metrics are kept fresh by in-band load reports. Zero disables skipping scrapes of such pods.")
+	metricsScrapeAllowConcurrent = flag.Bool(
+		"metrics-scrape-allow-concurrent",
+		runserver.DefaultMetricsScrapeAllowConcurrent,
+		"Scrape a pod again when its previous scrape is still running, rather than skipping the due scrape.")
+	// in-band load report flags
+	loadReportFormat = flag.String(
+		"load-report-format",
+		runserver.DefaultLoadReportFormat,
+		fmt.Sprintf("Format of the load reports attached by model servers to their response headers, one of %v. 'none' disables load reports.", backendmetrics.LoadReportFormats()))
+	loadReportHeader = flag.String(
+		"load-report-header",
+		runserver.DefaultLoadReportHeader,
+		"Response header carrying the load reports. Binary reports are also read from the header with a '-bin' suffix. The headers are removed from the response.")
+	loadReportQueuedRequestsMetric = flag.String(
+		"load-report-queued-requests-metric",
+		runserver.DefaultLoadReportQueuedRequestsMetric,
+		"Name of the load report metric for the number of queued requests. Empty disables it.")
+	loadReportRunningRequestsMetric = flag.String(
+		"load-report-running-requests-metric",
+		runserver.DefaultLoadReportRunningRequestsMetric,
+		"Name of the load report metric for the number of running requests. Empty disables it.")
+	loadReportKVCacheUsageMetric = flag.String(
+		"load-report-kv-cache-usage-metric",
+		runserver.DefaultLoadReportKVCacheUsageMetric,
+		"Name of the load report metric for the KV cache utilization. Empty disables it.")
+	processingMode = flag.String(
+		"processing-mode",
+		runserver.DefaultProcessingMode,
+		fmt.Sprintf("Ext-proc processing mode configured on the gateway, one of %v. HEADERS_ONLY schedules requests on the model name header set by Body-Based Routing, without receiving their body, and skips the RequestMutator plugins.", handlers.ProcessingModes()))
+	serveModels = flag.Bool(
+		"serve-models",
+		runserver.DefaultServeModels,
+		"Answers /v1/models requests from the EPP with the base models and LoRA adapters served across the pool, and the InferenceObjectives. When disabled, the requests are routed to a random pod.")
+	modelsReadiness = flag.Bool(
+		"models-readiness",
+		runserver.DefaultModelsReadiness,
+		"Adds the number of pods ready to serve each model to the /v1/models response.")
+	objectiveStatusSyncInterval = flag.Duration(
+		"objective-status-sync-interval",
+		runserver.DefaultObjectiveStatusSyncInterval,
+		"Interval at which the conditions, the last request time and the latency attainment of the InferenceObjectives are written to their status. Zero disables the management of their status.")
+	drainTimeout = flag.Duration(
+		"drain-timeout",
+		runserver.DefaultDrainTimeout,
+		"Maximum time given to the in-flight ext-proc streams to complete on shutdown. New streams are refused with UNAVAILABLE while draining, and readiness reports NOT_SERVING. Must be lower than the termination grace period of the pod.")
+	// request validation flags
+	requestValidation = flag.Bool(
+		"request-validation",
+		runserver.DefaultRequestValidation,
+		"Enables the validation of chat completions, completions and embeddings request bodies against the OpenAI API schemas before scheduling. Invalid requests are rejected with a 400.")
+	maxRequestBodyBytes = flag.Int(
+		"max-request-body-bytes",
+		runserver.DefaultMaxRequestBodyBytes,
+		"Maximum size of request bodies in bytes, when request validation is enabled. Zero disables the limit.")
+	maxRequestMessages = flag.Int(
+		"max-request-messages",
+		runserver.DefaultMaxRequestMessages,
+		"Maximum number of messages of chat completions requests, when request validation is enabled. Zero disables the limit.")
+	maxRequestTokens = flag.Int(
+		"max-request-tokens",
+		runserver.DefaultMaxRequestTokens,
+		"Ceiling of the max_tokens and max_completion_tokens request parameters, when request validation is enabled. Zero disables the limit.")
 	refreshPrometheusMetricsInterval = flag.Duration(
 		"refresh-prometheus-metrics-interval",
 		runserver.DefaultRefreshPrometheusMetricsInterval,
@@ -140,25 +255,61 @@ var (
 	configFile = flag.String(
 		"config-file",
 		runserver.DefaultConfigFile,
-		"The path to the configuration file")
+		"The path to the configuration file, which is reloaded when it changes")
 	configText = flag.String(
 		"config-text",
 		runserver.DefaultConfigText,
 		"The configuration specified as text, in lieu of a file")
+	experimentalFlowControl = flag.Bool(
+		"experimental-flow-control",
+		false,
+		"Accept the flowControl section of the configuration. The section is validated, but its priority bands aren't enforced yet, since the flow controller isn't part of the request path.")
+	standaloneConfigFile = flag.String(
+		"standalone-config-file",
+		runserver.DefaultStandaloneConfigFile,
+		"The path to a file defining the InferencePool, its endpoints and the InferenceObjectives. "+
+			"When set, the EPP runs without Kubernetes, and reloads the file when it changes.")
+	fileWatchInterval = flag.Duration(
+		"file-watch-interval",
+		runserver.DefaultFileWatchInterval,
+		"The interval at which the watched files are checked for changes")
+	// tracing flags
+	tracingExporter = flag.String(
+		"tracing-exporter",
+		runserver.DefaultTracingExporter,
+		fmt.Sprintf("Exporter of the OpenTelemetry spans of the requests, one of %v. The trace context is read from and propagated to the model servers in the W3C traceparent header.", tracing.Exporters()))
+	tracingOTLPEndpoint = flag.String(
+		"tracing-otlp-endpoint",
+		runserver.DefaultTracingOTLPEndpoint,
+		"The host:port of the OTLP/gRPC collector the spans are exported to. Defaults to the OTEL_EXPORTER_OTLP_ENDPOINT environment variable, or localhost:4317.")
+	tracingOTLPInsecure = flag.Bool(
+		"tracing-otlp-insecure",
+		runserver.DefaultTracingOTLPInsecure,
+		"Disables TLS on the connection to the OTLP collector.")
+	tracingSamplingRatio = flag.Float64(
+		"tracing-sampling-ratio",
+		runserver.DefaultTracingSamplingRatio,
+		"Ratio of the traces started by the EPP which are sampled. The requests carrying a trace context follow the sampling decision of the gateway.")
 
 	modelServerMetricsPort = flag.Int("model-server-metrics-port", 0, "Port to scrape metrics from pods. "+
-		"Default value will be set to InferencePool.Spec.TargetPortNumber if not set.")
+		"Default value will be set to the target port of each endpoint, i.e. of each data parallel rank of the pods, if not set.")
 	modelServerMetricsPath                    = flag.String("model-server-metrics-path", "/metrics", "Path to scrape metrics from pods")
 	modelServerMetricsScheme                  = flag.String("model-server-metrics-scheme", "http", "Scheme to scrape metrics from pods")
 	modelServerMetricsHttpsInsecureSkipVerify = flag.Bool("model-server-metrics-https-insecure-skip-verify", true, "When using 'https' scheme for 'model-server-metrics-scheme', configure 'InsecureSkipVerify' (default to true)")
+	enablePluggableDataLayer                  = flag.Bool("enable-pluggable-data-layer", false, "Collect endpoint data using the registered data layer sources and extractors, "+
+		"running a collector per endpoint, instead of the built-in metrics refresher.")
 
 	setupLog = ctrl.Log.WithName("setup")
 )
 
+// tracingShutdownTimeout is the maximum time given to the export of the pending spans on shutdown.
+const tracingShutdownTimeout = 5 * time.Second
+
 // NewRunner initializes a new EPP Runner and returns its pointer.
 func NewRunner() *Runner {
 	return &Runner{
 		requestControlConfig: requestcontrol.NewConfig(), // default requestcontrol config has empty plugin list
+		rateLimitBackend:     ratelimit.NewMemoryBackend(),
 	}
 }
 
@@ -166,6 +317,14 @@ func NewRunner() *Runner {
 type Runner struct {
 	requestControlConfig *requestcontrol.Config
 	schedulerConfig      *scheduling.SchedulerConfig
+	rateLimitBackend     ratelimit.Backend
+	// saturationDetectorConfig and flowControlConfig are set by the sections of the configuration.
+	saturationDetectorConfig *saturationdetector.Config
+	flowControlConfig        *config.FlowControlConfig
+	// configReloader and configContent are set when the configuration is read from a file, which is
+	// then watched for changes.
+	configReloader *configReloader
+	configContent  []byte
 }
 
 func (r *Runner) WithRequestControlConfig(requestControlConfig *requestcontrol.Config) *Runner {
@@ -178,6 +337,13 @@ func (r *Runner) WithSchedulerConfig(schedulerConfig *scheduling.SchedulerConfig
 	return r
 }
 
+// WithRateLimitBackend sets the backend storing the rate limit buckets of the InferenceObjectives.
+// The default backend keeps them in memory, so that each EPP replica enforces the limits on its own.
+func (r *Runner) WithRateLimitBackend(backend ratelimit.Backend) *Runner {
+	r.rateLimitBackend = backend
+	return r
+}
+
 func bindEnvToFlags() {
 	// map[ENV_VAR]flagName   – add more as needed
 	for env, flg := range map[string]string{
@@ -189,9 +355,11 @@ func bindEnvToFlags() {
 		"MODEL_SERVER_METRICS_HTTPS_INSECURE_SKIP_VERIFY": "model-server-metrics-https-insecure-skip-verify",
 		"POOL_NAME":                                       "pool-name",
 		"POOL_NAMESPACE":                                  "pool-namespace",
+		"MODEL_SERVER_TYPE":                               "model-server-type",
 		// durations & bools work too; flag.Set expects the *string* form
-		"REFRESH_METRICS_INTERVAL": "refresh-metrics-interval",
-		"SECURE_SERVING":           "secure-serving",
+		"REFRESH_METRICS_INTERVAL":    "refresh-metrics-interval",
+		"SECURE_SERVING":              "secure-serving",
+		"ENABLE_PLUGGABLE_DATA_LAYER": "enable-pluggable-data-layer",
 	} {
 		if v := os.Getenv(env); v != "" {
 			// ignore error; Parse() will catch invalid values later
@@ -227,52 +395,58 @@ func (r *Runner) Run(ctx context.Context) error {
 	})
 	setupLog.Info("Flags processed", "flags", flags)
 
+	// --- Setup Tracing ---
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Options{
+		Exporter:      tracing.Exporter(*tracingExporter),
+		OTLPEndpoint:  *tracingOTLPEndpoint,
+		OTLPInsecure:  *tracingOTLPInsecure,
+		SamplingRatio: *tracingSamplingRatio,
+	})
+	if err != nil {
+		setupLog.Error(err, "Failed to setup tracing")
+		return err
+	}
+	defer func() {
+		// The context is done once the manager stopped, the pending spans are flushed within a timeout.
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			setupLog.Error(err, "Failed to flush the pending spans")
+		}
+	}()
+
 	// --- Load Configurations from Environment Variables ---
-	sdConfig := saturationdetector.LoadConfigFromEnv()
+	odConfig := outlierdetection.LoadConfigFromEnv()
+
+	standaloneMode := *standaloneConfigFile != ""
 
 	// --- Get Kubernetes Config ---
-	cfg, err := ctrl.GetConfig()
-	if err != nil {
-		setupLog.Error(err, "Failed to get Kubernetes rest config")
-		return err
+	var cfg *rest.Config
+	if !standaloneMode {
+		var err error
+		cfg, err = ctrl.GetConfig()
+		if err != nil {
+			setupLog.Error(err, "Failed to get Kubernetes rest config")
+			return err
+		}
 	}
 
 	// --- Setup Datastore ---
-	mapping, err := backendmetrics.NewMetricMapping(
-		*totalQueuedRequestsMetric,
-		*kvCacheUsagePercentageMetric,
-		*loraInfoMetric,
-	)
+	epf, err := r.setupMetricsCollection()
 	if err != nil {
-		setupLog.Error(err, "Failed to create metric mapping from flags.")
 		return err
 	}
-	verifyMetricMapping(*mapping, setupLog)
+	datastore := datastore.NewDatastore(ctx, epf)
 
-	var metricsHttpClient *http.Client
-	if *modelServerMetricsScheme == "https" {
-		metricsHttpClient = &http.Client{
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{
-					InsecureSkipVerify: *modelServerMetricsHttpsInsecureSkipVerify,
-				},
-			},
-		}
-	} else {
-		metricsHttpClient = http.DefaultClient
+	// --- Setup Outlier Detection ---
+	outlierDetector := outlierdetection.NewDetector(odConfig, datastore, setupLog)
+	switch f := epf.(type) {
+	case *backendmetrics.PodMetricsFactory:
+		f.SetScrapeObserver(outlierDetector)
+	case *datalayer.EndpointLifecycle:
+		f.SetScrapeObserver(outlierDetector)
 	}
 
-	pmf := backendmetrics.NewPodMetricsFactory(&backendmetrics.PodMetricsClientImpl{
-		MetricMapping:            mapping,
-		ModelServerMetricsPort:   int32(*modelServerMetricsPort),
-		ModelServerMetricsPath:   *modelServerMetricsPath,
-		ModelServerMetricsScheme: *modelServerMetricsScheme,
-		Client:                   metricsHttpClient,
-	},
-		*refreshMetricsInterval)
-
-	datastore := datastore.NewDatastore(ctx, pmf)
-
 	// --- Setup Metrics Server ---
 	customCollectors := []prometheus.Collector{collectors.NewInferencePoolMetricsCollector(datastore)}
 	metrics.Register(customCollectors...)
@@ -295,14 +469,44 @@ func (r *Runner) Run(ctx context.Context) error {
 		Group: *poolGroup,
 		Kind:  "InferencePool",
 	}
+
+	// --- Setup Standalone Provider ---
+	// In standalone mode, the pool, its endpoints and the objectives are read from a file in lieu of
+	// being reconciled from the API server.
+	var standaloneWatcher *filewatch.Watcher
+	if standaloneMode {
+		provider := standalone.NewProvider(datastore)
+		standaloneWatcher = filewatch.New(*standaloneConfigFile, *fileWatchInterval, provider.Update)
+		if err := standaloneWatcher.Load(ctx); err != nil {
+			setupLog.Error(err, "Failed to load the standalone configuration file")
+			return err
+		}
+		pool, err := datastore.PoolGet()
+		if err != nil {
+			setupLog.Error(err, "Failed to get the InferencePool of the standalone configuration file")
+			return err
+		}
+		poolNamespacedName = types.NamespacedName{Name: pool.Name, Namespace: pool.Namespace}
+		poolGroupKind.Group = v1.GroupName
+		setupLog.Info("Running in standalone mode", "file", *standaloneConfigFile, "pool", poolNamespacedName)
+	}
 	poolGKNN := common.GKNN{
 		NamespacedName: poolNamespacedName,
 		GroupKind:      poolGroupKind,
 	}
-	mgr, err := runserver.NewDefaultManager(poolGKNN, cfg, metricsServerOptions)
-	if err != nil {
-		setupLog.Error(err, "Failed to create controller manager")
-		return err
+
+	var mgr runnableManager
+	var ctrlMgr ctrl.Manager
+	if standaloneMode {
+		mgr = newStandaloneManager(metricsServerOptions)
+	} else {
+		var err error
+		ctrlMgr, err = runserver.NewDefaultManager(poolGKNN, cfg, metricsServerOptions)
+		if err != nil {
+			setupLog.Error(err, "Failed to create controller manager")
+			return err
+		}
+		mgr = ctrlMgr
 	}
 
 	if *enablePprof {
@@ -331,10 +535,85 @@ func (r *Runner) Run(ctx context.Context) error {
 
 	scheduler := scheduling.NewSchedulerWithConfig(r.schedulerConfig)
 
+	// The saturationDetector section of the configuration takes precedence over the environment.
+	sdConfig := r.saturationDetectorConfig
+	if sdConfig == nil {
+		sdConfig = saturationdetector.LoadConfigFromEnv()
+	}
 	saturationDetector := saturationdetector.NewDetector(sdConfig, datastore, setupLog)
+	r.requestControlConfig.WithSaturationBypassCriticality(sdConfig.BypassCriticality)
+
+	if r.flowControlConfig != nil {
+		setupLog.Info("Loaded the experimental flow control configuration, its priority bands aren't enforced", "flowControl", r.flowControlConfig.Registry)
+	}
+
+	if err := mgr.Add(outlierDetector); err != nil {
+		setupLog.Error(err, "Failed to add outlier detector to the manager")
+		return err
+	}
+	r.requestControlConfig.WithOutlierDetector(outlierDetector)
+
+	if *objectiveStatusSyncInterval > 0 && standaloneMode {
+		setupLog.Info("Objective status updates are disabled in standalone mode")
+	} else if *objectiveStatusSyncInterval > 0 {
+		objectiveTracker := objectives.NewTracker(objectives.DefaultWindowSize)
+		statusUpdater := objectives.NewStatusUpdater(ctrlMgr.GetClient(), ctrlMgr.GetAPIReader(), poolGKNN, objectiveTracker, *objectiveStatusSyncInterval)
+		if err := mgr.Add(runnable.RequireLeaderElection(statusUpdater)); err != nil {
+			setupLog.Error(err, "Failed to add objective status updater to the manager")
+			return err
+		}
+		r.requestControlConfig.WithObjectiveTracker(objectiveTracker)
+	}
+
+	r.requestControlConfig.WithRateLimiter(ratelimit.NewLimiter(r.rateLimitBackend))
+
+	loadReportParser, err := backendmetrics.NewLoadReportParser(backendmetrics.LoadReportConfig{
+		Format:                     *loadReportFormat,
+		Header:                     *loadReportHeader,
+		TotalQueuedRequestsMetric:  *loadReportQueuedRequestsMetric,
+		TotalRunningRequestsMetric: *loadReportRunningRequestsMetric,
+		KVCacheUtilizationMetric:   *loadReportKVCacheUsageMetric,
+	})
+	if err != nil {
+		setupLog.Error(err, "Failed to create load report parser")
+		return err
+	}
+
+	var requestValidator *validation.Validator
+	if *requestValidation {
+		requestValidator, err = validation.NewValidator(validation.Limits{
+			MaxBodyBytes: *maxRequestBodyBytes,
+			MaxMessages:  *maxRequestMessages,
+			MaxTokens:    *maxRequestTokens,
+		})
+		if err != nil {
+			setupLog.Error(err, "Failed to create request validator")
+			return err
+		}
+	}
+
+	var modelsOptions *handlers.ModelsOptions
+	if *serveModels {
+		modelsOptions = &handlers.ModelsOptions{Readiness: *modelsReadiness}
+	}
+
+	drainer := handlers.NewDrainer()
 
 	director := requestcontrol.NewDirectorWithConfig(datastore, scheduler, saturationDetector, r.requestControlConfig)
 
+	// --- Setup Configuration Reload ---
+	// The configuration file is watched, e.g. as mounted from a ConfigMap, and its changes are
+	// applied to the director without restarting.
+	if r.configReloader != nil {
+		r.configReloader.director = director
+		configWatcher := filewatch.New(*configFile, *fileWatchInterval, r.configReloader.reload)
+		configWatcher.Skip(r.configContent)
+		if err := mgr.Add(configWatcher); err != nil {
+			setupLog.Error(err, "Failed to add the configuration watcher to the manager")
+			return err
+		}
+	}
+
 	// --- Setup ExtProc Server Runner ---
 	serverRunner := &runserver.ExtProcServerRunner{
 		GrpcPort:                         *grpcPort,
@@ -348,15 +627,26 @@ func (r *Runner) Run(ctx context.Context) error {
 		MetricsStalenessThreshold:        *metricsStalenessThreshold,
 		Director:                         director,
 		SaturationDetector:               saturationDetector,
+		LoadReportParser:                 loadReportParser,
+		RequestValidator:                 requestValidator,
+		ProcessingMode:                   handlers.ProcessingMode(*processingMode),
+		ModelsOptions:                    modelsOptions,
+		Drainer:                          drainer,
+		DrainTimeout:                     *drainTimeout,
 	}
-	if err := serverRunner.SetupWithManager(ctx, mgr); err != nil {
+	if standaloneMode {
+		if err := mgr.Add(standaloneWatcher); err != nil {
+			setupLog.Error(err, "Failed to add the standalone configuration watcher to the manager")
+			return err
+		}
+	} else if err := serverRunner.SetupWithManager(ctx, ctrlMgr); err != nil {
 		setupLog.Error(err, "Failed to setup EPP controllers")
 		return err
 	}
 
 	// --- Add Runnables to Manager ---
 	// Register health server.
-	if err := registerHealthServer(mgr, ctrl.Log.WithName("health"), datastore, *grpcHealthPort); err != nil {
+	if err := registerHealthServer(mgr, ctrl.Log.WithName("health"), datastore, drainer, *grpcHealthPort); err != nil {
 		return err
 	}
 
@@ -376,6 +666,145 @@ func (r *Runner) Run(ctx context.Context) error {
 	return nil
 }
 
+// setupMetricsCollection returns the endpoint factory used by the datastore to collect endpoint data.
+// By default, metrics are refreshed by the built-in PodMetricsClient. When the pluggable data layer
+// is enabled, a collector is run per endpoint using the registered data layer sources.
+func (r *Runner) setupMetricsCollection() (datalayer.EndpointFactory, error) {
+	var metricsHttpClient *http.Client
+	if *modelServerMetricsScheme == "https" {
+		metricsHttpClient = &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					InsecureSkipVerify: *modelServerMetricsHttpsInsecureSkipVerify,
+				},
+			},
+		}
+	} else {
+		metricsHttpClient = http.DefaultClient
+	}
+
+	specs, err := metricSpecsFromFlags()
+	if err != nil {
+		setupLog.Error(err, "Failed to resolve metric specifications from flags.")
+		return nil, err
+	}
+
+	if *enablePluggableDataLayer {
+		return setupDataLayer(metricsHttpClient, specs)
+	}
+
+	mapping, err := specs.MetricMapping()
+	if err != nil {
+		setupLog.Error(err, "Failed to create metric mapping from flags.")
+		return nil, err
+	}
+	verifyMetricMapping(*mapping, setupLog)
+
+	serverTypeMappings, err := backendmetrics.NewModelServerMetricMappings()
+	if err != nil {
+		setupLog.Error(err, "Failed to create model server metric mappings.")
+		return nil, err
+	}
+
+	return backendmetrics.NewPodMetricsFactoryWithConfig(&backendmetrics.PodMetricsClientImpl{
+		MetricMapping:             mapping,
+		ModelServerMetricMappings: serverTypeMappings,
+		ModelServerMetricsPort:    int32(*modelServerMetricsPort),
+		ModelServerMetricsPath:    *modelServerMetricsPath,
+		ModelServerMetricsScheme:  *modelServerMetricsScheme,
+		Client:                    metricsHttpClient,
+	}, scrapeConfigFromFlags()), nil
+}
+
+// metricSpecsFromFlags returns the metric specifications to scrape. When a model server type is set,
+// its preset is used, with metric flags that were explicitly set taking precedence.
+func metricSpecsFromFlags() (backendmetrics.MetricSpecs, error) {
+	if *modelServerType == "" {
+		return backendmetrics.MetricSpecs{
+			TotalQueuedRequests:  *totalQueuedRequestsMetric,
+			TotalRunningRequests: *totalRunningRequestsMetric,
+			KVCacheUtilization:   *kvCacheUsagePercentageMetric,
+			LoraRequestInfo:      *loraInfoMetric,
+			CacheConfigInfo:      *cacheInfoMetric,
+		}, nil
+	}
+
+	specs, err := backendmetrics.ModelServerMetricPreset(*modelServerType)
+	if err != nil {
+		return specs, err
+	}
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "total-queued-requests-metric":
+			specs.TotalQueuedRequests = f.Value.String()
+		case "total-running-requests-metric":
+			specs.TotalRunningRequests = f.Value.String()
+		case "kv-cache-usage-percentage-metric":
+			specs.KVCacheUtilization = f.Value.String()
+		case "lora-info-metric":
+			specs.LoraRequestInfo = f.Value.String()
+		case "cache-info-metric":
+			specs.CacheConfigInfo = f.Value.String()
+		}
+	})
+	return specs, nil
+}
+
+// setupDataLayer registers the model server protocol metrics data source and returns an endpoint
+// factory collecting from all registered data sources. Additional (custom) data sources and extractors
+// may be registered with the datalayer package before the runner is started.
+func setupDataLayer(metricsHttpClient *http.Client, specs backendmetrics.MetricSpecs) (datalayer.EndpointFactory, error) {
+	source := dlmetrics.NewDataSource(*modelServerMetricsScheme, int32(*modelServerMetricsPort),
+		*modelServerMetricsPath, dlmetrics.NewClient(metricsHttpClient))
+	extractor, err := dlmetrics.NewExtractor(specs.TotalQueuedRequests, specs.TotalRunningRequests,
+		specs.KVCacheUtilization, specs.LoraRequestInfo, specs.CacheConfigInfo)
+	if err != nil {
+		setupLog.Error(err, "Failed to create metrics extractor from flags.")
+		return nil, err
+	}
+	serverTypeMappings := map[string]*dlmetrics.Mapping{}
+	for _, serverType := range backendmetrics.ModelServerTypes() {
+		preset, _ := backendmetrics.ModelServerMetricPreset(serverType)
+		mapping, err := dlmetrics.NewMapping(preset.TotalQueuedRequests, preset.TotalRunningRequests,
+			preset.KVCacheUtilization, preset.LoraRequestInfo, preset.CacheConfigInfo)
+		if err != nil {
+			setupLog.Error(err, "Failed to create metrics mapping for model server type", "type", serverType)
+			return nil, err
+		}
+		serverTypeMappings[serverType] = mapping
+	}
+	extractor.SetModelServerMappings(backendmetrics.ModelServerTypeAnnotation, serverTypeMappings)
+	if err := source.AddExtractor(extractor); err != nil {
+		setupLog.Error(err, "Failed to add metrics extractor to data source")
+		return nil, err
+	}
+	if err := datalayer.RegisterSource(source); err != nil {
+		setupLog.Error(err, "Failed to register metrics data source")
+		return nil, err
+	}
+
+	sources := datalayer.GetSources()
+	names := make([]string, 0, len(sources))
+	for _, src := range sources {
+		names = append(names, src.Name())
+	}
+	setupLog.Info("Using pluggable data layer", "sources", names)
+	return datalayer.NewEndpointFactoryWithConfig(sources, scrapeConfigFromFlags()), nil
+}
+
+// scrapeConfigFromFlags returns the configuration of the scrape scheduler shared by all endpoints.
+func scrapeConfigFromFlags() datalayer.ScrapeConfig {
+	return datalayer.ScrapeConfig{
+		Interval:        *refreshMetricsInterval,
+		Timeout:         *metricsScrapeTimeout,
+		Workers:         *metricsScrapeWorkers,
+		Jitter:          *metricsScrapeJitter,
+		MaxBackoff:      *metricsScrapeMaxBackoff,
+		MaxInterval:     *metricsScrapeMaxInterval,
+		AllowConcurrent: *metricsScrapeAllowConcurrent,
+	}
+}
+
 // registerInTreePlugins registers the factory functions of all known plugins
 func (r *Runner) registerInTreePlugins() {
 	plugins.Register(prefix.PrefixCachePluginType, prefix.PrefixCachePluginFactory)
@@ -385,6 +814,8 @@ func (r *Runner) registerInTreePlugins() {
 	plugins.Register(scorer.KvCacheUtilizationScorerType, scorer.KvCacheUtilizationScorerFactory)
 	plugins.Register(scorer.QueueScorerType, scorer.QueueScorerFactory)
 	plugins.Register(scorer.LoraAffinityScorerType, scorer.LoraAffinityScorerFactory)
+	plugins.Register(servedby.ServedByType, servedby.ServedByFactory)
+	plugins.Register(redactor.ResponseRedactorType, redactor.ResponseRedactorFactory)
 	// register filter for test purpose only (used in conformance tests)
 	plugins.Register(testfilter.HeaderBasedTestingFilterType, testfilter.HeaderBasedTestingFilterFactory)
 }
@@ -413,8 +844,23 @@ func (r *Runner) parsePluginsConfiguration(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to load the configuration - %w", err)
 	}
+	if err := checkFlowControl(config, *experimentalFlowControl); err != nil {
+		return err
+	}
 
 	r.schedulerConfig = config.SchedulerConfig
+	r.saturationDetectorConfig = config.SaturationDetectorConfig
+	r.flowControlConfig = config.FlowControlConfig
+	metrics.RecordConfigVersion(1, configHash(configBytes))
+	if *configFile != "" {
+		r.configReloader = &configReloader{
+			baseRequestControlConfig: r.requestControlConfig.Clone(),
+			current:                  config,
+			version:                  1,
+			flowControlEnabled:       *experimentalFlowControl,
+		}
+		r.configContent = configBytes
+	}
 
 	// Add requestControl plugins
 	r.requestControlConfig.AddPlugins(handle.GetAllPlugins()...)
@@ -442,7 +888,7 @@ func initLogging(opts *zap.Options) {
 }
 
 // registerExtProcServer adds the ExtProcServerRunner as a Runnable to the manager.
-func registerExtProcServer(mgr manager.Manager, runner *runserver.ExtProcServerRunner, logger logr.Logger) error {
+func registerExtProcServer(mgr runnableManager, runner *runserver.ExtProcServerRunner, logger logr.Logger) error {
 	if err := mgr.Add(runner.AsRunnable(logger)); err != nil {
 		setupLog.Error(err, "Failed to register ext-proc gRPC server runnable")
 		return err
@@ -452,14 +898,22 @@ func registerExtProcServer(mgr manager.Manager, runner *runserver.ExtProcServerR
 }
 
 // registerHealthServer adds the Health gRPC server as a Runnable to the given manager.
-func registerHealthServer(mgr manager.Manager, logger logr.Logger, ds datastore.Datastore, port int) error {
+// The server keeps serving while the ext-proc streams are drained, so that liveness stays up and
+// readiness reports NOT_SERVING until the EPP exits.
+func registerHealthServer(mgr runnableManager, logger logr.Logger, ds datastore.Datastore, drainer *handlers.Drainer, port int) error {
 	srv := grpc.NewServer()
 	healthPb.RegisterHealthServer(srv, &healthServer{
-		logger:    logger,
-		datastore: ds,
+		logger:             logger,
+		datastore:          ds,
+		drainer:            drainer,
+		stalenessThreshold: *metricsStalenessThreshold,
 	})
+	drain := func(ctx context.Context) {
+		drainer.Start()
+		_ = drainer.Wait(ctx)
+	}
 	if err := mgr.Add(
-		runnable.NoLeaderElection(runnable.GRPCServer("health", srv, port))); err != nil {
+		runnable.NoLeaderElection(runnable.GRPCServerWithDrain("health", srv, port, drain, *drainTimeout))); err != nil {
 		setupLog.Error(err, "Failed to register health server")
 		return err
 	}
@@ -467,12 +921,62 @@ func registerHealthServer(mgr manager.Manager, logger logr.Logger, ds datastore.
 }
 
 func validateFlags() error {
-	if *poolName == "" {
+	if *poolName == "" && *standaloneConfigFile == "" {
 		return fmt.Errorf("required %q flag not set", "poolName")
 	}
 	if *configText != "" && *configFile != "" {
 		return fmt.Errorf("both the %q and %q flags can not be set at the same time", "configText", "configFile")
 	}
+	if *modelServerType != "" {
+		if _, err := backendmetrics.ModelServerMetricPreset(*modelServerType); err != nil {
+			return fmt.Errorf("invalid %q flag - %w", "model-server-type", err)
+		}
+	}
+	if *refreshMetricsInterval <= 0 {
+		return fmt.Errorf("invalid %q flag - must be positive", "refresh-metrics-interval")
+	}
+	if *metricsScrapeTimeout <= 0 {
+		return fmt.Errorf("invalid %q flag - must be positive", "metrics-scrape-timeout")
+	}
+	if *metricsScrapeWorkers <= 0 {
+		return fmt.Errorf("invalid %q flag - must be positive", "metrics-scrape-workers")
+	}
+	if *metricsScrapeJitter < 0 || *metricsScrapeJitter > 1 {
+		return fmt.Errorf("invalid %q flag - must be in [0, 1]", "metrics-scrape-jitter")
+	}
+	if *metricsScrapeMaxInterval < 0 {
+		return fmt.Errorf("invalid %q flag - must not be negative", "metrics-scrape-max-interval")
+	}
+	if !slices.Contains(backendmetrics.LoadReportFormats(), *loadReportFormat) {
+		return fmt.Errorf("invalid %q flag - must be one of %v", "load-report-format", backendmetrics.LoadReportFormats())
+	}
+	if !slices.Contains(handlers.ProcessingModes(), handlers.ProcessingMode(*processingMode)) {
+		return fmt.Errorf("invalid %q flag - must be one of %v", "processing-mode", handlers.ProcessingModes())
+	}
+	if *objectiveStatusSyncInterval < 0 {
+		return fmt.Errorf("invalid %q flag - must not be negative", "objective-status-sync-interval")
+	}
+	if *fileWatchInterval <= 0 {
+		return fmt.Errorf("invalid %q flag - must be positive", "file-watch-interval")
+	}
+	if *drainTimeout < 0 {
+		return fmt.Errorf("invalid %q flag - must not be negative", "drain-timeout")
+	}
+	if !slices.Contains(tracing.Exporters(), tracing.Exporter(*tracingExporter)) {
+		return fmt.Errorf("invalid %q flag - must be one of %v", "tracing-exporter", tracing.Exporters())
+	}
+	if *tracingSamplingRatio < 0 || *tracingSamplingRatio > 1 {
+		return fmt.Errorf("invalid %q flag - must be in [0, 1]", "tracing-sampling-ratio")
+	}
+	if *maxRequestBodyBytes < 0 {
+		return fmt.Errorf("invalid %q flag - must not be negative", "max-request-body-bytes")
+	}
+	if *maxRequestMessages < 0 {
+		return fmt.Errorf("invalid %q flag - must not be negative", "max-request-messages")
+	}
+	if *maxRequestTokens < 0 {
+		return fmt.Errorf("invalid %q flag - must not be negative", "max-request-tokens")
+	}
 	if *modelServerMetricsScheme != "http" && *modelServerMetricsScheme != "https" {
 		return fmt.Errorf("unexpected %q value for %q flag, it can only be set to 'http' or 'https'", *modelServerMetricsScheme, "model-server-metrics-scheme")
 	}
@@ -484,17 +988,23 @@ func verifyMetricMapping(mapping backendmetrics.MetricMapping, logger logr.Logge
 	if mapping.TotalQueuedRequests == nil {
 		logger.Info("Not scraping metric: TotalQueuedRequests")
 	}
+	if mapping.TotalRunningRequests == nil {
+		logger.Info("Not scraping metric: TotalRunningRequests")
+	}
 	if mapping.KVCacheUtilization == nil {
 		logger.Info("Not scraping metric: KVCacheUtilization")
 	}
 	if mapping.LoraRequestInfo == nil {
 		logger.Info("Not scraping metric: LoraRequestInfo")
 	}
+	if mapping.CacheConfigInfo == nil {
+		logger.Info("Not scraping metric: CacheConfigInfo")
+	}
 }
 
 // setupPprofHandlers only implements the pre-defined profiles:
 // https://cs.opensource.google/go/go/+/refs/tags/go1.24.4:src/runtime/pprof/pprof.go;l=108
-func setupPprofHandlers(mgr ctrl.Manager) error {
+func setupPprofHandlers(mgr runnableManager) error {
 	var err error
 	profiles := []string{
 		"heap",
@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/sync/errgroup"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+)
+
+// runnableManager is the part of the controller manager used to run the EPP servers. It's
+// implemented by the controller manager in Kubernetes, and by the standaloneManager otherwise.
+type runnableManager interface {
+	Add(manager.Runnable) error
+	AddMetricsServerExtraHandler(path string, handler http.Handler) error
+	Start(ctx context.Context) error
+}
+
+// standaloneManager runs the EPP servers and the metrics server without Kubernetes. There is a
+// single replica, hence no leader election, and the metrics are served without authentication.
+type standaloneManager struct {
+	metricsServerOptions metricsserver.Options
+	runnables            []manager.Runnable
+}
+
+func newStandaloneManager(metricsServerOptions metricsserver.Options) *standaloneManager {
+	// The authentication and authorization filter relies on the API server.
+	metricsServerOptions.FilterProvider = nil
+	return &standaloneManager{metricsServerOptions: metricsServerOptions}
+}
+
+// Add implements runnableManager.
+func (m *standaloneManager) Add(r manager.Runnable) error {
+	m.runnables = append(m.runnables, r)
+	return nil
+}
+
+// AddMetricsServerExtraHandler implements runnableManager.
+func (m *standaloneManager) AddMetricsServerExtraHandler(path string, handler http.Handler) error {
+	if m.metricsServerOptions.ExtraHandlers == nil {
+		m.metricsServerOptions.ExtraHandlers = make(map[string]http.Handler)
+	}
+	if _, ok := m.metricsServerOptions.ExtraHandlers[path]; ok {
+		return fmt.Errorf("overriding metrics server extra handler for path %s is not allowed", path)
+	}
+	m.metricsServerOptions.ExtraHandlers[path] = handler
+	return nil
+}
+
+// Start runs the metrics server and the runnables until the context is done, or one of them fails.
+func (m *standaloneManager) Start(ctx context.Context) error {
+	metricsServer, err := metricsserver.NewServer(m.metricsServerOptions, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create the metrics server: %w", err)
+	}
+	runnables := m.runnables
+	if metricsServer != nil {
+		runnables = append([]manager.Runnable{metricsServer}, runnables...)
+	}
+
+	group, ctx := errgroup.WithContext(ctx)
+	for _, r := range runnables {
+		group.Go(func() error {
+			return r.Start(ctx)
+		})
+	}
+	return group.Wait()
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+
+	uberzap "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	"sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/webhook"
+)
+
+var (
+	webhookPort = flag.Int(
+		"webhook-port", 9443, "The port of the admission webhook server")
+	certDir = flag.String(
+		"cert-dir", "", "The directory holding the tls.crt and tls.key serving certificate of the webhook server. "+
+			"Defaults to <temp-dir>/k8s-webhook-server/serving-certs.")
+	healthPort = flag.Int(
+		"health-port", 8081, "The port of the HTTP liveness and readiness probes")
+	metricsPort = flag.Int(
+		"metrics-port", 9090, "The metrics port")
+	missingPoolPolicy = flag.String(
+		"missing-pool-policy", string(webhook.MissingPoolDeny), fmt.Sprintf("Action taken on an InferenceObjective referencing an InferencePool which doesn't exist, one of %v. "+
+			"'warn' admits the objective with a warning, so that the objectives can be applied before their pool.", webhook.MissingPoolPolicies()))
+	logVerbosity = flag.Int("v", logging.DEFAULT, "number for the log level verbosity")
+
+	scheme   = runtime.NewScheme()
+	setupLog = ctrl.Log.WithName("setup")
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1.Install(scheme))
+	utilruntime.Must(v1alpha2.Install(scheme))
+}
+
+func main() {
+	if err := run(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	opts := zap.Options{Development: true}
+	opts.BindFlags(flag.CommandLine)
+	flag.Parse()
+	initLogging(&opts)
+
+	if !slices.Contains(webhook.MissingPoolPolicies(), webhook.MissingPoolPolicy(*missingPoolPolicy)) {
+		err := fmt.Errorf("invalid %q flag - must be one of %v", "missing-pool-policy", webhook.MissingPoolPolicies())
+		setupLog.Error(err, "Failed to validate flags")
+		return err
+	}
+
+	cfg, err := ctrl.GetConfig()
+	if err != nil {
+		setupLog.Error(err, "Failed to get rest config")
+		return err
+	}
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme: scheme,
+		Metrics: metricsserver.Options{
+			BindAddress:    fmt.Sprintf(":%d", *metricsPort),
+			FilterProvider: filters.WithAuthenticationAndAuthorization,
+		},
+		WebhookServer:          ctrlwebhook.NewServer(ctrlwebhook.Options{Port: *webhookPort, CertDir: *certDir}),
+		HealthProbeBindAddress: fmt.Sprintf(":%d", *healthPort),
+	})
+	if err != nil {
+		setupLog.Error(err, "Failed to create manager", "config", cfg)
+		return err
+	}
+
+	if err := webhook.SetupWithManager(mgr, webhook.MissingPoolPolicy(*missingPoolPolicy)); err != nil {
+		setupLog.Error(err, "Failed to setup the admission webhooks")
+		return err
+	}
+	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		setupLog.Error(err, "Failed to add liveness check")
+		return err
+	}
+	if err := mgr.AddReadyzCheck("webhook", mgr.GetWebhookServer().StartedChecker()); err != nil {
+		setupLog.Error(err, "Failed to add readiness check")
+		return err
+	}
+
+	// Start the manager. This blocks until a signal is received.
+	setupLog.Info("Manager starting")
+	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+		setupLog.Error(err, "Error starting manager")
+		return err
+	}
+	setupLog.Info("Manager terminated")
+	return nil
+}
+
+func initLogging(opts *zap.Options) {
+	useV := true
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "zap-log-level" {
+			useV = false
+		}
+	})
+	if useV {
+		// See https://pkg.go.dev/sigs.k8s.io/controller-runtime/pkg/log/zap#Options.Level
+		lvl := -1 * (*logVerbosity)
+		opts.Level = uberzap.NewAtomicLevelAt(zapcore.Level(int8(lvl)))
+	}
+
+	logger := zap.New(zap.UseFlagOptions(opts), zap.RawZapOpts(uberzap.AddCaller()))
+	ctrl.SetLogger(logger)
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"time"
 
 	"google.golang.org/grpc"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -29,6 +30,13 @@ import (
 // GRPCServer converts the given gRPC server into a runnable.
 // The server name is just being used for logging.
 func GRPCServer(name string, srv *grpc.Server, port int) manager.Runnable {
+	return GRPCServerWithDrain(name, srv, port, nil, 0)
+}
+
+// GRPCServerWithDrain is the same as GRPCServer, but calls drain when the context is closed and
+// keeps serving until it returns, or until the timeout expires. The server is then stopped
+// gracefully, and forcefully if the in-flight RPCs don't complete within the remaining time.
+func GRPCServerWithDrain(name string, srv *grpc.Server, port int, drain func(ctx context.Context), timeout time.Duration) manager.Runnable {
 	return manager.RunnableFunc(func(ctx context.Context) error {
 		// Use "name" key as that is what manager.Server does as well.
 		log := ctrl.Log.WithValues("name", name)
@@ -52,7 +60,24 @@ func GRPCServer(name string, srv *grpc.Server, port int) manager.Runnable {
 			select {
 			case <-ctx.Done():
 				log.Info("gRPC server shutting down")
-				srv.GracefulStop()
+				if drain == nil {
+					srv.GracefulStop()
+					return
+				}
+				drainCtx, cancel := context.WithTimeout(context.Background(), timeout)
+				defer cancel()
+				drain(drainCtx)
+				stopped := make(chan struct{})
+				go func() {
+					srv.GracefulStop()
+					close(stopped)
+				}()
+				select {
+				case <-stopped:
+				case <-drainCtx.Done():
+					log.Info("gRPC server drain timed out, stopping", "timeout", timeout)
+					srv.Stop()
+				}
 			case <-doneCh:
 			}
 		}()
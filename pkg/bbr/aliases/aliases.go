@@ -0,0 +1,179 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package aliases implements the model alias table of Body-Based Routing.
+//
+// An alias is a public model name, resolved to a base model and optionally a LoRA
+// adapter. An alias with several targets splits the traffic between them according
+// to their weights, e.g. to canary a new adapter:
+//
+//	aliases:
+//	- name: chat-latest
+//	  targets:
+//	  - model: meta-llama/Llama-3.1-8B-Instruct
+//	    adapter: chat-v7
+//	    weight: 90
+//	  - model: meta-llama/Llama-3.1-8B-Instruct
+//	    adapter: chat-v8
+//	    weight: 10
+//
+// The table is read from a local file or from a ConfigMap, and is reloaded when it
+// changes.
+package aliases
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync/atomic"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Target is a model an alias resolves to.
+type Target struct {
+	// Model is the base model, used to route the request.
+	Model string `json:"model"`
+	// Adapter is the LoRA adapter served on top of the base model, if any.
+	Adapter string `json:"adapter,omitempty"`
+	// Weight is the relative share of the alias traffic sent to this target. When all the
+	// targets of an alias have a zero weight, the traffic is split evenly.
+	Weight int `json:"weight,omitempty"`
+}
+
+// ServedModel returns the model name the model server expects in the request: the adapter
+// if any, the base model otherwise.
+func (t Target) ServedModel() string {
+	if t.Adapter != "" {
+		return t.Adapter
+	}
+	return t.Model
+}
+
+// Alias maps a public model name to its targets.
+type Alias struct {
+	Name    string   `json:"name"`
+	Targets []Target `json:"targets"`
+}
+
+// Config is the content of an alias table file or ConfigMap key.
+type Config struct {
+	Aliases []Alias `json:"aliases"`
+}
+
+// Table is a validated alias table.
+type Table struct {
+	aliases map[string]*alias
+}
+
+type alias struct {
+	targets     []Target
+	totalWeight int
+}
+
+// Parse parses and validates the YAML or JSON content of an alias table.
+func Parse(data []byte) (*Table, error) {
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse aliases: %w", err)
+	}
+	return NewTable(config.Aliases)
+}
+
+// NewTable validates the aliases and returns them as a table.
+func NewTable(aliases []Alias) (*Table, error) {
+	table := &Table{aliases: make(map[string]*alias, len(aliases))}
+	for i, a := range aliases {
+		if a.Name == "" {
+			return nil, fmt.Errorf("invalid alias %d: name is required", i)
+		}
+		if _, ok := table.aliases[a.Name]; ok {
+			return nil, fmt.Errorf("invalid alias %q: duplicate name", a.Name)
+		}
+		if len(a.Targets) == 0 {
+			return nil, fmt.Errorf("invalid alias %q: at least one target is required", a.Name)
+		}
+		resolved := &alias{targets: append([]Target(nil), a.Targets...)}
+		for j, target := range a.Targets {
+			if target.Model == "" {
+				return nil, fmt.Errorf("invalid alias %q: target %d: model is required", a.Name, j)
+			}
+			if target.Weight < 0 {
+				return nil, fmt.Errorf("invalid alias %q: target %d: weight must not be negative", a.Name, j)
+			}
+			resolved.totalWeight += target.Weight
+		}
+		table.aliases[a.Name] = resolved
+	}
+	return table, nil
+}
+
+// Len returns the number of aliases in the table.
+func (t *Table) Len() int {
+	return len(t.aliases)
+}
+
+// resolve picks a target of the alias, according to the target weights. The random
+// number generator returns an integer in [0, n).
+func (t *Table) resolve(name string, intN func(n int) int) (Target, bool) {
+	a, ok := t.aliases[name]
+	if !ok {
+		return Target{}, false
+	}
+	if len(a.targets) == 1 {
+		return a.targets[0], true
+	}
+	if a.totalWeight == 0 {
+		return a.targets[intN(len(a.targets))], true
+	}
+	pick := intN(a.totalWeight)
+	for _, target := range a.targets {
+		if pick < target.Weight {
+			return target, true
+		}
+		pick -= target.Weight
+	}
+	return a.targets[len(a.targets)-1], true
+}
+
+// Resolver resolves aliases against the latest loaded table. It is safe for concurrent use.
+type Resolver struct {
+	table atomic.Pointer[Table]
+	intN  func(n int) int
+}
+
+// NewResolver creates a Resolver with an empty table.
+func NewResolver() *Resolver {
+	r := &Resolver{intN: rand.IntN}
+	r.table.Store(&Table{})
+	return r
+}
+
+// Update replaces the alias table. A nil table clears it.
+func (r *Resolver) Update(table *Table) {
+	if table == nil {
+		table = &Table{}
+	}
+	r.table.Store(table)
+}
+
+// Resolve returns the target the model resolves to, if the model is an alias.
+func (r *Resolver) Resolve(model string) (Target, bool) {
+	return r.table.Load().resolve(model, r.intN)
+}
+
+// errNoAliases is returned when an alias source doesn't hold any alias table.
+var errNoAliases = errors.New("no alias table found")
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aliases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAliases = `
+aliases:
+- name: chat-latest
+  targets:
+  - model: base
+    adapter: chat-v7
+    weight: 90
+  - model: base
+    adapter: chat-v8
+    weight: 10
+- name: base-latest
+  targets:
+  - model: base
+- name: even
+  targets:
+  - model: a
+  - model: b
+`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantLen int
+		wantErr bool
+	}{
+		{name: "valid", config: testAliases, wantLen: 3},
+		{name: "empty", config: `aliases: []`, wantLen: 0},
+		{name: "unknown field", config: `aliases: [{name: a, targets: [{model: b}], canary: true}]`, wantErr: true},
+		{name: "missing name", config: `aliases: [{targets: [{model: b}]}]`, wantErr: true},
+		{name: "duplicate name", config: `aliases: [{name: a, targets: [{model: b}]}, {name: a, targets: [{model: c}]}]`, wantErr: true},
+		{name: "no targets", config: `aliases: [{name: a}]`, wantErr: true},
+		{name: "missing model", config: `aliases: [{name: a, targets: [{adapter: b}]}]`, wantErr: true},
+		{name: "negative weight", config: `aliases: [{name: a, targets: [{model: b, weight: -1}]}]`, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			table, err := Parse([]byte(test.config))
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.wantLen, table.Len())
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	table, err := Parse([]byte(testAliases))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		model  string
+		pick   int
+		want   Target
+		wantOk bool
+	}{
+		{name: "not an alias", model: "base"},
+		{name: "single target", model: "base-latest", want: Target{Model: "base"}, wantOk: true},
+		{name: "weighted first target", model: "chat-latest", pick: 89, want: Target{Model: "base", Adapter: "chat-v7", Weight: 90}, wantOk: true},
+		{name: "weighted second target", model: "chat-latest", pick: 90, want: Target{Model: "base", Adapter: "chat-v8", Weight: 10}, wantOk: true},
+		{name: "even split", model: "even", pick: 1, want: Target{Model: "b"}, wantOk: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := table.resolve(test.model, func(int) int { return test.pick })
+			assert.Equal(t, test.wantOk, ok)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestResolverSplitsTraffic(t *testing.T) {
+	table, err := Parse([]byte(testAliases))
+	require.NoError(t, err)
+	resolver := NewResolver()
+	resolver.Update(table)
+
+	counts := map[string]int{}
+	for range 10000 {
+		target, ok := resolver.Resolve("chat-latest")
+		require.True(t, ok)
+		counts[target.ServedModel()]++
+	}
+	assert.InDelta(t, 9000, counts["chat-v7"], 300)
+	assert.InDelta(t, 1000, counts["chat-v8"], 300)
+
+	resolver.Update(nil)
+	_, ok := resolver.Resolve("chat-latest")
+	assert.False(t, ok, "expected the table to be cleared")
+}
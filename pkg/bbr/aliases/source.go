@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aliases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/filewatch"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// DefaultConfigMapKey is the default ConfigMap key holding the alias table.
+const DefaultConfigMapKey = "aliases.yaml"
+
+// DefaultFilePollInterval is the default interval at which the alias file is checked for changes.
+const DefaultFilePollInterval = 10 * time.Second
+
+// NewFileWatcher returns a watcher loading the alias table from a local file, and reloading
+// it when its content changes. An invalid table fails Load, and is logged by Start while the
+// previous table is kept. A non-positive interval defaults to DefaultFilePollInterval.
+func NewFileWatcher(path string, interval time.Duration, resolver *Resolver) *filewatch.Watcher {
+	if interval <= 0 {
+		interval = DefaultFilePollInterval
+	}
+	return filewatch.New(path, interval, fileHandler(path, resolver))
+}
+
+func fileHandler(path string, resolver *Resolver) filewatch.Handler {
+	return func(ctx context.Context, content []byte) error {
+		table, err := Parse(content)
+		if err != nil {
+			return fmt.Errorf("invalid aliases file %q: %w", path, err)
+		}
+		resolver.Update(table)
+		log.FromContext(ctx).V(logutil.DEFAULT).Info("Loaded aliases", "path", path, "aliases", table.Len())
+		return nil
+	}
+}
+
+// ConfigMapReconciler loads the alias table from a key of a ConfigMap. An invalid table is
+// logged and the previous table is kept. Deleting the ConfigMap clears the table.
+type ConfigMapReconciler struct {
+	client.Reader
+	ConfigMap types.NamespacedName
+	Key       string
+	Resolver  *Resolver
+}
+
+func (c *ConfigMapReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).V(logutil.DEFAULT).WithValues("configMap", req.NamespacedName)
+	ctx = ctrl.LoggerInto(ctx, logger)
+
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, req.NamespacedName, cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "Unable to get ConfigMap")
+			return ctrl.Result{}, err
+		}
+		c.Resolver.Update(nil)
+		logger.Info("Cleared aliases, ConfigMap not found")
+		return ctrl.Result{}, nil
+	}
+
+	table, err := c.parse(cm)
+	if err != nil {
+		// Retrying won't fix the content, the next update of the ConfigMap will trigger a reconcile.
+		logger.Error(err, "Invalid aliases, keeping the previous table")
+		return ctrl.Result{}, nil
+	}
+	c.Resolver.Update(table)
+	logger.Info("Loaded aliases", "aliases", table.Len())
+	return ctrl.Result{}, nil
+}
+
+func (c *ConfigMapReconciler) parse(cm *corev1.ConfigMap) (*Table, error) {
+	key := c.Key
+	if key == "" {
+		key = DefaultConfigMapKey
+	}
+	data, ok := cm.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("%w in key %q", errNoAliases, key)
+	}
+	return Parse([]byte(data))
+}
+
+func (c *ConfigMapReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("aliases").
+		For(&corev1.ConfigMap{}).
+		WithEventFilter(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return obj.GetName() == c.ConfigMap.Name && obj.GetNamespace() == c.ConfigMap.Namespace
+		})).
+		Complete(c)
+}
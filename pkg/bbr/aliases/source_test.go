@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aliases
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+func TestFileWatcherReload(t *testing.T) {
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+	path := filepath.Join(t.TempDir(), "aliases.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`aliases: [{name: chat, targets: [{model: v1}]}]`), 0o600))
+
+	resolver := NewResolver()
+	require.NoError(t, NewFileWatcher(path, 0, resolver).Load(ctx))
+	target, ok := resolver.Resolve("chat")
+	require.True(t, ok)
+	assert.Equal(t, "v1", target.Model)
+
+	handler := fileHandler(path, resolver)
+	require.NoError(t, handler(ctx, []byte(`aliases: [{name: chat, targets: [{model: v2}]}]`)))
+	target, _ = resolver.Resolve("chat")
+	assert.Equal(t, "v2", target.Model)
+
+	assert.Error(t, handler(ctx, []byte(`aliases: [{name: chat}]`)))
+	target, _ = resolver.Resolve("chat")
+	assert.Equal(t, "v2", target.Model, "expected the previous table to be kept")
+
+	require.NoError(t, os.WriteFile(path, []byte(`aliases: [{name: chat}]`), 0o600))
+	assert.Error(t, NewFileWatcher(path, 0, NewResolver()).Load(ctx), "expected an invalid file to fail the load")
+}
+
+func TestConfigMapReconciler(t *testing.T) {
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+	name := types.NamespacedName{Namespace: "default", Name: "aliases"}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: name.Namespace, Name: name.Name},
+		Data:       map[string]string{DefaultConfigMapKey: `aliases: [{name: chat, targets: [{model: v1, adapter: lora}]}]`},
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(cm).Build()
+	resolver := NewResolver()
+	reconciler := &ConfigMapReconciler{Reader: fakeClient, ConfigMap: name, Resolver: resolver}
+
+	_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: name})
+	require.NoError(t, err)
+	target, ok := resolver.Resolve("chat")
+	require.True(t, ok)
+	assert.Equal(t, "lora", target.ServedModel())
+
+	// An invalid table keeps the previous one.
+	cm.Data[DefaultConfigMapKey] = `aliases: [{name: chat, targets: []}]`
+	require.NoError(t, fakeClient.Update(ctx, cm))
+	_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: name})
+	require.NoError(t, err)
+	_, ok = resolver.Resolve("chat")
+	assert.True(t, ok)
+
+	// Deleting the ConfigMap clears the table.
+	require.NoError(t, fakeClient.Delete(ctx, cm))
+	_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: name})
+	require.NoError(t, err)
+	_, ok = resolver.Resolve("chat")
+	assert.False(t, ok)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loader
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	configapi "sigs.k8s.io/gateway-api-inference-extension/apix/config/v1alpha1"
+	bbrplugins "sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(configapi.Install(scheme))
+}
+
+// LoadConfig loads the BodyBasedRoutingConfig from the supplied text, instantiates its plugins
+// and returns the request body plugins in the configured order.
+func LoadConfig(configBytes []byte, handle plugins.Handle, logger logr.Logger) ([]bbrplugins.RequestBodyPlugin, error) {
+	rawConfig, err := loadRawConfig(configBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Loaded configuration", "config", rawConfig)
+
+	// If no name was given for the plugin, use it's type as the name
+	for idx, pluginConfig := range rawConfig.Plugins {
+		if pluginConfig.Name == "" {
+			rawConfig.Plugins[idx].Name = pluginConfig.Type
+		}
+	}
+
+	if err = instantiatePlugins(rawConfig.Plugins, handle); err != nil {
+		return nil, fmt.Errorf("failed to instantiate plugins - %w", err)
+	}
+
+	requestBodyPlugins := []bbrplugins.RequestBodyPlugin{}
+	for _, pluginConfig := range rawConfig.Plugins {
+		if plugin, ok := handle.Plugin(pluginConfig.Name).(bbrplugins.RequestBodyPlugin); ok {
+			requestBodyPlugins = append(requestBodyPlugins, plugin)
+		}
+	}
+	if len(requestBodyPlugins) == 0 {
+		return nil, errors.New("no request body plugin was specified")
+	}
+	return requestBodyPlugins, nil
+}
+
+func loadRawConfig(configBytes []byte) (*configapi.BodyBasedRoutingConfig, error) {
+	rawConfig := &configapi.BodyBasedRoutingConfig{}
+
+	codecs := serializer.NewCodecFactory(scheme, serializer.EnableStrict)
+	err := runtime.DecodeInto(codecs.UniversalDecoder(), configBytes, rawConfig)
+	if err != nil {
+		return nil, fmt.Errorf("the configuration is invalid - %w", err)
+	}
+	return rawConfig, nil
+}
+
+func instantiatePlugins(configuredPlugins []configapi.PluginSpec, handle plugins.Handle) error {
+	pluginNames := sets.New[string]() // set of plugin names, a name must be unique
+
+	for _, pluginConfig := range configuredPlugins {
+		if pluginConfig.Type == "" {
+			return fmt.Errorf("plugin definition for '%s' is missing a type", pluginConfig.Name)
+		}
+
+		if pluginNames.Has(pluginConfig.Name) {
+			return fmt.Errorf("plugin name '%s' used more than once", pluginConfig.Name)
+		}
+		pluginNames.Insert(pluginConfig.Name)
+
+		factory, ok := bbrplugins.Registry[pluginConfig.Type]
+		if !ok {
+			return fmt.Errorf("plugin type '%s' is not found in registry", pluginConfig.Type)
+		}
+
+		plugin, err := factory(pluginConfig.Name, pluginConfig.Parameters, handle)
+		if err != nil {
+			return fmt.Errorf("failed to instantiate the plugin type '%s' - %w", pluginConfig.Type, err)
+		}
+
+		handle.AddPlugin(pluginConfig.Name, plugin)
+	}
+
+	return nil
+}
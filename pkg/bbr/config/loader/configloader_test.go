@@ -0,0 +1,217 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loader
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	bbrplugins "sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/plugins/modelextractor"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	testTaggerType = "test-tagger"
+	testOtherType  = "test-other"
+)
+
+type testTagger struct {
+	typedName plugins.TypedName
+}
+
+func (p *testTagger) TypedName() plugins.TypedName {
+	return p.typedName
+}
+
+func (p *testTagger) ProcessRequestBody(_ context.Context, _ *bbrplugins.Request) (*bbrplugins.ImmediateResponse, error) {
+	return nil, nil
+}
+
+// testOther is a plugin which is not a request body plugin.
+type testOther struct {
+	typedName plugins.TypedName
+}
+
+func (p *testOther) TypedName() plugins.TypedName {
+	return p.typedName
+}
+
+func registerTestPlugins() {
+	bbrplugins.Register(modelextractor.ModelExtractorType, modelextractor.ModelExtractorFactory)
+	bbrplugins.Register(testTaggerType, func(name string, parameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+		if string(parameters) == `{"fail":true}` {
+			return nil, errors.New("failed")
+		}
+		return &testTagger{typedName: plugins.TypedName{Type: testTaggerType, Name: name}}, nil
+	})
+	bbrplugins.Register(testOtherType, func(name string, _ json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+		return &testOther{typedName: plugins.TypedName{Type: testOtherType, Name: name}}, nil
+	})
+}
+
+func TestLoadConfig(t *testing.T) {
+	registerTestPlugins()
+
+	tests := []struct {
+		name       string
+		configText string
+		want       []plugins.TypedName
+		wantErr    bool
+	}{
+		{
+			name: "ordered plugins",
+			configText: `
+apiVersion: inference.networking.x-k8s.io/v1alpha1
+kind: BodyBasedRoutingConfig
+plugins:
+- name: guardrail
+  type: test-tagger
+- type: test-other
+- type: model-extractor
+  parameters:
+    rules:
+    - path: model
+      header: X-Gateway-Model-Name
+    - path: metadata.tenant
+      header: X-Gateway-Tenant
+- name: tagger
+  type: test-tagger
+`,
+			want: []plugins.TypedName{
+				{Type: testTaggerType, Name: "guardrail"},
+				{Type: modelextractor.ModelExtractorType, Name: modelextractor.ModelExtractorType},
+				{Type: testTaggerType, Name: "tagger"},
+			},
+		},
+		{
+			name: "wrong kind",
+			configText: `
+apiVersion: inference.networking.x-k8s.io/v1alpha1
+kind: EndpointPickerConfig
+plugins:
+- type: model-extractor
+`,
+			wantErr: true,
+		},
+		{
+			name: "unknown field",
+			configText: `
+apiVersion: inference.networking.x-k8s.io/v1alpha1
+kind: BodyBasedRoutingConfig
+plugins:
+- type: model-extractor
+  order: 1
+`,
+			wantErr: true,
+		},
+		{
+			name: "missing type",
+			configText: `
+apiVersion: inference.networking.x-k8s.io/v1alpha1
+kind: BodyBasedRoutingConfig
+plugins:
+- name: extractor
+`,
+			wantErr: true,
+		},
+		{
+			name: "duplicate name",
+			configText: `
+apiVersion: inference.networking.x-k8s.io/v1alpha1
+kind: BodyBasedRoutingConfig
+plugins:
+- type: model-extractor
+- type: model-extractor
+`,
+			wantErr: true,
+		},
+		{
+			name: "unknown type",
+			configText: `
+apiVersion: inference.networking.x-k8s.io/v1alpha1
+kind: BodyBasedRoutingConfig
+plugins:
+- type: guardrail
+`,
+			wantErr: true,
+		},
+		{
+			name: "factory error",
+			configText: `
+apiVersion: inference.networking.x-k8s.io/v1alpha1
+kind: BodyBasedRoutingConfig
+plugins:
+- type: test-tagger
+  parameters:
+    fail: true
+`,
+			wantErr: true,
+		},
+		{
+			name: "invalid rules",
+			configText: `
+apiVersion: inference.networking.x-k8s.io/v1alpha1
+kind: BodyBasedRoutingConfig
+plugins:
+- type: model-extractor
+  parameters:
+    rules:
+    - path: model
+`,
+			wantErr: true,
+		},
+		{
+			name: "no request body plugin",
+			configText: `
+apiVersion: inference.networking.x-k8s.io/v1alpha1
+kind: BodyBasedRoutingConfig
+plugins:
+- type: test-other
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handle := plugins.NewEppHandle(context.Background())
+			got, err := LoadConfig([]byte(test.configText), handle, logging.NewTestLogger())
+			if err != nil {
+				if !test.wantErr {
+					t.Fatalf("LoadConfig returned unexpected error: %v", err)
+				}
+				return
+			}
+			if test.wantErr {
+				t.Fatal("LoadConfig returned no error, want an error")
+			}
+			gotNames := []plugins.TypedName{}
+			for _, plugin := range got {
+				gotNames = append(gotNames, plugin.TypedName())
+			}
+			if diff := cmp.Diff(test.want, gotNames); diff != "" {
+				t.Errorf("LoadConfig returned unexpected plugins, diff(-want, +got): %v", diff)
+			}
+		})
+	}
+}
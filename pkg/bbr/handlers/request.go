@@ -19,21 +19,28 @@ package handlers
 import (
 	"context"
 	"encoding/json"
-	"fmt"
+	"sort"
+	"strconv"
 
 	basepb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	eppb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	envoyTypePb "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/aliases"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/metrics"
+	bbrplugins "sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/plugins/modelextractor"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/rules"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metadata"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 )
 
-const modelHeader = "X-Gateway-Model-Name"
+const modelHeader = rules.ModelHeader
 
-// HandleRequestBody handles request bodies.
+// HandleRequestBody handles request bodies. The request body plugins process the body in
+// order, and the headers they set are injected in the request headers.
 func (s *Server) HandleRequestBody(ctx context.Context, requestBodyBytes []byte) ([]*eppb.ProcessingResponse, error) {
-	logger := log.FromContext(ctx)
 	var ret []*eppb.ProcessingResponse
 
 	var requestBody map[string]any
@@ -41,10 +48,47 @@ func (s *Server) HandleRequestBody(ctx context.Context, requestBodyBytes []byte)
 		return nil, err
 	}
 
-	modelVal, ok := requestBody["model"]
-	if !ok {
-		metrics.RecordModelNotInBodyCounter()
-		logger.V(logutil.DEFAULT).Info("Request body does not contain model parameter")
+	request := bbrplugins.NewRequest(requestBody)
+	request.BodySize = len(requestBodyBytes)
+	target, aliased := s.resolveAlias(ctx, request)
+
+	for _, plugin := range s.requestBodyPlugins() {
+		immediateResponse, err := plugin.ProcessRequestBody(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		if immediateResponse != nil {
+			log.FromContext(ctx).V(logutil.VERBOSE).Info("Plugin responded to the request", "plugin", plugin.TypedName(),
+				"statusCode", immediateResponse.StatusCode)
+			return []*eppb.ProcessingResponse{buildImmediateResponse(immediateResponse)}, nil
+		}
+	}
+
+	if aliased {
+		// The alias target takes precedence over the model headers set by the plugins.
+		request.SetHeader(modelHeader, target.Model)
+		request.SetHeader(metadata.ModelNameRewriteKey, target.ServedModel())
+	}
+	if request.BodyMutated() {
+		rewritten, err := json.Marshal(request.Body)
+		if err != nil {
+			return nil, err
+		}
+		requestBodyBytes = rewritten
+		request.SetHeader("Content-Length", strconv.Itoa(len(requestBodyBytes)))
+	}
+
+	headers := []*basepb.HeaderValueOption{}
+	for _, header := range request.Headers() {
+		headers = append(headers, &basepb.HeaderValueOption{
+			Header: &basepb.HeaderValue{
+				Key:      header.Key,
+				RawValue: []byte(header.Value),
+			},
+		})
+	}
+
+	if len(headers) == 0 {
 		if s.streaming {
 			ret = append(ret, &eppb.ProcessingResponse{
 				Response: &eppb.ProcessingResponse_RequestHeaders{
@@ -63,15 +107,6 @@ func (s *Server) HandleRequestBody(ctx context.Context, requestBodyBytes []byte)
 		return ret, nil
 	}
 
-	modelStr, ok := modelVal.(string)
-	if !ok {
-		metrics.RecordModelNotParsedCounter()
-		logger.V(logutil.DEFAULT).Info("Model parameter value is not a string")
-		return nil, fmt.Errorf("the model parameter value %v is not a string", modelVal)
-	}
-
-	metrics.RecordSuccessCounter()
-
 	if s.streaming {
 		ret = append(ret, &eppb.ProcessingResponse{
 			Response: &eppb.ProcessingResponse_RequestHeaders{
@@ -79,14 +114,7 @@ func (s *Server) HandleRequestBody(ctx context.Context, requestBodyBytes []byte)
 					Response: &eppb.CommonResponse{
 						ClearRouteCache: true,
 						HeaderMutation: &eppb.HeaderMutation{
-							SetHeaders: []*basepb.HeaderValueOption{
-								{
-									Header: &basepb.HeaderValue{
-										Key:      modelHeader,
-										RawValue: []byte(modelStr),
-									},
-								},
-							},
+							SetHeaders: headers,
 						},
 					},
 				},
@@ -104,15 +132,9 @@ func (s *Server) HandleRequestBody(ctx context.Context, requestBodyBytes []byte)
 						// Necessary so that the new headers are used in the routing decision.
 						ClearRouteCache: true,
 						HeaderMutation: &eppb.HeaderMutation{
-							SetHeaders: []*basepb.HeaderValueOption{
-								{
-									Header: &basepb.HeaderValue{
-										Key:      modelHeader,
-										RawValue: []byte(modelStr),
-									},
-								},
-							},
+							SetHeaders: headers,
 						},
+						BodyMutation: bodyMutation(request.BodyMutated(), requestBodyBytes),
 					},
 				},
 			},
@@ -120,6 +142,73 @@ func (s *Server) HandleRequestBody(ctx context.Context, requestBodyBytes []byte)
 	}, nil
 }
 
+// requestBodyPlugins returns the configured request body plugins, defaulting to the model extractor.
+func (s *Server) requestBodyPlugins() []bbrplugins.RequestBodyPlugin {
+	if len(s.plugins) == 0 {
+		return []bbrplugins.RequestBodyPlugin{modelextractor.New(nil)}
+	}
+	return s.plugins
+}
+
+// resolveAlias rewrites the model of the request body if it is an alias, and returns the
+// target it resolved to.
+func (s *Server) resolveAlias(ctx context.Context, request *bbrplugins.Request) (aliases.Target, bool) {
+	if s.aliases == nil {
+		return aliases.Target{}, false
+	}
+	model, ok := request.Body["model"].(string)
+	if !ok {
+		return aliases.Target{}, false
+	}
+	target, ok := s.aliases.Resolve(model)
+	if !ok {
+		return aliases.Target{}, false
+	}
+	request.Body["model"] = target.ServedModel()
+	request.MarkBodyMutated()
+	metrics.RecordAliasResolvedCounter(model, target.ServedModel())
+	log.FromContext(ctx).V(logutil.VERBOSE).Info("Resolved model alias", "alias", model, "model", target.Model, "adapter", target.Adapter)
+	return target, true
+}
+
+func buildImmediateResponse(response *bbrplugins.ImmediateResponse) *eppb.ProcessingResponse {
+	headers := make([]*basepb.HeaderValueOption, 0, len(response.Headers))
+	for key, value := range response.Headers {
+		headers = append(headers, &basepb.HeaderValueOption{
+			Header: &basepb.HeaderValue{
+				Key:      key,
+				RawValue: []byte(value),
+			},
+		})
+	}
+	sort.Slice(headers, func(i, j int) bool { return headers[i].Header.Key < headers[j].Header.Key })
+	return &eppb.ProcessingResponse{
+		Response: &eppb.ProcessingResponse_ImmediateResponse{
+			ImmediateResponse: &eppb.ImmediateResponse{
+				Status: &envoyTypePb.HttpStatus{
+					Code: envoyTypePb.StatusCode(response.StatusCode),
+				},
+				Headers: &eppb.HeaderMutation{
+					SetHeaders: headers,
+				},
+				Body: response.Body,
+			},
+		},
+	}
+}
+
+// bodyMutation returns the mutation replacing the request body, if it was rewritten.
+func bodyMutation(rewritten bool, requestBodyBytes []byte) *eppb.BodyMutation {
+	if !rewritten {
+		return nil
+	}
+	return &eppb.BodyMutation{
+		Mutation: &eppb.BodyMutation_Body{
+			Body: requestBodyBytes,
+		},
+	}
+}
+
 func addStreamedBodyResponse(responses []*eppb.ProcessingResponse, requestBodyBytes []byte) []*eppb.ProcessingResponse {
 	return append(responses, &eppb.ProcessingResponse{
 		Response: &eppb.ProcessingResponse_RequestBody{
@@ -19,17 +19,24 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"strconv"
 	"strings"
 	"testing"
 
 	basepb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	envoyTypePb "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/google/go-cmp/cmp"
 	"google.golang.org/protobuf/testing/protocmp"
 	metricsutils "k8s.io/component-base/metrics/testutil"
 	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/aliases"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/metrics"
+	bbrplugins "sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/plugins/modelextractor"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/rules"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 )
 
@@ -41,6 +48,7 @@ func TestHandleRequestBody(t *testing.T) {
 		name      string
 		body      map[string]any
 		streaming bool
+		plugins   []bbrplugins.RequestBodyPlugin
 		want      []*extProcPb.ProcessingResponse
 		wantErr   bool
 	}{
@@ -126,6 +134,73 @@ func TestHandleRequestBody(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "custom rules",
+			body: map[string]any{
+				"model":    "foo",
+				"messages": []any{map[string]any{"role": "user", "content": "Tell me a joke"}},
+			},
+			plugins: mustLoadRules(t, `
+rules:
+- path: model
+  header: X-Gateway-Model-Name
+- path: metadata.tenant
+  header: X-Gateway-Tenant
+  default: unknown
+- path: stream
+  header: X-Gateway-Stream
+  type: bool
+- path: messages
+  header: X-Gateway-Message-Count
+  type: length
+`),
+			want: []*extProcPb.ProcessingResponse{
+				{
+					Response: &extProcPb.ProcessingResponse_RequestBody{
+						RequestBody: &extProcPb.BodyResponse{
+							Response: &extProcPb.CommonResponse{
+								ClearRouteCache: true,
+								HeaderMutation: &extProcPb.HeaderMutation{
+									SetHeaders: []*basepb.HeaderValueOption{
+										{
+											Header: &basepb.HeaderValue{
+												Key:      "X-Gateway-Model-Name",
+												RawValue: []byte("foo"),
+											},
+										},
+										{
+											Header: &basepb.HeaderValue{
+												Key:      "X-Gateway-Tenant",
+												RawValue: []byte("unknown"),
+											},
+										},
+										{
+											Header: &basepb.HeaderValue{
+												Key:      "X-Gateway-Message-Count",
+												RawValue: []byte("1"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "rule rejects request",
+			body: map[string]any{
+				"model": "foo",
+			},
+			plugins: mustLoadRules(t, `
+rules:
+- path: user
+  header: X-Gateway-User
+  missing: reject
+`),
+			wantErr: true,
+		},
 		{
 			name: "success-with-streaming",
 			body: map[string]any{
@@ -178,7 +253,7 @@ func TestHandleRequestBody(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			server := &Server{streaming: test.streaming}
+			server := NewServer(test.streaming, test.plugins)
 			bodyBytes, _ := json.Marshal(test.body)
 			resp, err := server.HandleRequestBody(ctx, bodyBytes)
 			if err != nil {
@@ -187,6 +262,9 @@ func TestHandleRequestBody(t *testing.T) {
 				}
 				return
 			}
+			if test.wantErr {
+				t.Fatalf("HandleRequestBody returned no error, want %v", test.wantErr)
+			}
 
 			if diff := cmp.Diff(test.want, resp, protocmp.Transform()); diff != "" {
 				t.Errorf("HandleRequestBody returned unexpected response, diff(-want, +got): %v", diff)
@@ -197,20 +275,227 @@ func TestHandleRequestBody(t *testing.T) {
 	wantMetrics := `
 	# HELP bbr_model_not_in_body_total [ALPHA] Count of times the model was not present in the request body.
 	# TYPE bbr_model_not_in_body_total counter
-	bbr_model_not_in_body_total{} 1
+	bbr_model_not_in_body_total{} 2
 	# HELP bbr_model_not_parsed_total [ALPHA] Count of times the model was in the request body but we could not parse it.
 	# TYPE bbr_model_not_parsed_total counter
 	bbr_model_not_parsed_total{} 1
 	# HELP bbr_success_total [ALPHA] Count of successes pulling model name from body and injecting it in the request headers.
 	# TYPE bbr_success_total counter
-	bbr_success_total{} 1
+	bbr_success_total{} 3
+	# HELP bbr_rule_invalid_total [ALPHA] Count of times a rule rejected the request, because its value could not be coerced or was required.
+	# TYPE bbr_rule_invalid_total counter
+	bbr_rule_invalid_total{rule="X-Gateway-User"} 1
+	bbr_rule_invalid_total{rule="model"} 1
+	# HELP bbr_rule_miss_total [ALPHA] Count of times the path of a rule was not present in the request body.
+	# TYPE bbr_rule_miss_total counter
+	bbr_rule_miss_total{rule="X-Gateway-Stream"} 1
+	bbr_rule_miss_total{rule="X-Gateway-Tenant"} 1
+	bbr_rule_miss_total{rule="model"} 2
 	`
 
-	if err := metricsutils.GatherAndCompare(crmetrics.Registry, strings.NewReader(wantMetrics), "inference_model_request_total"); err != nil {
+	if err := metricsutils.GatherAndCompare(crmetrics.Registry, strings.NewReader(wantMetrics),
+		"bbr_model_not_in_body_total", "bbr_model_not_parsed_total", "bbr_success_total", "bbr_rule_invalid_total", "bbr_rule_miss_total"); err != nil {
 		t.Error(err)
 	}
 }
 
+func TestHandleRequestBodyResolvesAlias(t *testing.T) {
+	metrics.Register()
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+
+	table, err := aliases.Parse([]byte(`aliases: [{name: chat-latest, targets: [{model: base, adapter: chat-v7}]}]`))
+	if err != nil {
+		t.Fatalf("Parse(): %v", err)
+	}
+	resolver := aliases.NewResolver()
+	resolver.Update(table)
+	server := NewServer(false, nil).WithAliases(resolver)
+
+	wantBody := mapToBytes(t, map[string]any{"model": "chat-v7", "prompt": "Tell me a joke"})
+	want := []*extProcPb.ProcessingResponse{
+		{
+			Response: &extProcPb.ProcessingResponse_RequestBody{
+				RequestBody: &extProcPb.BodyResponse{
+					Response: &extProcPb.CommonResponse{
+						ClearRouteCache: true,
+						HeaderMutation: &extProcPb.HeaderMutation{
+							SetHeaders: []*basepb.HeaderValueOption{
+								{
+									Header: &basepb.HeaderValue{
+										Key:      "X-Gateway-Model-Name",
+										RawValue: []byte("base"),
+									},
+								},
+								{
+									Header: &basepb.HeaderValue{
+										Key:      "x-gateway-model-name-rewrite",
+										RawValue: []byte("chat-v7"),
+									},
+								},
+								{
+									Header: &basepb.HeaderValue{
+										Key:      "Content-Length",
+										RawValue: []byte(strconv.Itoa(len(wantBody))),
+									},
+								},
+							},
+						},
+						BodyMutation: &extProcPb.BodyMutation{
+							Mutation: &extProcPb.BodyMutation_Body{
+								Body: wantBody,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	resp, err := server.HandleRequestBody(ctx, mapToBytes(t, map[string]any{"model": "chat-latest", "prompt": "Tell me a joke"}))
+	if err != nil {
+		t.Fatalf("HandleRequestBody returned unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, resp, protocmp.Transform()); diff != "" {
+		t.Errorf("HandleRequestBody returned unexpected response, diff(-want, +got): %v", diff)
+	}
+
+	// Models which are not aliases are left unchanged.
+	resp, err = server.HandleRequestBody(ctx, mapToBytes(t, map[string]any{"model": "base"}))
+	if err != nil {
+		t.Fatalf("HandleRequestBody returned unexpected error: %v", err)
+	}
+	if got := resp[0].GetRequestBody().GetResponse().GetBodyMutation(); got != nil {
+		t.Errorf("HandleRequestBody returned unexpected body mutation: %v", got)
+	}
+}
+
+// testPlugin is a request body plugin running the given function.
+type testPlugin struct {
+	process func(*bbrplugins.Request) *bbrplugins.ImmediateResponse
+}
+
+func (p *testPlugin) TypedName() plugins.TypedName {
+	return plugins.TypedName{Type: "test", Name: "test"}
+}
+
+func (p *testPlugin) ProcessRequestBody(_ context.Context, request *bbrplugins.Request) (*bbrplugins.ImmediateResponse, error) {
+	return p.process(request), nil
+}
+
+func TestHandleRequestBodyRunsPlugins(t *testing.T) {
+	metrics.Register()
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+
+	redact := &testPlugin{process: func(request *bbrplugins.Request) *bbrplugins.ImmediateResponse {
+		delete(request.Body, "user")
+		request.MarkBodyMutated()
+		request.SetHeader("X-Gateway-Redacted", "true")
+		return nil
+	}}
+	reject := &testPlugin{process: func(request *bbrplugins.Request) *bbrplugins.ImmediateResponse {
+		if request.Body["model"] == "forbidden" {
+			return &bbrplugins.ImmediateResponse{
+				StatusCode: 403,
+				Headers:    map[string]string{"X-Gateway-Rejected-By": "test", "Content-Type": "text/plain"},
+				Body:       []byte("model not allowed"),
+			}
+		}
+		return nil
+	}}
+	server := NewServer(false, []bbrplugins.RequestBodyPlugin{redact, reject, modelextractor.New(nil)})
+
+	wantBody := mapToBytes(t, map[string]any{"model": "foo"})
+	want := []*extProcPb.ProcessingResponse{
+		{
+			Response: &extProcPb.ProcessingResponse_RequestBody{
+				RequestBody: &extProcPb.BodyResponse{
+					Response: &extProcPb.CommonResponse{
+						ClearRouteCache: true,
+						HeaderMutation: &extProcPb.HeaderMutation{
+							SetHeaders: []*basepb.HeaderValueOption{
+								{
+									Header: &basepb.HeaderValue{
+										Key:      "X-Gateway-Redacted",
+										RawValue: []byte("true"),
+									},
+								},
+								{
+									Header: &basepb.HeaderValue{
+										Key:      "X-Gateway-Model-Name",
+										RawValue: []byte("foo"),
+									},
+								},
+								{
+									Header: &basepb.HeaderValue{
+										Key:      "Content-Length",
+										RawValue: []byte(strconv.Itoa(len(wantBody))),
+									},
+								},
+							},
+						},
+						BodyMutation: &extProcPb.BodyMutation{
+							Mutation: &extProcPb.BodyMutation_Body{
+								Body: wantBody,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	resp, err := server.HandleRequestBody(ctx, mapToBytes(t, map[string]any{"model": "foo", "user": "alice"}))
+	if err != nil {
+		t.Fatalf("HandleRequestBody returned unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, resp, protocmp.Transform()); diff != "" {
+		t.Errorf("HandleRequestBody returned unexpected response, diff(-want, +got): %v", diff)
+	}
+
+	// A plugin returning an immediate response short-circuits the chain.
+	want = []*extProcPb.ProcessingResponse{
+		{
+			Response: &extProcPb.ProcessingResponse_ImmediateResponse{
+				ImmediateResponse: &extProcPb.ImmediateResponse{
+					Status: &envoyTypePb.HttpStatus{Code: envoyTypePb.StatusCode_Forbidden},
+					Headers: &extProcPb.HeaderMutation{
+						SetHeaders: []*basepb.HeaderValueOption{
+							{
+								Header: &basepb.HeaderValue{
+									Key:      "Content-Type",
+									RawValue: []byte("text/plain"),
+								},
+							},
+							{
+								Header: &basepb.HeaderValue{
+									Key:      "X-Gateway-Rejected-By",
+									RawValue: []byte("test"),
+								},
+							},
+						},
+					},
+					Body: []byte("model not allowed"),
+				},
+			},
+		},
+	}
+	resp, err = server.HandleRequestBody(ctx, mapToBytes(t, map[string]any{"model": "forbidden"}))
+	if err != nil {
+		t.Fatalf("HandleRequestBody returned unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, resp, protocmp.Transform()); diff != "" {
+		t.Errorf("HandleRequestBody returned unexpected response, diff(-want, +got): %v", diff)
+	}
+}
+
+// mustLoadRules returns a model extractor with the given rules.
+func mustLoadRules(t *testing.T, config string) []bbrplugins.RequestBodyPlugin {
+	rs, err := rules.Load([]byte(config))
+	if err != nil {
+		t.Fatalf("Load(): %v", err)
+	}
+	return []bbrplugins.RequestBodyPlugin{modelextractor.New(rs)}
+}
+
 func mapToBytes(t *testing.T, m map[string]any) []byte {
 	// Convert map to JSON byte array
 	bytes, err := json.Marshal(m)
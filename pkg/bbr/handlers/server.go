@@ -27,18 +27,30 @@ import (
 	"google.golang.org/grpc/status"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/aliases"
+	bbrplugins "sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/plugins"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 	requtil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/request"
 )
 
-func NewServer(streaming bool) *Server {
-	return &Server{streaming: streaming}
+// NewServer creates a new Server. The request body plugins process the request body in
+// order. Without plugins, the model name is copied into the X-Gateway-Model-Name header.
+func NewServer(streaming bool, plugins []bbrplugins.RequestBodyPlugin) *Server {
+	return &Server{streaming: streaming, plugins: plugins}
 }
 
 // Server implements the Envoy external processing server.
 // https://www.envoyproxy.io/docs/envoy/latest/api-v3/service/ext_proc/v3/external_processor.proto
 type Server struct {
 	streaming bool
+	plugins   []bbrplugins.RequestBodyPlugin
+	aliases   *aliases.Resolver
+}
+
+// WithAliases enables the resolution of model aliases. A nil resolver disables it.
+func (s *Server) WithAliases(resolver *aliases.Resolver) *Server {
+	s.aliases = resolver
+	return s
 }
 
 func (s *Server) Process(srv extProcPb.ExternalProcessor_ProcessServer) error {
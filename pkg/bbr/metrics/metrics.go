@@ -53,6 +53,38 @@ var (
 		},
 		[]string{},
 	)
+	ruleSuccessCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: component,
+			Name:      "rule_success_total",
+			Help:      metricsutil.HelpMsgWithStability("Count of times a rule found its value in the request body and injected it in the request headers.", compbasemetrics.ALPHA),
+		},
+		[]string{"rule"},
+	)
+	ruleMissCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: component,
+			Name:      "rule_miss_total",
+			Help:      metricsutil.HelpMsgWithStability("Count of times the path of a rule was not present in the request body.", compbasemetrics.ALPHA),
+		},
+		[]string{"rule"},
+	)
+	ruleInvalidCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: component,
+			Name:      "rule_invalid_total",
+			Help:      metricsutil.HelpMsgWithStability("Count of times a rule rejected the request, because its value could not be coerced or was required.", compbasemetrics.ALPHA),
+		},
+		[]string{"rule"},
+	)
+	aliasResolvedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: component,
+			Name:      "alias_resolved_total",
+			Help:      metricsutil.HelpMsgWithStability("Count of requests whose model alias was resolved, broken out by alias and served model.", compbasemetrics.ALPHA),
+		},
+		[]string{"alias", "target_model"},
+	)
 
 	// TODO: Uncomment and use this metrics once the core server implementation has handling to skip body parsing if header exists.
 	/*
@@ -75,6 +107,10 @@ func Register() {
 		metrics.Registry.MustRegister(successCounter)
 		metrics.Registry.MustRegister(modelNotInBodyCounter)
 		metrics.Registry.MustRegister(modelNotParsedCounter)
+		metrics.Registry.MustRegister(ruleSuccessCounter)
+		metrics.Registry.MustRegister(ruleMissCounter)
+		metrics.Registry.MustRegister(ruleInvalidCounter)
+		metrics.Registry.MustRegister(aliasResolvedCounter)
 		// metrics.Registry.MustRegister(modelAlreadyPresentInHeaderCounter)
 	})
 }
@@ -94,6 +130,26 @@ func RecordModelNotParsedCounter() {
 	modelNotParsedCounter.WithLabelValues().Inc()
 }
 
+// RecordRuleSuccessCounter records the number of times a rule injected its value in the request headers.
+func RecordRuleSuccessCounter(rule string) {
+	ruleSuccessCounter.WithLabelValues(rule).Inc()
+}
+
+// RecordRuleMissCounter records the number of times the path of a rule was not found in the request body.
+func RecordRuleMissCounter(rule string) {
+	ruleMissCounter.WithLabelValues(rule).Inc()
+}
+
+// RecordRuleInvalidCounter records the number of times a rule rejected the request.
+func RecordRuleInvalidCounter(rule string) {
+	ruleInvalidCounter.WithLabelValues(rule).Inc()
+}
+
+// RecordAliasResolvedCounter records the number of times an alias was resolved to the target model.
+func RecordAliasResolvedCounter(alias, targetModel string) {
+	aliasResolvedCounter.WithLabelValues(alias, targetModel).Inc()
+}
+
 /*
 // RecordModelAlreadyInHeaderCounter records the number of times the model was already found in the request headers.
 func RecordModelAlreadyInHeaderCounter() {
@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package modelextractor implements the default request body plugin of Body-Based
+// Routing, which copies body fields, by default the model name, into request headers.
+package modelextractor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/metrics"
+	bbrplugins "sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/rules"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	ModelExtractorType = "model-extractor"
+)
+
+// compile-time type assertion
+var _ bbrplugins.RequestBodyPlugin = &ModelExtractor{}
+
+// ModelExtractorFactory defines the factory function for ModelExtractor. The parameters
+// hold the extraction rules, in the format of a rules file. Without rules, the model name
+// is copied into the X-Gateway-Model-Name header.
+func ModelExtractorFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	ruleSet := rules.Default()
+	if rawParameters != nil {
+		parameters := rules.Config{}
+		if err := json.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the %s plugin. Error: %s", ModelExtractorType, err)
+		}
+		if len(parameters.Rules) > 0 {
+			var err error
+			if ruleSet, err = rules.NewRuleSet(parameters.Rules); err != nil {
+				return nil, fmt.Errorf("invalid parameters of the %s plugin - %w", ModelExtractorType, err)
+			}
+		}
+	}
+	return New(ruleSet).WithName(name), nil
+}
+
+// New initializes a new ModelExtractor and returns its pointer. A nil rule set defaults to
+// copying the model name into the X-Gateway-Model-Name header.
+func New(ruleSet *rules.RuleSet) *ModelExtractor {
+	if ruleSet == nil {
+		ruleSet = rules.Default()
+	}
+	return &ModelExtractor{
+		typedName: plugins.TypedName{Type: ModelExtractorType, Name: ModelExtractorType},
+		rules:     ruleSet,
+	}
+}
+
+// ModelExtractor evaluates the body-to-header extraction rules against the request body.
+type ModelExtractor struct {
+	typedName plugins.TypedName
+	rules     *rules.RuleSet
+}
+
+// TypedName returns the type and name tuple of this plugin instance.
+func (m *ModelExtractor) TypedName() plugins.TypedName {
+	return m.typedName
+}
+
+// WithName sets the name of the plugin.
+func (m *ModelExtractor) WithName(name string) *ModelExtractor {
+	m.typedName.Name = name
+	return m
+}
+
+// ProcessRequestBody sets the headers extracted from the request body. A rule rejecting the
+// request fails it.
+func (m *ModelExtractor) ProcessRequestBody(ctx context.Context, request *bbrplugins.Request) (*bbrplugins.ImmediateResponse, error) {
+	logger := log.FromContext(ctx)
+	results, err := m.rules.Evaluate(request.Body)
+	for _, result := range results {
+		recordRuleResult(result)
+		switch result.Outcome {
+		case rules.OutcomeExtracted, rules.OutcomeDefaulted:
+			request.SetHeader(result.Rule.Header, result.Value)
+		case rules.OutcomeMissing:
+			logger.V(logutil.DEFAULT).Info("Request body does not contain rule path", "rule", result.Rule.Name, "path", result.Rule.Path)
+		case rules.OutcomeInvalid:
+			logger.V(logutil.DEFAULT).Info("Rule rejected the request", "rule", result.Rule.Name, "error", result.Err)
+		}
+	}
+	return nil, err
+}
+
+// recordRuleResult records the per-rule counters. The model counters are kept for the rule
+// setting the model name header.
+func recordRuleResult(result rules.Result) {
+	isModelRule := strings.EqualFold(result.Rule.Header, rules.ModelHeader)
+	switch result.Outcome {
+	case rules.OutcomeExtracted:
+		metrics.RecordRuleSuccessCounter(result.Rule.Name)
+		if isModelRule {
+			metrics.RecordSuccessCounter()
+		}
+	case rules.OutcomeDefaulted, rules.OutcomeMissing:
+		metrics.RecordRuleMissCounter(result.Rule.Name)
+		if isModelRule {
+			metrics.RecordModelNotInBodyCounter()
+		}
+	case rules.OutcomeInvalid:
+		metrics.RecordRuleInvalidCounter(result.Rule.Name)
+		if isModelRule {
+			metrics.RecordModelNotParsedCounter()
+		}
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plugins defines the extension points of Body-Based Routing. It mirrors the
+// plugin registry of the endpoint picker: plugins are instantiated by registered
+// factories from a BodyBasedRoutingConfig, and the request body plugins process the
+// request body in the configured order.
+package plugins
+
+import (
+	"context"
+	"strings"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+)
+
+// RequestBodyPlugin processes the parsed request body. It can set request headers, mutate
+// the body, or short-circuit the request with an immediate response.
+type RequestBodyPlugin interface {
+	plugins.Plugin
+	// ProcessRequestBody processes the request. Returning an immediate response stops the
+	// processing and sends the response to the client. Returning an error fails the request.
+	ProcessRequestBody(ctx context.Context, request *Request) (*ImmediateResponse, error)
+}
+
+// Header is a request header set by a plugin.
+type Header struct {
+	Key   string
+	Value string
+}
+
+// Request is the request processed by the request body plugins.
+type Request struct {
+	// Body is the parsed request body. Plugins mutating it must call MarkBodyMutated.
+	Body map[string]any
+	// BodySize is the size in bytes of the request body, as received.
+	BodySize int
+
+	headers     []Header
+	bodyMutated bool
+}
+
+// NewRequest creates a Request for the parsed body.
+func NewRequest(body map[string]any) *Request {
+	return &Request{Body: body}
+}
+
+// SetHeader sets a request header, replacing any value previously set by a plugin.
+func (r *Request) SetHeader(key, value string) {
+	for i := range r.headers {
+		if strings.EqualFold(r.headers[i].Key, key) {
+			r.headers[i].Value = value
+			return
+		}
+	}
+	r.headers = append(r.headers, Header{Key: key, Value: value})
+}
+
+// Headers returns the request headers set by the plugins, in the order they were first set.
+func (r *Request) Headers() []Header {
+	return r.headers
+}
+
+// MarkBodyMutated records that the body was mutated and must be forwarded re-encoded.
+func (r *Request) MarkBodyMutated() {
+	r.bodyMutated = true
+}
+
+// BodyMutated returns true if a plugin mutated the body.
+func (r *Request) BodyMutated() bool {
+	return r.bodyMutated
+}
+
+// ImmediateResponse is a response sent to the client instead of forwarding the request.
+type ImmediateResponse struct {
+	StatusCode int
+	Headers    map[string]string
+	Body       []byte
+}
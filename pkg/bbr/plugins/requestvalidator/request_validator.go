@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package requestvalidator implements a request body plugin of Body-Based Routing, which
+// rejects requests not matching their OpenAI API schema or exceeding the configured limits.
+package requestvalidator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	bbrplugins "sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/common/validation"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	RequestValidatorType = "request-validator"
+)
+
+// compile-time type assertion
+var _ bbrplugins.RequestBodyPlugin = &RequestValidator{}
+
+// RequestValidatorFactory defines the factory function for RequestValidator. The parameters
+// hold the limits, none are enforced by default.
+func RequestValidatorFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	limits := validation.Limits{}
+	if rawParameters != nil {
+		if err := json.Unmarshal(rawParameters, &limits); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the %s plugin. Error: %s", RequestValidatorType, err)
+		}
+	}
+	validator, err := validation.NewValidator(limits)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters of the %s plugin - %w", RequestValidatorType, err)
+	}
+	return New(validator).WithName(name), nil
+}
+
+// New initializes a new RequestValidator and returns its pointer.
+func New(validator *validation.Validator) *RequestValidator {
+	return &RequestValidator{
+		typedName: plugins.TypedName{Type: RequestValidatorType, Name: RequestValidatorType},
+		validator: validator,
+	}
+}
+
+// RequestValidator validates the request body against the OpenAI API schemas and limits.
+type RequestValidator struct {
+	typedName plugins.TypedName
+	validator *validation.Validator
+}
+
+// TypedName returns the type and name tuple of this plugin instance.
+func (v *RequestValidator) TypedName() plugins.TypedName {
+	return v.typedName
+}
+
+// WithName sets the name of the plugin.
+func (v *RequestValidator) WithName(name string) *RequestValidator {
+	v.typedName.Name = name
+	return v
+}
+
+// ProcessRequestBody rejects invalid requests with a 400 listing the violations.
+func (v *RequestValidator) ProcessRequestBody(ctx context.Context, request *bbrplugins.Request) (*bbrplugins.ImmediateResponse, error) {
+	err := v.validator.ValidateSize(request.BodySize)
+	if err == nil {
+		err = v.validator.Validate(request.Body)
+	}
+	if err == nil {
+		return nil, nil
+	}
+	log.FromContext(ctx).V(logutil.DEFAULT).Info("Rejected invalid request", "error", err)
+	return &bbrplugins.ImmediateResponse{
+		StatusCode: http.StatusBadRequest,
+		Headers:    map[string]string{"Content-Type": "text/plain"},
+		Body:       []byte(err.Error()),
+	}, nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestvalidator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	bbrplugins "sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/plugins"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+func TestProcessRequestBody(t *testing.T) {
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+	plugin, err := RequestValidatorFactory("validator", json.RawMessage(`{"maxBodyBytes": 64, "maxMessages": 1}`), nil)
+	require.NoError(t, err)
+	validator := plugin.(*RequestValidator)
+	assert.Equal(t, "validator", validator.TypedName().Name)
+
+	tests := []struct {
+		name     string
+		body     string
+		wantBody string
+	}{
+		{
+			name: "valid",
+			body: `{"model": "m", "messages": [{"role": "user", "content": "hi"}]}`,
+		},
+		{
+			name:     "too many messages",
+			body:     `{"model": "m", "messages": [{"role": "user"}, {"role": "user"}]}`,
+			wantBody: "invalid request: messages: 2 messages exceed the limit of 1",
+		},
+		{
+			name:     "oversized body",
+			body:     `{"model": "m", "prompt": "a prompt longer than the sixty four bytes limit"}`,
+			wantBody: "invalid request: request body size exceeds the limit of 64 bytes",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			body := map[string]any{}
+			require.NoError(t, json.Unmarshal([]byte(test.body), &body))
+			request := bbrplugins.NewRequest(body)
+			request.BodySize = len(test.body)
+
+			resp, err := validator.ProcessRequestBody(ctx, request)
+			require.NoError(t, err)
+			if test.wantBody == "" {
+				assert.Nil(t, resp)
+				return
+			}
+			require.NotNil(t, resp)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			assert.Equal(t, test.wantBody, string(resp.Body))
+		})
+	}
+
+	_, err = RequestValidatorFactory("validator", json.RawMessage(`{"maxTokens": -1}`), nil)
+	assert.Error(t, err)
+}
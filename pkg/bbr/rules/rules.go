@@ -0,0 +1,396 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rules implements the body-to-header extraction rules of Body-Based Routing.
+//
+// A rule copies the value found at a JSON path of the request body into a request
+// header, so that the Gateway can route on it. For example, the following rules
+// file routes on the model name, the tenant of the request and the number of
+// messages:
+//
+//	rules:
+//	- path: model
+//	  header: X-Gateway-Model-Name
+//	- path: metadata.tenant
+//	  header: X-Gateway-Tenant
+//	  default: unknown
+//	- path: messages
+//	  header: X-Gateway-Message-Count
+//	  type: length
+package rules
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Type is the type a value is coerced to before being written to a header.
+type Type string
+
+const (
+	// TypeString accepts string values only.
+	TypeString Type = "string"
+	// TypeNumber accepts numbers and numeric strings.
+	TypeNumber Type = "number"
+	// TypeInteger accepts integral numbers and integer strings.
+	TypeInteger Type = "integer"
+	// TypeBool accepts booleans and the "true" and "false" strings.
+	TypeBool Type = "bool"
+	// TypeLength writes the length of a string, an array or an object.
+	TypeLength Type = "length"
+	// TypeAny accepts any value. Arrays and objects are written as JSON.
+	TypeAny Type = "any"
+)
+
+// MissingPolicy determines what happens when the path of a rule is not found in the body.
+type MissingPolicy string
+
+const (
+	// MissingSkip leaves the header unset.
+	MissingSkip MissingPolicy = "skip"
+	// MissingDefault sets the header to the default value of the rule.
+	MissingDefault MissingPolicy = "default"
+	// MissingReject rejects the request.
+	MissingReject MissingPolicy = "reject"
+)
+
+// ModelHeader is the header set by the default rule.
+const ModelHeader = "X-Gateway-Model-Name"
+
+// Rule maps a JSON path of the request body to a request header.
+type Rule struct {
+	// Name identifies the rule in the metrics. Defaults to the header.
+	Name string `json:"name,omitempty"`
+	// Path is the JSON path of the value, made of dot separated keys and [n] array
+	// indexes, with an optional "$." prefix, e.g. "metadata.tenant" or "messages[0].role".
+	Path string `json:"path"`
+	// Header is the request header the value is written to.
+	Header string `json:"header"`
+	// Type is the type the value is coerced to. Defaults to string.
+	Type Type `json:"type,omitempty"`
+	// Default is the value written to the header when the path is missing and the
+	// missing policy is default.
+	Default *string `json:"default,omitempty"`
+	// Missing is the policy applied when the path is missing. Defaults to default when
+	// a default value is set, and to skip otherwise.
+	Missing MissingPolicy `json:"missing,omitempty"`
+
+	segments []segment
+}
+
+// Config is the content of a rules file.
+type Config struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Outcome is the outcome of the evaluation of a rule against a request body.
+type Outcome string
+
+const (
+	// OutcomeExtracted means the value was found and written to the header.
+	OutcomeExtracted Outcome = "extracted"
+	// OutcomeDefaulted means the path was missing and the default value was written to the header.
+	OutcomeDefaulted Outcome = "defaulted"
+	// OutcomeMissing means the path was missing and the header was left unset.
+	OutcomeMissing Outcome = "missing"
+	// OutcomeInvalid means the value could not be coerced to the type of the rule, or a
+	// required path was missing. The request is rejected.
+	OutcomeInvalid Outcome = "invalid"
+)
+
+// Result is the result of the evaluation of a single rule.
+type Result struct {
+	Rule    *Rule
+	Outcome Outcome
+	// Value is the header value, set when the outcome is extracted or defaulted.
+	Value string
+	// Err is set when the outcome is invalid.
+	Err error
+}
+
+// RuleSet is an ordered, validated set of rules.
+type RuleSet struct {
+	rules []*Rule
+}
+
+// Default returns the rule set copying the model name into the X-Gateway-Model-Name header.
+func Default() *RuleSet {
+	rs, _ := NewRuleSet([]Rule{{Name: "model", Path: "model", Header: ModelHeader}})
+	return rs
+}
+
+// LoadFile loads a rule set from a YAML or JSON rules file.
+func LoadFile(path string) (*RuleSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules file %q: %w", path, err)
+	}
+	return Load(data)
+}
+
+// Load loads a rule set from the YAML or JSON content of a rules file.
+func Load(data []byte) (*RuleSet, error) {
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse rules: %w", err)
+	}
+	return NewRuleSet(config.Rules)
+}
+
+// NewRuleSet validates the rules, sets their defaults and returns them as a rule set.
+func NewRuleSet(rules []Rule) (*RuleSet, error) {
+	if len(rules) == 0 {
+		return nil, errors.New("at least one rule is required")
+	}
+	rs := &RuleSet{}
+	names := make(map[string]bool)
+	for i := range rules {
+		rule := rules[i]
+		if err := rule.complete(); err != nil {
+			return nil, fmt.Errorf("invalid rule %d: %w", i, err)
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("invalid rule %d: duplicate rule name %q", i, rule.Name)
+		}
+		names[rule.Name] = true
+		rs.rules = append(rs.rules, &rule)
+	}
+	return rs, nil
+}
+
+// Rules returns the rules of the set, in order.
+func (rs *RuleSet) Rules() []*Rule {
+	return rs.rules
+}
+
+// Evaluate evaluates the rules against the request body, in order. It returns the result of
+// every rule, and an error if any rule rejects the request.
+func (rs *RuleSet) Evaluate(body map[string]any) ([]Result, error) {
+	results := make([]Result, 0, len(rs.rules))
+	var errs []error
+	for _, rule := range rs.rules {
+		result := rule.evaluate(body)
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+		results = append(results, result)
+	}
+	return results, errors.Join(errs...)
+}
+
+func (r *Rule) complete() error {
+	if r.Header == "" {
+		return errors.New("header is required")
+	}
+	if r.Name == "" {
+		r.Name = r.Header
+	}
+	segments, err := parsePath(r.Path)
+	if err != nil {
+		return err
+	}
+	r.segments = segments
+
+	switch r.Type {
+	case "":
+		r.Type = TypeString
+	case TypeString, TypeNumber, TypeInteger, TypeBool, TypeLength, TypeAny:
+	default:
+		return fmt.Errorf("unknown type %q", r.Type)
+	}
+
+	switch r.Missing {
+	case "":
+		r.Missing = MissingSkip
+		if r.Default != nil {
+			r.Missing = MissingDefault
+		}
+	case MissingDefault:
+		if r.Default == nil {
+			return fmt.Errorf("missing policy %q requires a default value", MissingDefault)
+		}
+	case MissingSkip, MissingReject:
+	default:
+		return fmt.Errorf("unknown missing policy %q", r.Missing)
+	}
+	return nil
+}
+
+func (r *Rule) evaluate(body map[string]any) Result {
+	value, ok := lookup(body, r.segments)
+	if !ok {
+		switch r.Missing {
+		case MissingDefault:
+			return Result{Rule: r, Outcome: OutcomeDefaulted, Value: *r.Default}
+		case MissingReject:
+			return Result{Rule: r, Outcome: OutcomeInvalid, Err: fmt.Errorf("the request body does not contain %q", r.Path)}
+		default:
+			return Result{Rule: r, Outcome: OutcomeMissing}
+		}
+	}
+	headerValue, err := coerce(value, r.Type)
+	if err != nil {
+		return Result{Rule: r, Outcome: OutcomeInvalid, Err: fmt.Errorf("the %q value %v: %w", r.Path, value, err)}
+	}
+	return Result{Rule: r, Outcome: OutcomeExtracted, Value: headerValue}
+}
+
+// segment is a key or an array index of a path.
+type segment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+func parsePath(path string) ([]segment, error) {
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if trimmed == "" {
+		return nil, fmt.Errorf("invalid path %q: path is empty", path)
+	}
+	var segments []segment
+	for _, part := range strings.Split(trimmed, ".") {
+		key, rest, _ := strings.Cut(part, "[")
+		if key == "" && rest == "" {
+			return nil, fmt.Errorf("invalid path %q: empty key", path)
+		}
+		if key != "" {
+			segments = append(segments, segment{key: key})
+		}
+		for rest != "" {
+			rawIndex, after, ok := strings.Cut(rest, "]")
+			if !ok {
+				return nil, fmt.Errorf("invalid path %q: unterminated index", path)
+			}
+			index, err := strconv.Atoi(rawIndex)
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid path %q: invalid index %q", path, rawIndex)
+			}
+			segments = append(segments, segment{index: index, isIndex: true})
+			if after == "" {
+				break
+			}
+			if !strings.HasPrefix(after, "[") {
+				return nil, fmt.Errorf("invalid path %q: unexpected %q", path, after)
+			}
+			rest = after[1:]
+		}
+	}
+	return segments, nil
+}
+
+// lookup returns the value found at the path. A null value is reported as missing.
+func lookup(body map[string]any, segments []segment) (any, bool) {
+	var current any = body
+	for _, seg := range segments {
+		if seg.isIndex {
+			array, ok := current.([]any)
+			if !ok || seg.index >= len(array) {
+				return nil, false
+			}
+			current = array[seg.index]
+			continue
+		}
+		object, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = object[seg.key]; !ok {
+			return nil, false
+		}
+	}
+	return current, current != nil
+}
+
+func coerce(value any, typ Type) (string, error) {
+	switch typ {
+	case TypeString:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+		return "", errors.New("is not a string")
+	case TypeNumber:
+		f, err := toFloat(value)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	case TypeInteger:
+		f, err := toFloat(value)
+		if err != nil {
+			return "", err
+		}
+		if f != float64(int64(f)) {
+			return "", errors.New("is not an integer")
+		}
+		return strconv.FormatInt(int64(f), 10), nil
+	case TypeBool:
+		switch v := value.(type) {
+		case bool:
+			return strconv.FormatBool(v), nil
+		case string:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return "", errors.New("is not a boolean")
+			}
+			return strconv.FormatBool(b), nil
+		}
+		return "", errors.New("is not a boolean")
+	case TypeLength:
+		switch v := value.(type) {
+		case string:
+			return strconv.Itoa(len(v)), nil
+		case []any:
+			return strconv.Itoa(len(v)), nil
+		case map[string]any:
+			return strconv.Itoa(len(v)), nil
+		}
+		return "", errors.New("has no length")
+	default:
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		return string(encoded), nil
+	}
+}
+
+func toFloat(value any) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, errors.New("is not a number")
+		}
+		return f, nil
+	}
+	return 0, errors.New("is not a number")
+}
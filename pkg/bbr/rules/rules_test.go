@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		want    []Rule
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			config: `
+rules:
+- path: model
+  header: X-Gateway-Model-Name
+- path: $.metadata.tenant
+  header: X-Gateway-Tenant
+  default: unknown
+`,
+			want: []Rule{
+				{Name: "X-Gateway-Model-Name", Path: "model", Header: "X-Gateway-Model-Name", Type: TypeString, Missing: MissingSkip},
+				{Name: "X-Gateway-Tenant", Path: "$.metadata.tenant", Header: "X-Gateway-Tenant", Type: TypeString, Missing: MissingDefault, Default: ptr("unknown")},
+			},
+		},
+		{
+			name:   "json",
+			config: `{"rules": [{"name": "stream", "path": "stream", "header": "X-Stream", "type": "bool", "missing": "reject"}]}`,
+			want: []Rule{
+				{Name: "stream", Path: "stream", Header: "X-Stream", Type: TypeBool, Missing: MissingReject},
+			},
+		},
+		{name: "no rules", config: `rules: []`, wantErr: true},
+		{name: "unknown field", config: `rules: [{path: model, header: X-Model, required: true}]`, wantErr: true},
+		{name: "missing header", config: `rules: [{path: model}]`, wantErr: true},
+		{name: "empty path", config: `rules: [{path: "$", header: X-Model}]`, wantErr: true},
+		{name: "invalid index", config: `rules: [{path: "messages[a]", header: X-Model}]`, wantErr: true},
+		{name: "unknown type", config: `rules: [{path: model, header: X-Model, type: date}]`, wantErr: true},
+		{name: "unknown policy", config: `rules: [{path: model, header: X-Model, missing: ignore}]`, wantErr: true},
+		{name: "default policy without default", config: `rules: [{path: model, header: X-Model, missing: default}]`, wantErr: true},
+		{name: "duplicate names", config: `rules: [{path: model, header: X-Model}, {path: name, header: X-Model}]`, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rs, err := Load([]byte(test.config))
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			got := []Rule{}
+			for _, rule := range rs.Rules() {
+				r := *rule
+				r.segments = nil
+				got = append(got, r)
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	body := map[string]any{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"model": "foo",
+		"stream": true,
+		"user": 42,
+		"temperature": 0.5,
+		"metadata": {"tenant": "team-a", "priority": "3"},
+		"messages": [{"role": "system", "content": "Be nice"}, {"role": "user", "content": "Tell me a joke"}],
+		"tools": null
+	}`), &body))
+
+	tests := []struct {
+		name        string
+		rule        Rule
+		wantOutcome Outcome
+		wantValue   string
+	}{
+		{name: "string", rule: Rule{Path: "model", Header: "h"}, wantOutcome: OutcomeExtracted, wantValue: "foo"},
+		{name: "nested string", rule: Rule{Path: "$.metadata.tenant", Header: "h"}, wantOutcome: OutcomeExtracted, wantValue: "team-a"},
+		{name: "array index", rule: Rule{Path: "messages[1].role", Header: "h"}, wantOutcome: OutcomeExtracted, wantValue: "user"},
+		{name: "bool", rule: Rule{Path: "stream", Header: "h", Type: TypeBool}, wantOutcome: OutcomeExtracted, wantValue: "true"},
+		{name: "number", rule: Rule{Path: "temperature", Header: "h", Type: TypeNumber}, wantOutcome: OutcomeExtracted, wantValue: "0.5"},
+		{name: "integer from string", rule: Rule{Path: "metadata.priority", Header: "h", Type: TypeInteger}, wantOutcome: OutcomeExtracted, wantValue: "3"},
+		{name: "length", rule: Rule{Path: "messages", Header: "h", Type: TypeLength}, wantOutcome: OutcomeExtracted, wantValue: "2"},
+		{name: "any number", rule: Rule{Path: "user", Header: "h", Type: TypeAny}, wantOutcome: OutcomeExtracted, wantValue: "42"},
+		{name: "any object", rule: Rule{Path: "metadata", Header: "h", Type: TypeAny}, wantOutcome: OutcomeExtracted, wantValue: `{"priority":"3","tenant":"team-a"}`},
+		{name: "not a string", rule: Rule{Path: "user", Header: "h"}, wantOutcome: OutcomeInvalid},
+		{name: "not an integer", rule: Rule{Path: "temperature", Header: "h", Type: TypeInteger}, wantOutcome: OutcomeInvalid},
+		{name: "no length", rule: Rule{Path: "user", Header: "h", Type: TypeLength}, wantOutcome: OutcomeInvalid},
+		{name: "missing", rule: Rule{Path: "metadata.region", Header: "h"}, wantOutcome: OutcomeMissing},
+		{name: "null is missing", rule: Rule{Path: "tools", Header: "h"}, wantOutcome: OutcomeMissing},
+		{name: "index out of range", rule: Rule{Path: "messages[2].role", Header: "h"}, wantOutcome: OutcomeMissing},
+		{name: "missing with default", rule: Rule{Path: "metadata.region", Header: "h", Default: ptr("us")}, wantOutcome: OutcomeDefaulted, wantValue: "us"},
+		{name: "missing rejected", rule: Rule{Path: "metadata.region", Header: "h", Missing: MissingReject}, wantOutcome: OutcomeInvalid},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rs, err := NewRuleSet([]Rule{test.rule})
+			require.NoError(t, err)
+			results, err := rs.Evaluate(body)
+			require.Len(t, results, 1)
+			assert.Equal(t, test.wantOutcome, results[0].Outcome)
+			assert.Equal(t, test.wantValue, results[0].Value)
+			if test.wantOutcome == OutcomeInvalid {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
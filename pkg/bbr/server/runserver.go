@@ -28,7 +28,9 @@ import (
 
 	"sigs.k8s.io/gateway-api-inference-extension/internal/runnable"
 	tlsutil "sigs.k8s.io/gateway-api-inference-extension/internal/tls"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/aliases"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/handlers"
+	bbrplugins "sigs.k8s.io/gateway-api-inference-extension/pkg/bbr/plugins"
 )
 
 // ExtProcServerRunner provides methods to manage an external process server.
@@ -36,6 +38,10 @@ type ExtProcServerRunner struct {
 	GrpcPort      int
 	SecureServing bool
 	Streaming     bool
+	// RequestBodyPlugins process the request body in order. Defaults to copying the model name.
+	RequestBodyPlugins []bbrplugins.RequestBodyPlugin
+	// Aliases resolves model aliases. Nil disables alias resolution.
+	Aliases *aliases.Resolver
 }
 
 func NewDefaultExtProcServerRunner(port int, streaming bool) *ExtProcServerRunner {
@@ -65,7 +71,7 @@ func (r *ExtProcServerRunner) AsRunnable(logger logr.Logger) manager.Runnable {
 
 		extProcPb.RegisterExternalProcessorServer(
 			srv,
-			handlers.NewServer(r.Streaming),
+			handlers.NewServer(r.Streaming, r.RequestBodyPlugins).WithAliases(r.Aliases),
 		)
 
 		// Forward to the gRPC runnable.
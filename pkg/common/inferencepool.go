@@ -0,0 +1,37 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+// TargetPorts returns the ports of the model servers selected by an InferencePool, either its
+// TargetPorts or its single TargetPortNumber. It returns nil if neither is set.
+func TargetPorts(spec *v1.InferencePoolSpec) []int32 {
+	if len(spec.TargetPorts) > 0 {
+		ports := make([]int32, len(spec.TargetPorts))
+		for i, port := range spec.TargetPorts {
+			ports[i] = int32(port)
+		}
+		return ports
+	}
+	if spec.TargetPortNumber != 0 {
+		return []int32{spec.TargetPortNumber}
+	}
+	return nil
+}
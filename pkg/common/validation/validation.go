@@ -0,0 +1,197 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package validation validates OpenAI request bodies against embedded schemas of the chat
+// completions, completions and embeddings APIs, and enforces configurable size limits. It
+// is shared by Body-Based Routing and the endpoint picker.
+package validation
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+)
+
+// Endpoint is an OpenAI API endpoint with a request schema.
+type Endpoint string
+
+const (
+	ChatCompletions Endpoint = "chat_completions"
+	Completions     Endpoint = "completions"
+	Embeddings      Endpoint = "embeddings"
+)
+
+//go:embed schemas/*.json
+var schemaFiles embed.FS
+
+// Limits are the size limits enforced on requests. A zero limit is not enforced.
+type Limits struct {
+	// MaxBodyBytes is the maximum size of the request body in bytes.
+	MaxBodyBytes int `json:"maxBodyBytes,omitempty"`
+	// MaxMessages is the maximum number of messages of a chat completions request.
+	MaxMessages int `json:"maxMessages,omitempty"`
+	// MaxTokens is the ceiling of the max_tokens and max_completion_tokens parameters.
+	MaxTokens int `json:"maxTokens,omitempty"`
+}
+
+// Violation is a reason a request is invalid.
+type Violation struct {
+	// Field is the path of the invalid field in the body, empty for the body itself.
+	Field string
+	// Message describes the violation.
+	Message string
+}
+
+func (v Violation) String() string {
+	if v.Field == "" {
+		return v.Message
+	}
+	return v.Field + ": " + v.Message
+}
+
+// Error is returned for an invalid request. It lists every violation found.
+type Error struct {
+	Violations []Violation
+}
+
+func (e *Error) Error() string {
+	violations := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		violations = append(violations, v.String())
+	}
+	return "invalid request: " + strings.Join(violations, "; ")
+}
+
+// Validator validates request bodies. It is safe for concurrent use.
+type Validator struct {
+	limits     Limits
+	validators map[Endpoint]*validate.SchemaValidator
+}
+
+// NewValidator creates a Validator enforcing the given limits.
+func NewValidator(limits Limits) (*Validator, error) {
+	if limits.MaxBodyBytes < 0 || limits.MaxMessages < 0 || limits.MaxTokens < 0 {
+		return nil, fmt.Errorf("invalid limits %+v - must not be negative", limits)
+	}
+	v := &Validator{limits: limits, validators: map[Endpoint]*validate.SchemaValidator{}}
+	for _, endpoint := range []Endpoint{ChatCompletions, Completions, Embeddings} {
+		data, err := schemaFiles.ReadFile("schemas/" + string(endpoint) + ".json")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the %s schema: %w", endpoint, err)
+		}
+		schema := &spec.Schema{}
+		if err := json.Unmarshal(data, schema); err != nil {
+			return nil, fmt.Errorf("failed to parse the %s schema: %w", endpoint, err)
+		}
+		v.validators[endpoint] = validate.NewSchemaValidator(schema, nil, "", strfmt.Default)
+	}
+	return v, nil
+}
+
+// Limits returns the limits enforced by the validator.
+func (v *Validator) Limits() Limits {
+	return v.limits
+}
+
+// ValidateSize validates the size of the request body. It can be called while the body is
+// received, to reject oversized bodies before they are fully buffered.
+func (v *Validator) ValidateSize(size int) error {
+	if v.limits.MaxBodyBytes > 0 && size > v.limits.MaxBodyBytes {
+		return &Error{Violations: []Violation{{
+			Message: fmt.Sprintf("request body size exceeds the limit of %d bytes", v.limits.MaxBodyBytes),
+		}}}
+	}
+	return nil
+}
+
+// Validate validates the parsed request body. The endpoint is detected from the fields of
+// the body. Bodies of other endpoints are only checked against the limits.
+func (v *Validator) Validate(body map[string]any) error {
+	endpoint, _ := DetectEndpoint(body)
+	return v.ValidateEndpoint(endpoint, body)
+}
+
+// ValidateEndpoint validates the parsed request body against the schema of the endpoint, and
+// the limits. An empty endpoint only checks the limits.
+func (v *Validator) ValidateEndpoint(endpoint Endpoint, body map[string]any) error {
+	violations := []Violation{}
+	if validator, ok := v.validators[endpoint]; ok {
+		for _, err := range validator.Validate(body).Errors {
+			violations = append(violations, toViolation(err))
+		}
+	}
+	violations = append(violations, v.validateLimits(body)...)
+	if len(violations) == 0 {
+		return nil
+	}
+	// The schema validation errors are collected from maps, sort them for stable messages.
+	slices.SortFunc(violations, func(a, b Violation) int { return strings.Compare(a.String(), b.String()) })
+	return &Error{Violations: slices.Compact(violations)}
+}
+
+func (v *Validator) validateLimits(body map[string]any) []Violation {
+	violations := []Violation{}
+	if messages, ok := body["messages"].([]any); ok && v.limits.MaxMessages > 0 && len(messages) > v.limits.MaxMessages {
+		violations = append(violations, Violation{
+			Field:   "messages",
+			Message: fmt.Sprintf("%d messages exceed the limit of %d", len(messages), v.limits.MaxMessages),
+		})
+	}
+	if v.limits.MaxTokens > 0 {
+		for _, field := range []string{"max_tokens", "max_completion_tokens"} {
+			if tokens, ok := body[field].(float64); ok && tokens > float64(v.limits.MaxTokens) {
+				violations = append(violations, Violation{
+					Field:   field,
+					Message: fmt.Sprintf("must be less than or equal to %d", v.limits.MaxTokens),
+				})
+			}
+		}
+	}
+	return violations
+}
+
+// toViolation converts a schema validation error. Its message starts with the field path,
+// e.g. `messages[0].role in body is required` or `"stop" must validate at least one schema`.
+func toViolation(err error) Violation {
+	message := err.Error()
+	if field, rest, ok := strings.Cut(message, " in body "); ok {
+		// Required fields of the body itself are reported as ".field".
+		return Violation{Field: strings.TrimPrefix(field, "."), Message: rest}
+	}
+	if quoted, rest, ok := strings.Cut(message, " "); ok && len(quoted) > 2 && strings.HasPrefix(quoted, `"`) && strings.HasSuffix(quoted, `"`) {
+		return Violation{Field: strings.Trim(quoted, `"`), Message: rest}
+	}
+	return Violation{Message: message}
+}
+
+// DetectEndpoint returns the endpoint of the request body, based on its fields.
+func DetectEndpoint(body map[string]any) (Endpoint, bool) {
+	switch {
+	case body["messages"] != nil:
+		return ChatCompletions, true
+	case body["prompt"] != nil:
+		return Completions, true
+	case body["input"] != nil:
+		return Embeddings, true
+	}
+	return "", false
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	validator, err := NewValidator(Limits{MaxMessages: 2, MaxTokens: 1024})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		body           string
+		wantViolations []Violation
+	}{
+		{
+			name: "valid chat completions",
+			body: `{"model": "m", "messages": [{"role": "system", "content": "be nice"}, {"role": "user", "content": [{"type": "text", "text": "hi"}]}], "max_tokens": 100, "n": 2, "stream": true, "stop": ["\n"]}`,
+		},
+		{
+			name: "valid completions",
+			body: `{"model": "m", "prompt": "hi", "max_tokens": 0, "stop": "\n"}`,
+		},
+		{
+			name: "valid embeddings",
+			body: `{"model": "m", "input": ["a", "b"], "encoding_format": "float"}`,
+		},
+		{
+			name: "unknown endpoint only checks limits",
+			body: `{"model": "m", "query": "q", "documents": ["a"], "max_tokens": 2048}`,
+			wantViolations: []Violation{
+				{Field: "max_tokens", Message: "must be less than or equal to 1024"},
+			},
+		},
+		{
+			name: "unknown role",
+			body: `{"model": "m", "messages": [{"role": "robot", "content": "hi"}]}`,
+			wantViolations: []Violation{
+				{Field: "messages[0].role", Message: "should be one of [system developer user assistant tool function]"},
+			},
+		},
+		{
+			name: "invalid parameters",
+			body: `{"model": "m", "messages": [{"content": "hi"}], "max_tokens": 1.5, "n": 1000, "temperature": 3}`,
+			wantViolations: []Violation{
+				{Field: "max_tokens", Message: `must be of type integer: "number"`},
+				{Field: "messages[0].role", Message: "is required"},
+				{Field: "n", Message: "should be less than or equal to 128"},
+				{Field: "temperature", Message: "should be less than or equal to 2"},
+			},
+		},
+		{
+			name: "invalid embeddings",
+			body: `{"input": "hi", "encoding_format": "int8"}`,
+			wantViolations: []Violation{
+				{Field: "encoding_format", Message: "should be one of [float base64]"},
+				{Field: "model", Message: "is required"},
+			},
+		},
+		{
+			name: "limits exceeded",
+			body: `{"model": "m", "messages": [{"role": "user"}, {"role": "assistant"}, {"role": "user"}], "max_completion_tokens": 4096}`,
+			wantViolations: []Violation{
+				{Field: "max_completion_tokens", Message: "must be less than or equal to 1024"},
+				{Field: "messages", Message: "3 messages exceed the limit of 2"},
+			},
+		},
+		{
+			name: "empty embeddings input",
+			body: `{"model": "m", "input": [], "dimensions": 0}`,
+			wantViolations: []Violation{
+				{Field: "dimensions", Message: "should be greater than or equal to 1"},
+				{Field: "input", Message: "should have at least 1 items"},
+				{Field: "input", Message: "must validate at least one schema (anyOf)"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			body := map[string]any{}
+			require.NoError(t, json.Unmarshal([]byte(test.body), &body))
+			err := validator.Validate(body)
+			if len(test.wantViolations) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *Error
+			require.ErrorAs(t, err, &validationErr)
+			assert.ElementsMatch(t, test.wantViolations, validationErr.Violations)
+		})
+	}
+}
+
+func TestValidateSize(t *testing.T) {
+	validator, err := NewValidator(Limits{MaxBodyBytes: 10})
+	require.NoError(t, err)
+	assert.NoError(t, validator.ValidateSize(10))
+	assert.EqualError(t, validator.ValidateSize(11), "invalid request: request body size exceeds the limit of 10 bytes")
+
+	unlimited, err := NewValidator(Limits{})
+	require.NoError(t, err)
+	assert.NoError(t, unlimited.ValidateSize(1<<30))
+
+	_, err = NewValidator(Limits{MaxTokens: -1})
+	assert.Error(t, err)
+}
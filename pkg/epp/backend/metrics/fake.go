@@ -21,18 +21,19 @@ import (
 	"fmt"
 	"sync"
 
-	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datalayer"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 )
 
 // FakePodMetrics is an implementation of PodMetrics that doesn't run the async refresh loop.
 type FakePodMetrics struct {
-	Pod     *backend.Pod
-	Metrics *MetricsState
+	Pod        *backend.Pod
+	Metrics    *MetricsState
+	Attributes *datalayer.Attributes
 }
 
 func (fpm *FakePodMetrics) String() string {
@@ -45,10 +46,33 @@ func (fpm *FakePodMetrics) GetPod() *backend.Pod {
 func (fpm *FakePodMetrics) GetMetrics() *MetricsState {
 	return fpm.Metrics
 }
-func (fpm *FakePodMetrics) UpdatePod(pod *corev1.Pod) {
-	fpm.Pod = toInternalPod(pod)
+func (fpm *FakePodMetrics) UpdatePod(pod *backend.Pod) {
+	fpm.Pod = pod
+}
+func (fpm *FakePodMetrics) UpdateMetrics(metrics *MetricsState) {
+	fpm.Metrics = metrics
+}
+
+func (fpm *FakePodMetrics) Put(key string, value datalayer.Cloneable) {
+	if fpm.Attributes == nil {
+		fpm.Attributes = datalayer.NewAttributes()
+	}
+	fpm.Attributes.Put(key, value)
+}
+
+func (fpm *FakePodMetrics) Get(key string) (datalayer.Cloneable, bool) {
+	if fpm.Attributes == nil {
+		return nil, false
+	}
+	return fpm.Attributes.Get(key)
+}
+
+func (fpm *FakePodMetrics) Keys() []string {
+	if fpm.Attributes == nil {
+		return nil
+	}
+	return fpm.Attributes.Keys()
 }
-func (fpm *FakePodMetrics) StopRefreshLoop() {} // noop
 
 type FakePodMetricsClient struct {
 	errMu sync.RWMutex
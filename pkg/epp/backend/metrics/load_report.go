@@ -0,0 +1,239 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	orcav3 "github.com/cncf/xds/go/xds/data/orca/v3"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Load report formats. The ORCA encodings are the ones of the "endpoint-load-metrics" header
+// parsed by Envoy.
+const (
+	// LoadReportFormatNone disables in-band load reports.
+	LoadReportFormatNone = "none"
+	// LoadReportFormatORCA accepts any of the ORCA encodings.
+	LoadReportFormatORCA = "orca"
+	// LoadReportFormatORCAText accepts "TEXT key=value, ..." reports only.
+	LoadReportFormatORCAText = "orca-text"
+	// LoadReportFormatORCAJSON accepts "JSON {...}" reports only.
+	LoadReportFormatORCAJSON = "orca-json"
+	// LoadReportFormatORCABinary accepts base64 encoded OrcaLoadReport protos only, either in the
+	// "<header>-bin" header or prefixed with "BIN " in the load report header.
+	LoadReportFormatORCABinary = "orca-binary"
+
+	// DefaultLoadReportHeader is the header carrying ORCA load reports.
+	DefaultLoadReportHeader = "endpoint-load-metrics"
+
+	orcaTextPrefix     = "TEXT "
+	orcaJSONPrefix     = "JSON "
+	orcaBinaryPrefix   = "BIN "
+	orcaBinaryHeader   = "-bin"
+	orcaNamedMetrics   = "named_metrics."
+	orcaUtilization    = "utilization."
+	orcaRequestCost    = "request_cost."
+	orcaCPUUtilization = "cpu_utilization"
+	orcaMemUtilization = "mem_utilization"
+	orcaAppUtilization = "application_utilization"
+	orcaRPSFractional  = "rps_fractional"
+	orcaEPS            = "eps"
+)
+
+// LoadReportFormats returns the supported load report formats.
+func LoadReportFormats() []string {
+	return []string{LoadReportFormatNone, LoadReportFormatORCA, LoadReportFormatORCAText, LoadReportFormatORCAJSON, LoadReportFormatORCABinary}
+}
+
+// LoadReportConfig configures the parsing of the load reports attached by model servers to their
+// responses. The metrics are looked up by name in the named metrics of the report, then in its
+// utilization metrics. An empty name disables the corresponding metric.
+type LoadReportConfig struct {
+	Format                     string
+	Header                     string
+	TotalQueuedRequestsMetric  string
+	TotalRunningRequestsMetric string
+	KVCacheUtilizationMetric   string
+}
+
+// LoadReportParser parses in-band load reports into metrics.
+type LoadReportParser struct {
+	config LoadReportConfig
+}
+
+// NewLoadReportParser creates a LoadReportParser. It returns a nil parser if load reports are disabled.
+func NewLoadReportParser(config LoadReportConfig) (*LoadReportParser, error) {
+	switch config.Format {
+	case "", LoadReportFormatNone:
+		return nil, nil
+	case LoadReportFormatORCA, LoadReportFormatORCAText, LoadReportFormatORCAJSON, LoadReportFormatORCABinary:
+	default:
+		return nil, fmt.Errorf("unknown load report format %q, valid formats are %v", config.Format, LoadReportFormats())
+	}
+	if config.Header == "" {
+		config.Header = DefaultLoadReportHeader
+	}
+	config.Header = strings.ToLower(config.Header)
+	return &LoadReportParser{config: config}, nil
+}
+
+// Headers returns the names of the response headers that may carry load reports. These headers
+// are consumed by the parser and are not meant to reach the client.
+func (p *LoadReportParser) Headers() []string {
+	return []string{p.config.Header, p.config.Header + orcaBinaryHeader}
+}
+
+// Parse parses the load report found in the response headers, if any, and returns a copy of the
+// existing metrics updated with the reported values. It returns nil if the headers don't carry a
+// load report with any of the configured metrics.
+func (p *LoadReportParser) Parse(headers map[string]string, existing *MetricsState) (*MetricsState, error) {
+	report, err := p.parseReport(headers)
+	if err != nil || report == nil {
+		return nil, err
+	}
+
+	updated := existing.Clone()
+	found := false
+	if value, ok := lookupLoadMetric(report, p.config.TotalQueuedRequestsMetric); ok {
+		updated.WaitingQueueSize = int(value)
+		found = true
+	}
+	if value, ok := lookupLoadMetric(report, p.config.TotalRunningRequestsMetric); ok {
+		updated.RunningQueueSize = int(value)
+		found = true
+	}
+	if value, ok := lookupLoadMetric(report, p.config.KVCacheUtilizationMetric); ok {
+		updated.KVCacheUsagePercent = value
+		found = true
+	}
+	if !found {
+		return nil, nil
+	}
+	return updated, nil
+}
+
+func (p *LoadReportParser) parseReport(headers map[string]string) (*orcav3.OrcaLoadReport, error) {
+	if value, ok := headers[p.config.Header+orcaBinaryHeader]; ok && p.accepts(LoadReportFormatORCABinary) {
+		return parseORCABinary(value)
+	}
+	value, ok := headers[p.config.Header]
+	if !ok {
+		return nil, nil
+	}
+	switch {
+	case strings.HasPrefix(value, orcaTextPrefix) && p.accepts(LoadReportFormatORCAText):
+		return parseORCAText(strings.TrimPrefix(value, orcaTextPrefix))
+	case strings.HasPrefix(value, orcaJSONPrefix) && p.accepts(LoadReportFormatORCAJSON):
+		return parseORCAJSON(strings.TrimPrefix(value, orcaJSONPrefix))
+	case strings.HasPrefix(value, orcaBinaryPrefix) && p.accepts(LoadReportFormatORCABinary):
+		return parseORCABinary(strings.TrimPrefix(value, orcaBinaryPrefix))
+	}
+	return nil, fmt.Errorf("unsupported load report in header %q for format %q", p.config.Header, p.config.Format)
+}
+
+func (p *LoadReportParser) accepts(format string) bool {
+	return p.config.Format == LoadReportFormatORCA || p.config.Format == format
+}
+
+func lookupLoadMetric(report *orcav3.OrcaLoadReport, name string) (float64, bool) {
+	if name == "" {
+		return 0, false
+	}
+	if value, ok := report.GetNamedMetrics()[name]; ok {
+		return value, true
+	}
+	value, ok := report.GetUtilization()[name]
+	return value, ok
+}
+
+// parseORCAText parses a comma separated list of key=value pairs, for example
+// "cpu_utilization=0.3, named_metrics.num_requests_waiting=2". Unknown keys are ignored.
+func parseORCAText(text string) (*orcav3.OrcaLoadReport, error) {
+	report := &orcav3.OrcaLoadReport{}
+	for _, pair := range strings.Split(text, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, rawValue, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid ORCA text load report entry %q", pair)
+		}
+		key = strings.TrimSpace(key)
+		value, err := strconv.ParseFloat(strings.TrimSpace(rawValue), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of ORCA text load report entry %q: %w", key, err)
+		}
+		switch {
+		case strings.HasPrefix(key, orcaNamedMetrics):
+			if report.NamedMetrics == nil {
+				report.NamedMetrics = make(map[string]float64)
+			}
+			report.NamedMetrics[strings.TrimPrefix(key, orcaNamedMetrics)] = value
+		case strings.HasPrefix(key, orcaUtilization):
+			if report.Utilization == nil {
+				report.Utilization = make(map[string]float64)
+			}
+			report.Utilization[strings.TrimPrefix(key, orcaUtilization)] = value
+		case strings.HasPrefix(key, orcaRequestCost):
+			if report.RequestCost == nil {
+				report.RequestCost = make(map[string]float64)
+			}
+			report.RequestCost[strings.TrimPrefix(key, orcaRequestCost)] = value
+		case key == orcaCPUUtilization:
+			report.CpuUtilization = value
+		case key == orcaMemUtilization:
+			report.MemUtilization = value
+		case key == orcaAppUtilization:
+			report.ApplicationUtilization = value
+		case key == orcaRPSFractional:
+			report.RpsFractional = value
+		case key == orcaEPS:
+			report.Eps = value
+		}
+	}
+	return report, nil
+}
+
+func parseORCAJSON(text string) (*orcav3.OrcaLoadReport, error) {
+	report := &orcav3.OrcaLoadReport{}
+	if err := protojson.Unmarshal([]byte(text), report); err != nil {
+		return nil, fmt.Errorf("invalid ORCA JSON load report: %w", err)
+	}
+	return report, nil
+}
+
+func parseORCABinary(text string) (*orcav3.OrcaLoadReport, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(text))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 encoding of ORCA binary load report: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, errors.New("empty ORCA binary load report")
+	}
+	report := &orcav3.OrcaLoadReport{}
+	if err := proto.Unmarshal(data, report); err != nil {
+		return nil, fmt.Errorf("invalid ORCA binary load report: %w", err)
+	}
+	return report, nil
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"encoding/base64"
+	"testing"
+
+	orcav3 "github.com/cncf/xds/go/xds/data/orca/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNewLoadReportParser(t *testing.T) {
+	parser, err := NewLoadReportParser(LoadReportConfig{Format: LoadReportFormatNone})
+	assert.NoError(t, err)
+	assert.Nil(t, parser, "expected no parser when load reports are disabled")
+
+	_, err = NewLoadReportParser(LoadReportConfig{Format: "prometheus"})
+	assert.Error(t, err)
+
+	parser, err = NewLoadReportParser(LoadReportConfig{Format: LoadReportFormatORCA, Header: "X-Load"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"x-load", "x-load-bin"}, parser.Headers())
+}
+
+func TestLoadReportParse(t *testing.T) {
+	binaryReport, err := proto.Marshal(&orcav3.OrcaLoadReport{
+		NamedMetrics: map[string]float64{"num_requests_waiting": 7},
+		Utilization:  map[string]float64{"kv_cache_usage_perc": 0.25},
+	})
+	require.NoError(t, err)
+	encodedReport := base64.StdEncoding.EncodeToString(binaryReport)
+
+	existing := &MetricsState{
+		RunningQueueSize:    1,
+		WaitingQueueSize:    1,
+		KVCacheUsagePercent: 0.1,
+		ActiveModels:        map[string]int{"foo": 1},
+		WaitingModels:       map[string]int{},
+	}
+
+	tests := []struct {
+		name    string
+		format  string
+		headers map[string]string
+		want    *MetricsState
+		wantErr bool
+	}{
+		{
+			name:   "text",
+			format: LoadReportFormatORCA,
+			headers: map[string]string{
+				DefaultLoadReportHeader: "TEXT cpu_utilization=0.5, named_metrics.num_requests_waiting=3, named_metrics.num_requests_running=4, utilization.kv_cache_usage_perc=0.8",
+			},
+			want: &MetricsState{
+				RunningQueueSize:    4,
+				WaitingQueueSize:    3,
+				KVCacheUsagePercent: 0.8,
+				ActiveModels:        map[string]int{"foo": 1},
+				WaitingModels:       map[string]int{},
+			},
+		},
+		{
+			name:   "json",
+			format: LoadReportFormatORCAJSON,
+			headers: map[string]string{
+				DefaultLoadReportHeader: `JSON {"named_metrics": {"num_requests_running": 2, "kv_cache_usage_perc": 0.5}}`,
+			},
+			want: &MetricsState{
+				RunningQueueSize:    2,
+				WaitingQueueSize:    1,
+				KVCacheUsagePercent: 0.5,
+				ActiveModels:        map[string]int{"foo": 1},
+				WaitingModels:       map[string]int{},
+			},
+		},
+		{
+			name:    "binary header",
+			format:  LoadReportFormatORCA,
+			headers: map[string]string{DefaultLoadReportHeader + "-bin": encodedReport},
+			want: &MetricsState{
+				RunningQueueSize:    1,
+				WaitingQueueSize:    7,
+				KVCacheUsagePercent: 0.25,
+				ActiveModels:        map[string]int{"foo": 1},
+				WaitingModels:       map[string]int{},
+			},
+		},
+		{
+			name:    "binary prefix",
+			format:  LoadReportFormatORCABinary,
+			headers: map[string]string{DefaultLoadReportHeader: "BIN " + encodedReport},
+			want: &MetricsState{
+				RunningQueueSize:    1,
+				WaitingQueueSize:    7,
+				KVCacheUsagePercent: 0.25,
+				ActiveModels:        map[string]int{"foo": 1},
+				WaitingModels:       map[string]int{},
+			},
+		},
+		{
+			name:    "no load report",
+			format:  LoadReportFormatORCA,
+			headers: map[string]string{"content-type": "application/json"},
+		},
+		{
+			name:    "no configured metric",
+			format:  LoadReportFormatORCA,
+			headers: map[string]string{DefaultLoadReportHeader: "TEXT cpu_utilization=0.5"},
+		},
+		{
+			name:    "format not accepted",
+			format:  LoadReportFormatORCAText,
+			headers: map[string]string{DefaultLoadReportHeader: `JSON {"named_metrics": {"num_requests_running": 2}}`},
+			wantErr: true,
+		},
+		{
+			name:    "invalid text value",
+			format:  LoadReportFormatORCA,
+			headers: map[string]string{DefaultLoadReportHeader: "TEXT named_metrics.num_requests_waiting=many"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid json",
+			format:  LoadReportFormatORCA,
+			headers: map[string]string{DefaultLoadReportHeader: "JSON {"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid binary",
+			format:  LoadReportFormatORCA,
+			headers: map[string]string{DefaultLoadReportHeader + "-bin": "not base64!"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parser, err := NewLoadReportParser(LoadReportConfig{
+				Format:                     test.format,
+				TotalQueuedRequestsMetric:  "num_requests_waiting",
+				TotalRunningRequestsMetric: "num_requests_running",
+				KVCacheUtilizationMetric:   "kv_cache_usage_perc",
+			})
+			require.NoError(t, err)
+
+			got, err := parser.Parse(test.headers, existing)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+	assert.Equal(t, 1, existing.WaitingQueueSize, "expected the existing metrics to be left unchanged")
+}
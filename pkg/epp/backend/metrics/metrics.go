@@ -28,6 +28,7 @@ import (
 	"go.uber.org/multierr"
 
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	dlmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datalayer/metrics"
 )
 
 const (
@@ -35,13 +36,24 @@ const (
 	LoraInfoRunningAdaptersMetricName = "running_lora_adapters"
 	LoraInfoWaitingAdaptersMetricName = "waiting_lora_adapters"
 	LoraInfoMaxAdaptersMetricName     = "max_lora"
+
+	// ModelNameLabel is the label of the model server metrics holding the base model name.
+	ModelNameLabel = "model_name"
+
+	// Cache config metrics based on protocol
+	CacheConfigBlockSizeInfoMetricName = "block_size"
+	CacheConfigNumGPUBlocksMetricName  = "num_gpu_blocks"
 )
 
 type PodMetricsClientImpl struct {
-	MetricMapping            *MetricMapping
-	ModelServerMetricsPort   int32
-	ModelServerMetricsPath   string
-	ModelServerMetricsScheme string
+	MetricMapping *MetricMapping
+	// ModelServerMetricMappings maps a model server type to its MetricMapping. It is used for pods
+	// that override the model server type using the ModelServerTypeAnnotation, allowing pools with
+	// mixed model servers. Pods without the annotation use MetricMapping.
+	ModelServerMetricMappings map[string]*MetricMapping
+	ModelServerMetricsPort    int32
+	ModelServerMetricsPath    string
+	ModelServerMetricsScheme  string
 
 	Client *http.Client
 }
@@ -70,35 +82,63 @@ func (p *PodMetricsClientImpl) FetchMetrics(ctx context.Context, pod *backend.Po
 	if err != nil {
 		return nil, err
 	}
-	return p.promToPodMetrics(metricFamilies, existing)
+	return p.promToPodMetrics(metricFamilies, p.metricMappingFor(pod), existing)
+}
+
+// metricMappingFor returns the MetricMapping to use for the given pod, taking into account
+// a model server type override set by the pod's annotations.
+func (p *PodMetricsClientImpl) metricMappingFor(pod *backend.Pod) *MetricMapping {
+	if serverType, ok := pod.Annotations[ModelServerTypeAnnotation]; ok {
+		if mapping, ok := p.ModelServerMetricMappings[serverType]; ok {
+			return mapping
+		}
+	}
+	return p.MetricMapping
 }
 
+// getMetricEndpoint returns the metrics URL of the pod. The metrics are scraped from the given
+// target port unless a metrics port is configured, so that every data parallel rank of a pod is
+// scraped separately.
 func (p *PodMetricsClientImpl) getMetricEndpoint(pod *backend.Pod, targetPortNumber int32) string {
-	if p.ModelServerMetricsPort == 0 {
-		p.ModelServerMetricsPort = targetPortNumber
+	port := p.ModelServerMetricsPort
+	if port == 0 {
+		port = targetPortNumber
 	}
-	return fmt.Sprintf("%s://%s:%d%s", p.ModelServerMetricsScheme, pod.Address, p.ModelServerMetricsPort, p.ModelServerMetricsPath)
+	return fmt.Sprintf("%s://%s:%d%s", p.ModelServerMetricsScheme, pod.Address, port, p.ModelServerMetricsPath)
 }
 
 // promToPodMetrics updates internal pod metrics with scraped Prometheus metrics.
 func (p *PodMetricsClientImpl) promToPodMetrics(
 	metricFamilies map[string]*dto.MetricFamily,
+	mapping *MetricMapping,
 	existing *MetricsState,
 ) (*MetricsState, error) {
 	var errs error
 	updated := existing.Clone()
 
-	if p.MetricMapping.TotalQueuedRequests != nil {
-		queued, err := p.getMetric(metricFamilies, *p.MetricMapping.TotalQueuedRequests)
+	if mapping.TotalQueuedRequests != nil {
+		queued, err := p.getMetric(metricFamilies, *mapping.TotalQueuedRequests)
 		if err == nil {
 			updated.WaitingQueueSize = int(queued.GetGauge().GetValue())
+			if model := labelValue(queued, ModelNameLabel); model != "" {
+				updated.BaseModel = model
+			}
+		} else {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	if mapping.TotalRunningRequests != nil {
+		running, err := p.getMetric(metricFamilies, *mapping.TotalRunningRequests)
+		if err == nil {
+			updated.RunningQueueSize = int(running.GetGauge().GetValue())
 		} else {
 			errs = multierr.Append(errs, err)
 		}
 	}
 
-	if p.MetricMapping.KVCacheUtilization != nil {
-		usage, err := p.getMetric(metricFamilies, *p.MetricMapping.KVCacheUtilization)
+	if mapping.KVCacheUtilization != nil {
+		usage, err := p.getMetric(metricFamilies, *mapping.KVCacheUtilization)
 		if err == nil {
 			updated.KVCacheUsagePercent = usage.GetGauge().GetValue()
 		} else {
@@ -107,8 +147,8 @@ func (p *PodMetricsClientImpl) promToPodMetrics(
 	}
 
 	// Handle LoRA metrics (only if all LoRA MetricSpecs are present)
-	if p.MetricMapping.LoraRequestInfo != nil {
-		loraMetrics, err := p.getLatestLoraMetric(metricFamilies)
+	if mapping.LoraRequestInfo != nil {
+		loraMetrics, err := p.getLatestLoraMetric(metricFamilies, mapping)
 		errs = multierr.Append(errs, err)
 
 		if loraMetrics != nil {
@@ -143,21 +183,45 @@ func (p *PodMetricsClientImpl) promToPodMetrics(
 		}
 	}
 
+	if mapping.CacheConfigInfo != nil {
+		cacheMetric, err := p.getMetric(metricFamilies, *mapping.CacheConfigInfo)
+		if err == nil {
+			capacity, err := dlmetrics.KVCacheMaxTokenCapacity(cacheMetric)
+			if err == nil {
+				updated.KvCacheMaxTokenCapacity = capacity
+			} else {
+				errs = multierr.Append(errs, err)
+			}
+		} else {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
 	return updated, errs
 }
 
+// labelValue returns the value of a label of the metric, empty if it isn't set.
+func labelValue(metric *dto.Metric, name string) string {
+	for _, label := range metric.GetLabel() {
+		if label.GetName() == name {
+			return label.GetValue()
+		}
+	}
+	return ""
+}
+
 // getLatestLoraMetric gets latest lora metric series in gauge metric family `vllm:lora_requests_info`
 // reason its specially fetched is because each label key value pair permutation generates new series
 // and only most recent is useful. The value of each series is the creation timestamp so we can
 // retrieve the latest by sorting the value.
-func (p *PodMetricsClientImpl) getLatestLoraMetric(metricFamilies map[string]*dto.MetricFamily) (*dto.Metric, error) {
-	if p.MetricMapping.LoraRequestInfo == nil {
+func (p *PodMetricsClientImpl) getLatestLoraMetric(metricFamilies map[string]*dto.MetricFamily, mapping *MetricMapping) (*dto.Metric, error) {
+	if mapping.LoraRequestInfo == nil {
 		return nil, nil // No LoRA metrics configured
 	}
 
-	loraRequests, ok := metricFamilies[p.MetricMapping.LoraRequestInfo.MetricName]
+	loraRequests, ok := metricFamilies[mapping.LoraRequestInfo.MetricName]
 	if !ok {
-		return nil, fmt.Errorf("metric family %q not found", p.MetricMapping.LoraRequestInfo.MetricName)
+		return nil, fmt.Errorf("metric family %q not found", mapping.LoraRequestInfo.MetricName)
 	}
 
 	var latest *dto.Metric
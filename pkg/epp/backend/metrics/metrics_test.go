@@ -314,7 +314,7 @@ func TestGetLatestLoraMetric(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			p := &PodMetricsClientImpl{MetricMapping: tc.mapping}
-			loraMetric, err := p.getLatestLoraMetric(tc.metricFamilies)
+			loraMetric, err := p.getLatestLoraMetric(tc.metricFamilies, tc.mapping)
 
 			if tc.expectedErr != nil {
 				if err == nil || err.Error() != tc.expectedErr.Error() {
@@ -386,8 +386,8 @@ func TestPromToPodMetrics(t *testing.T) {
 			name: "vllm metrics",
 			metricFamilies: map[string]*dto.MetricFamily{
 				"vllm_waiting": makeMetricFamily("vllm_waiting",
-					makeMetric(nil, 5.0, 1000),
-					makeMetric(nil, 7.0, 2000), // Newer
+					makeMetric(map[string]string{"model_name": "base"}, 5.0, 1000),
+					makeMetric(map[string]string{"model_name": "base"}, 7.0, 2000), // Newer
 				),
 				"vllm_usage": makeMetricFamily("vllm_usage",
 					makeMetric(nil, 0.8, 2000),
@@ -404,6 +404,7 @@ func TestPromToPodMetrics(t *testing.T) {
 			},
 			existingMetrics: &MetricsState{},
 			expectedMetrics: &MetricsState{
+				BaseModel:           "base",
 				WaitingQueueSize:    7,
 				KVCacheUsagePercent: 0.8,
 				ActiveModels:        map[string]int{"lora1": 0, "lora2": 0},
@@ -411,6 +412,58 @@ func TestPromToPodMetrics(t *testing.T) {
 				MaxActiveModels:     3,
 			},
 		},
+		{
+			name: "running requests and cache config info",
+			metricFamilies: map[string]*dto.MetricFamily{
+				"vllm_running": makeMetricFamily("vllm_running",
+					makeMetric(nil, 4.0, 1000),
+				),
+				"vllm:cache_config_info": makeMetricFamily("vllm:cache_config_info",
+					makeMetric(map[string]string{"block_size": "16", "num_gpu_blocks": "1000"}, 1.0, 1000),
+				),
+			},
+			mapping: &MetricMapping{
+				TotalRunningRequests: &MetricSpec{MetricName: "vllm_running"},
+				CacheConfigInfo:      &MetricSpec{MetricName: "vllm:cache_config_info"},
+			},
+			existingMetrics: &MetricsState{},
+			expectedMetrics: &MetricsState{
+				RunningQueueSize:        4,
+				KvCacheMaxTokenCapacity: 16000,
+				ActiveModels:            map[string]int{},
+				WaitingModels:           map[string]int{},
+			},
+		},
+		{
+			name: "cache capacity from metric value",
+			metricFamilies: map[string]*dto.MetricFamily{
+				"sglang:max_total_num_tokens": makeMetricFamily("sglang:max_total_num_tokens",
+					makeMetric(nil, 32768.0, 1000),
+				),
+			},
+			mapping: &MetricMapping{
+				CacheConfigInfo: &MetricSpec{MetricName: "sglang:max_total_num_tokens"},
+			},
+			existingMetrics: &MetricsState{},
+			expectedMetrics: &MetricsState{
+				KvCacheMaxTokenCapacity: 32768,
+				ActiveModels:            map[string]int{},
+				WaitingModels:           map[string]int{},
+			},
+		},
+		{
+			name: "invalid cache config info",
+			metricFamilies: map[string]*dto.MetricFamily{
+				"vllm:cache_config_info": makeMetricFamily("vllm:cache_config_info",
+					makeMetric(map[string]string{"block_size": "16"}, 1.0, 1000),
+				),
+			},
+			mapping: &MetricMapping{
+				CacheConfigInfo: &MetricSpec{MetricName: "vllm:cache_config_info"},
+			},
+			existingMetrics: &MetricsState{},
+			expectedErr:     errors.New("invalid \"num_gpu_blocks\" label in cache config metric: strconv.Atoi: parsing \"\": invalid syntax"),
+		},
 		{
 			name:           "missing metrics",
 			metricFamilies: map[string]*dto.MetricFamily{}, // No metrics
@@ -472,7 +525,7 @@ func TestPromToPodMetrics(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			p := &PodMetricsClientImpl{MetricMapping: tc.mapping}
-			updated, err := p.promToPodMetrics(tc.metricFamilies, tc.existingMetrics)
+			updated, err := p.promToPodMetrics(tc.metricFamilies, tc.mapping, tc.existingMetrics)
 			if tc.expectedErr != nil {
 				assert.Error(t, err)
 				assert.EqualError(t, err, tc.expectedErr.Error())
@@ -514,3 +567,15 @@ func TestFetchMetrics(t *testing.T) {
 		t.Errorf("FetchMetrics() error = %v, want error containing %q", err, expectedSubstr)
 	}
 }
+
+func TestGetMetricEndpoint(t *testing.T) {
+	pod := &backend.Pod{Address: "10.0.0.1"}
+	p := &PodMetricsClientImpl{ModelServerMetricsScheme: "http", ModelServerMetricsPath: "/metrics"}
+
+	// Every data parallel rank is scraped on its own target port.
+	assert.Equal(t, "http://10.0.0.1:8000/metrics", p.getMetricEndpoint(pod, 8000))
+	assert.Equal(t, "http://10.0.0.1:8001/metrics", p.getMetricEndpoint(pod, 8001))
+
+	p.ModelServerMetricsPort = 9090
+	assert.Equal(t, "http://10.0.0.1:9090/metrics", p.getMetricEndpoint(pod, 8000))
+}
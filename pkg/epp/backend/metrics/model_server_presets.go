@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ModelServerTypeAnnotation is the pod annotation used to override the model server type of
+// a single pod, allowing pools with a mix of model servers.
+const ModelServerTypeAnnotation = "inference.networking.k8s.io/model-server-type"
+
+// Well-known model server types.
+const (
+	ModelServerTypeVLLM              = "vllm"
+	ModelServerTypeSGLang            = "sglang"
+	ModelServerTypeTGI               = "tgi"
+	ModelServerTypeTritonTensorRTLLM = "triton-tensorrt-llm"
+)
+
+// MetricSpecs holds the string specifications of the metrics used to build a MetricMapping.
+// An empty string disables scraping of the corresponding metric.
+type MetricSpecs struct {
+	TotalQueuedRequests  string
+	TotalRunningRequests string
+	KVCacheUtilization   string
+	LoraRequestInfo      string
+	CacheConfigInfo      string
+}
+
+// MetricMapping creates a MetricMapping from the specifications.
+func (s MetricSpecs) MetricMapping() (*MetricMapping, error) {
+	return NewMetricMapping(s.TotalQueuedRequests, s.TotalRunningRequests, s.KVCacheUtilization, s.LoraRequestInfo, s.CacheConfigInfo)
+}
+
+var modelServerMetricPresets = map[string]MetricSpecs{
+	ModelServerTypeVLLM: {
+		TotalQueuedRequests:  "vllm:num_requests_waiting",
+		TotalRunningRequests: "vllm:num_requests_running",
+		KVCacheUtilization:   "vllm:gpu_cache_usage_perc",
+		LoraRequestInfo:      "vllm:lora_requests_info",
+		CacheConfigInfo:      "vllm:cache_config_info",
+	},
+	ModelServerTypeSGLang: {
+		TotalQueuedRequests:  "sglang:num_queue_reqs",
+		TotalRunningRequests: "sglang:num_running_reqs",
+		KVCacheUtilization:   "sglang:token_usage",
+		CacheConfigInfo:      "sglang:max_total_num_tokens",
+	},
+	// TGI doesn't expose its KV cache utilization, so TGI pods always report an empty KV cache to the
+	// kv-cache-utilization-scorer and the saturation detector.
+	ModelServerTypeTGI: {
+		TotalQueuedRequests:  "tgi_queue_size",
+		TotalRunningRequests: "tgi_batch_current_size",
+	},
+	ModelServerTypeTritonTensorRTLLM: {
+		TotalQueuedRequests:  "nv_trt_llm_request_metrics{request_type=waiting}",
+		TotalRunningRequests: "nv_trt_llm_request_metrics{request_type=scheduled}",
+		KVCacheUtilization:   "nv_trt_llm_kv_cache_block_metrics{kv_cache_block_type=fraction}",
+	},
+}
+
+// ModelServerTypes returns the sorted list of model server types with a metrics preset.
+func ModelServerTypes() []string {
+	types := make([]string, 0, len(modelServerMetricPresets))
+	for serverType := range modelServerMetricPresets {
+		types = append(types, serverType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// ModelServerMetricPreset returns the metric specifications for a well-known model server type.
+func ModelServerMetricPreset(serverType string) (MetricSpecs, error) {
+	specs, ok := modelServerMetricPresets[serverType]
+	if !ok {
+		return MetricSpecs{}, fmt.Errorf("unknown model server type %q, valid types are %v", serverType, ModelServerTypes())
+	}
+	return specs, nil
+}
+
+// NewModelServerMetricMappings creates the MetricMappings of all well-known model server types,
+// keyed by the model server type.
+func NewModelServerMetricMappings() (map[string]*MetricMapping, error) {
+	mappings := make(map[string]*MetricMapping, len(modelServerMetricPresets))
+	for serverType, specs := range modelServerMetricPresets {
+		mapping, err := specs.MetricMapping()
+		if err != nil {
+			return nil, fmt.Errorf("invalid metrics preset for model server type %q: %w", serverType, err)
+		}
+		mappings[serverType] = mapping
+	}
+	return mappings, nil
+}
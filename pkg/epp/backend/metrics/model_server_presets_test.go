@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+)
+
+func TestModelServerMetricPreset(t *testing.T) {
+	for _, serverType := range ModelServerTypes() {
+		t.Run(serverType, func(t *testing.T) {
+			specs, err := ModelServerMetricPreset(serverType)
+			require.NoError(t, err)
+			mapping, err := specs.MetricMapping()
+			require.NoError(t, err)
+			assert.NotNil(t, mapping.TotalQueuedRequests, "all presets are expected to scrape the queue size")
+		})
+	}
+
+	_, err := ModelServerMetricPreset("unknown")
+	assert.Error(t, err)
+}
+
+func TestMetricMappingFor(t *testing.T) {
+	mappings, err := NewModelServerMetricMappings()
+	require.NoError(t, err)
+	defaultMapping, err := NewMetricMapping("default_queue", "", "", "", "")
+	require.NoError(t, err)
+
+	p := &PodMetricsClientImpl{
+		MetricMapping:             defaultMapping,
+		ModelServerMetricMappings: mappings,
+	}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        *MetricMapping
+	}{
+		{
+			name: "no annotation",
+			want: defaultMapping,
+		},
+		{
+			name:        "annotated model server type",
+			annotations: map[string]string{ModelServerTypeAnnotation: ModelServerTypeSGLang},
+			want:        mappings[ModelServerTypeSGLang],
+		},
+		{
+			name:        "unknown model server type falls back to the default",
+			annotations: map[string]string{ModelServerTypeAnnotation: "unknown"},
+			want:        defaultMapping,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &backend.Pod{Annotations: tc.annotations}
+			assert.Same(t, tc.want, p.metricMappingFor(pod))
+		})
+	}
+}
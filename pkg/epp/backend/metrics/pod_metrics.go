@@ -19,32 +19,27 @@ package metrics
 import (
 	"context"
 	"fmt"
-	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
 
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/common"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datalayer"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 )
 
-const (
-	fetchMetricsTimeout = 5 * time.Second
+var (
+	errPoolNotSynced = fmt.Errorf("InferencePool is not initialized in data store - %w", datalayer.ErrScrapeNotReady)
 )
 
 type podMetrics struct {
-	pod      atomic.Pointer[backend.Pod]
-	metrics  atomic.Pointer[MetricsState]
-	pmc      PodMetricsClient
-	ds       Datastore
-	interval time.Duration
-
-	startOnce sync.Once // ensures the refresh loop goroutine is started only once
-	stopOnce  sync.Once // ensures the done channel is closed only once
-	done      chan struct{}
+	pod        atomic.Pointer[backend.Pod]
+	metrics    atomic.Pointer[MetricsState]
+	pmc        PodMetricsClient
+	ds         datalayer.PoolInfo
+	attributes *datalayer.Attributes
 
 	logger logr.Logger
 }
@@ -65,66 +60,48 @@ func (pm *podMetrics) GetMetrics() *MetricsState {
 	return pm.metrics.Load()
 }
 
-func (pm *podMetrics) UpdatePod(pod *corev1.Pod) {
-	pm.pod.Store(toInternalPod(pod))
+func (pm *podMetrics) UpdatePod(pod *backend.Pod) {
+	pm.pod.Store(pod)
 }
 
-func toInternalPod(pod *corev1.Pod) *backend.Pod {
-	labels := make(map[string]string, len(pod.GetLabels()))
-	for key, value := range pod.GetLabels() {
-		labels[key] = value
-	}
-	return &backend.Pod{
-		NamespacedName: types.NamespacedName{
-			Name:      pod.Name,
-			Namespace: pod.Namespace,
-		},
-		Address: pod.Status.PodIP,
-		Labels:  labels,
-	}
+func (pm *podMetrics) UpdateMetrics(metrics *MetricsState) {
+	pm.metrics.Store(metrics)
 }
 
-// start starts a goroutine exactly once to periodically update metrics. The goroutine will be
-// stopped either when stop() is called, or the given ctx is cancelled.
-func (pm *podMetrics) startRefreshLoop(ctx context.Context) {
-	pm.startOnce.Do(func() {
-		go func() {
-			pm.logger.V(logutil.DEFAULT).Info("Starting refresher", "pod", pm.GetPod())
-			ticker := time.NewTicker(pm.interval)
-			defer ticker.Stop()
-			for {
-				select {
-				case <-pm.done:
-					return
-				case <-ctx.Done():
-					return
-				case <-ticker.C: // refresh metrics periodically
-					if err := pm.refreshMetrics(); err != nil {
-						pm.logger.V(logutil.TRACE).Error(err, "Failed to refresh metrics", "pod", pm.GetPod())
-					}
-				}
-			}
-		}()
-	})
+func (pm *podMetrics) Put(key string, value datalayer.Cloneable) {
+	pm.attributes.Put(key, value)
 }
 
-func (pm *podMetrics) refreshMetrics() error {
+func (pm *podMetrics) Get(key string) (datalayer.Cloneable, bool) {
+	return pm.attributes.Get(key)
+}
+
+func (pm *podMetrics) Keys() []string {
+	return pm.attributes.Keys()
+}
+
+// refreshMetrics fetches the latest metrics of the pod and stores them. It returns whether the metrics
+// were updated, along with any error encountered. Metrics may be updated even if an error is returned,
+// in the case of a partial scrape.
+func (pm *podMetrics) refreshMetrics(ctx context.Context) (bool, error) {
 	pool, err := pm.ds.PoolGet()
 	if err != nil {
 		// No inference pool or not initialize.
-		return err
+		return false, errPoolNotSynced
+	}
+	pod := pm.GetPod()
+	port := pod.Port
+	if ports := common.TargetPorts(&pool.Spec); port == 0 && len(ports) > 0 {
+		port = ports[0]
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), fetchMetricsTimeout)
-	defer cancel()
-	updated, err := pm.pmc.FetchMetrics(ctx, pm.GetPod(), pm.GetMetrics(), pool.Spec.TargetPortNumber)
+	updated, err := pm.pmc.FetchMetrics(ctx, pod, pm.GetMetrics(), port)
 	if err != nil {
 		pm.logger.V(logutil.TRACE).Info("Failed to refreshed metrics:", "err", err)
 	}
 	// Optimistically update metrics even if there was an error.
 	// The FetchMetrics can return an error for the following reasons:
 	// 1. As refresher is running in the background, it's possible that the pod is deleted but
-	// the refresh goroutine doesn't read the done channel yet. In this case, the updated
-	// metrics object will be nil. And the refresher will soon be stopped.
+	// the scrape was already dispatched. In this case, the updated metrics object will be nil.
 	// 2. The FetchMetrics call can partially fail. For example, due to one metric missing. In
 	// this case, the updated metrics object will have partial updates. A partial update is
 	// considered better than no updates.
@@ -132,14 +109,7 @@ func (pm *podMetrics) refreshMetrics() error {
 		updated.UpdateTime = time.Now()
 		pm.logger.V(logutil.TRACE).Info("Refreshed metrics", "updated", updated)
 		pm.metrics.Store(updated)
+		return true, err
 	}
-
-	return nil
-}
-
-func (pm *podMetrics) StopRefreshLoop() {
-	pm.logger.V(logutil.DEFAULT).Info("Stopping refresher", "pod", pm.GetPod())
-	pm.stopOnce.Do(func() {
-		close(pm.done)
-	})
+	return false, err
 }
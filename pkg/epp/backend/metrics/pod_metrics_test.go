@@ -28,6 +28,8 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datalayer"
 )
 
 var (
@@ -65,7 +67,7 @@ func TestMetricsRefresh(t *testing.T) {
 	pmf := NewPodMetricsFactory(pmc, time.Millisecond)
 
 	// The refresher is initialized with empty metrics.
-	pm := pmf.NewPodMetrics(ctx, pod1, &fakeDataStore{})
+	pm := pmf.NewEndpoint(ctx, datalayer.ToPodInfo(pod1), &fakeDataStore{})
 
 	namespacedName := types.NamespacedName{Name: pod1.Name, Namespace: pod1.Namespace}
 	// Use SetRes to simulate an update of metrics from the pod.
@@ -78,17 +80,73 @@ func TestMetricsRefresh(t *testing.T) {
 
 	// Stop the loop, and simulate metric update again, this time the PodMetrics won't get the
 	// new update.
-	pm.StopRefreshLoop()
-	time.Sleep(pmf.refreshMetricsInterval * 2 /* small buffer for robustness */)
+	pmf.ReleaseEndpoint(pm)
+	time.Sleep(2 * time.Millisecond /* twice the refresh interval, a small buffer for robustness */)
 	pmc.SetRes(map[types.NamespacedName]*MetricsState{namespacedName: updated})
 	// Still expect the same condition (no metrics update).
 	assert.EventuallyWithT(t, condition, time.Second, time.Millisecond)
 }
 
-type fakeDataStore struct{}
+func TestRefreshMetricsPort(t *testing.T) {
+	tests := []struct {
+		name     string
+		pool     v1.InferencePoolSpec
+		podPort  int32
+		wantPort int32
+	}{
+		{
+			name:     "target port number",
+			pool:     v1.InferencePoolSpec{TargetPortNumber: 8000},
+			wantPort: 8000,
+		},
+		{
+			name:     "target ports only",
+			pool:     v1.InferencePoolSpec{TargetPorts: []v1.PortNumber{8001, 8002}},
+			wantPort: 8001,
+		},
+		{
+			name:     "endpoint port",
+			pool:     v1.InferencePoolSpec{TargetPorts: []v1.PortNumber{8001, 8002}},
+			podPort:  8002,
+			wantPort: 8002,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pmc := &portRecordingClient{}
+			pm := &podMetrics{pmc: pmc, ds: &fakeDataStore{pool: test.pool}}
+			pod := datalayer.ToPodInfo(pod1)
+			pod.Port = test.podPort
+			pm.UpdatePod(pod)
+			pm.UpdateMetrics(NewMetricsState())
+
+			_, err := pm.refreshMetrics(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantPort, pmc.port)
+		})
+	}
+}
+
+// portRecordingClient records the port of the last scrape.
+type portRecordingClient struct {
+	port int32
+}
+
+func (c *portRecordingClient) FetchMetrics(_ context.Context, _ *backend.Pod, existing *MetricsState, port int32) (*MetricsState, error) {
+	c.port = port
+	return existing.Clone(), nil
+}
+
+type fakeDataStore struct {
+	// pool is the spec of the pool, which defaults to a single target port 8000.
+	pool v1.InferencePoolSpec
+}
 
 func (f *fakeDataStore) PoolGet() (*v1.InferencePool, error) {
-	return &v1.InferencePool{Spec: v1.InferencePoolSpec{TargetPortNumber: 8000}}, nil
+	if f.pool.TargetPortNumber == 0 && len(f.pool.TargetPorts) == 0 {
+		return &v1.InferencePool{Spec: v1.InferencePoolSpec{TargetPortNumber: 8000}}, nil
+	}
+	return &v1.InferencePool{Spec: f.pool}, nil
 }
 
 func (f *fakeDataStore) PodList(func(PodMetrics) bool) []PodMetrics {
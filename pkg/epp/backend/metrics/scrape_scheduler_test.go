@@ -0,0 +1,174 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datalayer"
+)
+
+// countingClient counts the scrapes and the maximum number of concurrent scrapes. Each scrape
+// blocks for the configured delay, and fails if err is set.
+type countingClient struct {
+	delay         time.Duration
+	err           error
+	calls         atomic.Int64
+	inFlight      atomic.Int64
+	maxConcurrent atomic.Int64
+}
+
+func (c *countingClient) FetchMetrics(ctx context.Context, _ *backend.Pod, existing *MetricsState, _ int32) (*MetricsState, error) {
+	c.calls.Add(1)
+	current := c.inFlight.Add(1)
+	defer c.inFlight.Add(-1)
+	for {
+		prev := c.maxConcurrent.Load()
+		if current <= prev || c.maxConcurrent.CompareAndSwap(prev, current) {
+			break
+		}
+	}
+	select {
+	case <-time.After(c.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+	return existing.Clone(), nil
+}
+
+func newTestPod(name string) *backend.Pod {
+	return datalayer.ToPodInfo(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}})
+}
+
+func TestScrapeSchedulerBoundsConcurrency(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pmc := &countingClient{delay: 20 * time.Millisecond}
+	pmf := NewPodMetricsFactoryWithConfig(pmc, datalayer.ScrapeConfig{
+		Interval: time.Millisecond,
+		Timeout:  time.Second,
+		Workers:  2,
+	})
+	for _, name := range []string{"pod1", "pod2", "pod3", "pod4", "pod5"} {
+		pmf.NewEndpoint(ctx, newTestPod(name), &fakeDataStore{})
+	}
+
+	assert.Eventually(t, func() bool { return pmc.calls.Load() >= 10 }, 5*time.Second, time.Millisecond)
+	assert.LessOrEqual(t, pmc.maxConcurrent.Load(), int64(2), "expected scrapes to be bounded by the number of workers")
+}
+
+func TestScrapeSchedulerSkipsInFlightScrapes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pmc := &countingClient{delay: 50 * time.Millisecond}
+	pmf := NewPodMetricsFactoryWithConfig(pmc, datalayer.ScrapeConfig{
+		Interval: time.Millisecond,
+		Timeout:  time.Second,
+		Workers:  4,
+	})
+	pmf.NewEndpoint(ctx, newTestPod("pod1"), &fakeDataStore{})
+
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, int64(1), pmc.maxConcurrent.Load(), "expected a single scrape of the pod at a time")
+	assert.LessOrEqual(t, pmc.calls.Load(), int64(5), "expected due scrapes to be skipped while a scrape is in flight")
+}
+
+func TestScrapeSchedulerBacksOffFailingPods(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pmc := &countingClient{err: errors.New("connection refused")}
+	pmf := NewPodMetricsFactoryWithConfig(pmc, datalayer.ScrapeConfig{
+		Interval:   10 * time.Millisecond,
+		Timeout:    time.Second,
+		Workers:    1,
+		MaxBackoff: time.Minute,
+	})
+	pmf.NewEndpoint(ctx, newTestPod("pod1"), &fakeDataStore{})
+
+	// Without backoff, the pod would be scraped about 30 times. With backoff, scrapes happen after
+	// roughly 0, 20, 60, 140 and 300 milliseconds.
+	time.Sleep(300 * time.Millisecond)
+	calls := pmc.calls.Load()
+	assert.GreaterOrEqual(t, calls, int64(2))
+	assert.LessOrEqual(t, calls, int64(6))
+}
+
+func TestScrapeSchedulerStopsAfterRelease(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pmc := &countingClient{}
+	pmf := NewPodMetricsFactoryWithConfig(pmc, datalayer.ScrapeConfig{
+		Interval: time.Millisecond,
+		Timeout:  time.Second,
+		Workers:  1,
+	})
+	pm := pmf.NewEndpoint(ctx, newTestPod("pod1"), &fakeDataStore{})
+	assert.Eventually(t, func() bool { return pmc.calls.Load() > 0 }, time.Second, time.Millisecond)
+
+	pmf.ReleaseEndpoint(pm)
+	time.Sleep(10 * time.Millisecond) // allow an in-flight scrape to complete
+	calls := pmc.calls.Load()
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, calls, pmc.calls.Load(), "expected scrapes to stop after release")
+}
+
+func TestScrapeSchedulerSkipsFreshPods(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pmc := &countingClient{}
+	pmf := NewPodMetricsFactoryWithConfig(pmc, datalayer.ScrapeConfig{
+		Interval:    10 * time.Millisecond,
+		Timeout:     time.Second,
+		Workers:     1,
+		MaxInterval: time.Hour,
+	})
+	pm := pmf.NewEndpoint(ctx, newTestPod("pod1"), &fakeDataStore{})
+	assert.Eventually(t, func() bool { return pmc.calls.Load() > 0 }, time.Second, time.Millisecond)
+
+	// Keep the metrics fresh, as in-band load reports do.
+	deadline := time.Now().Add(200 * time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	calls := pmc.calls.Load()
+	for time.Now().Before(deadline) {
+		updated := pm.GetMetrics().Clone()
+		updated.UpdateTime = time.Now()
+		pm.UpdateMetrics(updated)
+		time.Sleep(time.Millisecond)
+	}
+	assert.LessOrEqual(t, pmc.calls.Load()-calls, int64(1), "expected scrapes of a pod with fresh metrics to be skipped")
+
+	// Scrapes resume once the metrics are no longer refreshed.
+	calls = pmc.calls.Load()
+	assert.Eventually(t, func() bool { return pmc.calls.Load() > calls+2 }, time.Second, time.Millisecond)
+}
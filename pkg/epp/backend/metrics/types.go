@@ -19,13 +19,12 @@ package metrics
 
 import (
 	"context"
-	"sync"
 	"time"
 
-	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datalayer"
 )
 
 var (
@@ -41,40 +40,52 @@ func PodsWithFreshMetrics(stalenessThreshold time.Duration) func(PodMetrics) boo
 	}
 }
 
+// NewPodMetricsFactory returns a PodMetricsFactory scraping each pod at the given interval, using
+// the default settings for the other scrape parameters.
 func NewPodMetricsFactory(pmc PodMetricsClient, refreshMetricsInterval time.Duration) *PodMetricsFactory {
+	return NewPodMetricsFactoryWithConfig(pmc, datalayer.DefaultScrapeConfig(refreshMetricsInterval))
+}
+
+// NewPodMetricsFactoryWithConfig returns a PodMetricsFactory scraping pods according to the given
+// ScrapeConfig.
+func NewPodMetricsFactoryWithConfig(pmc PodMetricsClient, config datalayer.ScrapeConfig) *PodMetricsFactory {
 	return &PodMetricsFactory{
-		pmc:                    pmc,
-		refreshMetricsInterval: refreshMetricsInterval,
+		pmc:       pmc,
+		scheduler: datalayer.NewScrapeScheduler(config),
 	}
 }
 
+// PodMetricsFactory is a datalayer.EndpointFactory that refreshes the metrics of the endpoints
+// using a PodMetricsClient. Scrapes of all endpoints are driven by a shared scheduler.
 type PodMetricsFactory struct {
-	pmc                    PodMetricsClient
-	refreshMetricsInterval time.Duration
+	pmc       PodMetricsClient
+	scheduler *datalayer.ScrapeScheduler
 }
 
-func (f *PodMetricsFactory) NewPodMetrics(parentCtx context.Context, in *corev1.Pod, ds Datastore) PodMetrics {
-	pod := toInternalPod(in)
+func (f *PodMetricsFactory) NewEndpoint(parentCtx context.Context, pod *backend.Pod, ds datalayer.PoolInfo) datalayer.Endpoint {
 	pm := &podMetrics{
-		pmc:       f.pmc,
-		ds:        ds,
-		interval:  f.refreshMetricsInterval,
-		startOnce: sync.Once{},
-		stopOnce:  sync.Once{},
-		done:      make(chan struct{}),
-		logger:    log.FromContext(parentCtx).WithValues("pod", pod.NamespacedName),
+		pmc:        f.pmc,
+		ds:         ds,
+		attributes: datalayer.NewAttributes(),
+		logger:     log.FromContext(parentCtx).WithValues("pod", pod.NamespacedName),
 	}
 	pm.pod.Store(pod)
 	pm.metrics.Store(NewMetricsState())
 
-	pm.startRefreshLoop(parentCtx)
+	f.scheduler.Start(parentCtx)
+	f.scheduler.Register(pm, pm.logger, pm.refreshMetrics)
 	return pm
 }
 
-type PodMetrics interface {
-	GetPod() *backend.Pod
-	GetMetrics() *MetricsState
-	UpdatePod(*corev1.Pod)
-	StopRefreshLoop()
-	String() string
+// SetScrapeObserver sets the observer notified of the outcome of each scrape.
+func (f *PodMetricsFactory) SetScrapeObserver(observer datalayer.ScrapeObserver) {
+	f.scheduler.SetObserver(observer)
 }
+
+func (f *PodMetricsFactory) ReleaseEndpoint(ep datalayer.Endpoint) {
+	f.scheduler.Unregister(ep)
+}
+
+// PodMetrics is an alias of datalayer.Endpoint, allowing both the legacy metrics refresher
+// and the pluggable data layer to provide endpoints to the datastore.
+type PodMetrics = datalayer.Endpoint
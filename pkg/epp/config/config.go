@@ -16,9 +16,57 @@ limitations under the License.
 
 package config
 
-import "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling"
+import (
+	configapi "sigs.k8s.io/gateway-api-inference-extension/apix/config/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/flowcontrol/registry"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/saturationdetector"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling"
+)
 
 // Config is the configuration loaded from the text based configuration
 type Config struct {
 	SchedulerConfig *scheduling.SchedulerConfig
+	// Plugins are the plugins instantiated for the configuration, by name.
+	Plugins map[string]PluginInstance
+	// SaturationDetectorConfig is the configuration of the saturation detector, with its defaults
+	// applied. It's nil if the configuration doesn't have a saturationDetector section.
+	SaturationDetectorConfig *saturationdetector.Config
+	// FlowControlConfig is the configuration of the flow control layer. It's nil if the
+	// configuration doesn't have a flowControl section.
+	FlowControlConfig *FlowControlConfig
+}
+
+// PluginInstance is a plugin instantiated for the configuration, along with its spec.
+type PluginInstance struct {
+	Spec   configapi.PluginSpec
+	Plugin plugins.Plugin
+}
+
+// FlowControlConfig is the configuration of the flow control layer.
+type FlowControlConfig struct {
+	// Registry is the configuration of the flow registry, validated and with its defaults applied.
+	Registry registry.Config
+	// Bands maps the criticality ranges to the priorities of the bands. The ranges cover all the
+	// criticalities, and are sorted from the lowest criticalities.
+	Bands []CriticalityBand
+}
+
+// CriticalityBand maps an inclusive range of criticalities to the priority of a band.
+type CriticalityBand struct {
+	// Min and Max are the bounds of the range, nil when the range is unbounded.
+	Min, Max *int
+	// Priority is the priority of the band in the flow registry, 0 being the highest.
+	Priority uint
+}
+
+// Priority returns the priority of the band serving the requests of the given criticality.
+func (c *FlowControlConfig) Priority(criticality int) uint {
+	for _, band := range c.Bands {
+		if band.Max == nil || criticality <= *band.Max {
+			return band.Priority
+		}
+	}
+	// Unreachable for a loaded configuration, whose ranges cover all the criticalities.
+	return c.Bands[len(c.Bands)-1].Priority
 }
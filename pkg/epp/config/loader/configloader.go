@@ -17,6 +17,8 @@ limitations under the License.
 package loader
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -41,6 +43,13 @@ func init() {
 
 // Load config from supplied text that was converted to []byte
 func LoadConfig(configBytes []byte, handle plugins.Handle, logger logr.Logger) (*config.Config, error) {
+	return ReloadConfig(configBytes, handle, nil, logger)
+}
+
+// ReloadConfig loads a new configuration like LoadConfig, but reuses the plugin instances of the
+// previous configuration whose name, type and parameters did not change, so that they keep their
+// state, e.g. the index of the prefix cache plugin. The previous configuration may be nil.
+func ReloadConfig(configBytes []byte, handle plugins.Handle, previous *config.Config, logger logr.Logger) (*config.Config, error) {
 	rawConfig, err := loadRawConfig(configBytes)
 	if err != nil {
 		return nil, err
@@ -51,7 +60,11 @@ func LoadConfig(configBytes []byte, handle plugins.Handle, logger logr.Logger) (
 	setDefaultsPhaseOne(rawConfig)
 
 	// instantiate loaded plugins
-	if err = instantiatePlugins(rawConfig.Plugins, handle); err != nil {
+	var previousPlugins map[string]config.PluginInstance
+	if previous != nil {
+		previousPlugins = previous.Plugins
+	}
+	if err = instantiatePlugins(rawConfig.Plugins, handle, previousPlugins, logger); err != nil {
 		return nil, fmt.Errorf("failed to instantiate plugins - %w", err)
 	}
 
@@ -63,14 +76,28 @@ func LoadConfig(configBytes []byte, handle plugins.Handle, logger logr.Logger) (
 		return nil, fmt.Errorf("failed to validate scheduling profiles - %w", err)
 	}
 
-	config := &config.Config{}
+	loaded := &config.Config{Plugins: make(map[string]config.PluginInstance, len(rawConfig.Plugins))}
 
-	config.SchedulerConfig, err = loadSchedulerConfig(rawConfig.SchedulingProfiles, handle)
+	loaded.SchedulerConfig, err = loadSchedulerConfig(rawConfig.SchedulingProfiles, handle)
 	if err != nil {
 		return nil, err
 	}
 
-	return config, nil
+	loaded.SaturationDetectorConfig, err = loadSaturationDetectorConfig(rawConfig.SaturationDetector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate the saturation detector configuration - %w", err)
+	}
+
+	loaded.FlowControlConfig, err = loadFlowControlConfig(rawConfig.FlowControl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate the flow control configuration - %w", err)
+	}
+
+	for _, pluginConfig := range rawConfig.Plugins {
+		loaded.Plugins[pluginConfig.Name] = config.PluginInstance{Spec: pluginConfig, Plugin: handle.Plugin(pluginConfig.Name)}
+	}
+
+	return loaded, nil
 }
 
 func loadRawConfig(configBytes []byte) (*configapi.EndpointPickerConfig, error) {
@@ -116,7 +143,8 @@ func loadSchedulerConfig(configProfiles []configapi.SchedulingProfile, handle pl
 	return scheduling.NewSchedulerConfig(profileHandler, profiles), nil
 }
 
-func instantiatePlugins(configuredPlugins []configapi.PluginSpec, handle plugins.Handle) error {
+func instantiatePlugins(configuredPlugins []configapi.PluginSpec, handle plugins.Handle,
+	previousPlugins map[string]config.PluginInstance, logger logr.Logger) error {
 	pluginNames := sets.New[string]() // set of plugin names, a name must be unique
 
 	for _, pluginConfig := range configuredPlugins {
@@ -129,6 +157,12 @@ func instantiatePlugins(configuredPlugins []configapi.PluginSpec, handle plugins
 		}
 		pluginNames.Insert(pluginConfig.Name)
 
+		if previous, ok := previousPlugins[pluginConfig.Name]; ok && samePluginSpec(previous.Spec, pluginConfig) {
+			logger.Info("Reusing the unchanged plugin", "name", pluginConfig.Name, "type", pluginConfig.Type)
+			handle.AddPlugin(pluginConfig.Name, previous.Plugin)
+			continue
+		}
+
 		factory, ok := plugins.Registry[pluginConfig.Type]
 		if !ok {
 			return fmt.Errorf("plugin type '%s' is not found in registry", pluginConfig.Type)
@@ -145,6 +179,31 @@ func instantiatePlugins(configuredPlugins []configapi.PluginSpec, handle plugins
 	return nil
 }
 
+// samePluginSpec returns whether two plugin specs have the same name, type and parameters. The
+// parameters are compared regardless of their formatting and of the order of their fields.
+func samePluginSpec(a, b configapi.PluginSpec) bool {
+	if a.Name != b.Name || a.Type != b.Type {
+		return false
+	}
+	return bytes.Equal(normalizeJSON(a.Parameters), normalizeJSON(b.Parameters))
+}
+
+// normalizeJSON re-encodes the JSON value, which sorts the fields of its objects.
+func normalizeJSON(raw json.RawMessage) []byte {
+	if len(raw) == 0 {
+		return nil
+	}
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return raw
+	}
+	normalized, err := json.Marshal(value)
+	if err != nil {
+		return raw
+	}
+	return normalized
+}
+
 func validateSchedulingProfiles(config *configapi.EndpointPickerConfig) error {
 	profileNames := sets.New[string]()
 	for _, profile := range config.SchedulingProfiles {
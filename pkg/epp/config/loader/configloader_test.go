@@ -314,7 +314,7 @@ func TestLoadRawConfigurationWithDefaults(t *testing.T) {
 		if err == nil {
 			setDefaultsPhaseOne(got)
 
-			err = instantiatePlugins(got.Plugins, handle)
+			err = instantiatePlugins(got.Plugins, handle, nil, logging.NewTestLogger())
 			if err == nil {
 				setDefaultsPhaseTwo(got, handle)
 
@@ -390,6 +390,11 @@ func TestLoadConfig(t *testing.T) {
 			configText: successWithNoProfileHandlersText,
 			wantErr:    false,
 		},
+		{
+			name:       "successWithScorerAfterPicker",
+			configText: successWithScorerAfterPickerText,
+			wantErr:    false,
+		},
 		{
 			name:       "errorBadYaml",
 			configText: errorBadYamlText,
@@ -439,6 +444,110 @@ func TestLoadConfig(t *testing.T) {
 	}
 }
 
+func TestReloadConfig(t *testing.T) {
+	registerNeededPlgugins()
+	logger := logging.NewTestLogger()
+
+	previous, err := LoadConfig([]byte(successSchedulerConfigText), utils.NewTestHandle(context.Background()), logger)
+	if err != nil {
+		t.Fatalf("LoadConfig returned unexpected error - %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		configText string
+		wantReused bool
+	}{
+		{
+			name:       "unchanged parameters",
+			configText: successSchedulerConfigText,
+			wantReused: true,
+		},
+		{
+			name: "reformatted parameters and changed weight",
+			configText: `
+apiVersion: inference.networking.x-k8s.io/v1alpha1
+kind: EndpointPickerConfig
+plugins:
+- name: prefixCacheScorer
+  type: prefix-cache-scorer
+  parameters: {"hashBlockSize": 32}
+- name: maxScorePicker
+  type: max-score-picker
+- name: profileHandler
+  type: single-profile-handler
+schedulingProfiles:
+- name: default
+  plugins:
+  - pluginRef: prefixCacheScorer
+    weight: 10
+  - pluginRef: maxScorePicker
+`,
+			wantReused: true,
+		},
+		{
+			name: "changed parameters",
+			configText: `
+apiVersion: inference.networking.x-k8s.io/v1alpha1
+kind: EndpointPickerConfig
+plugins:
+- name: prefixCacheScorer
+  type: prefix-cache-scorer
+  parameters:
+    hashBlockSize: 64
+- name: maxScorePicker
+  type: max-score-picker
+- name: profileHandler
+  type: single-profile-handler
+`,
+			wantReused: false,
+		},
+		{
+			name: "renamed plugin",
+			configText: `
+apiVersion: inference.networking.x-k8s.io/v1alpha1
+kind: EndpointPickerConfig
+plugins:
+- name: prefixCache
+  type: prefix-cache-scorer
+  parameters:
+    hashBlockSize: 32
+- name: maxScorePicker
+  type: max-score-picker
+- name: profileHandler
+  type: single-profile-handler
+`,
+			wantReused: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handle := utils.NewTestHandle(context.Background())
+			got, err := ReloadConfig([]byte(test.configText), handle, previous, logger)
+			if err != nil {
+				t.Fatalf("ReloadConfig returned unexpected error - %v", err)
+			}
+			reused := false
+			for _, plugin := range got.Plugins {
+				if plugin.Plugin == previous.Plugins["prefixCacheScorer"].Plugin {
+					reused = true
+				}
+			}
+			if reused != test.wantReused {
+				t.Errorf("prefix cache plugin reused = %t, want %t", reused, test.wantReused)
+			}
+			if got.Plugins["maxScorePicker"].Plugin != previous.Plugins["maxScorePicker"].Plugin {
+				t.Errorf("unchanged plugin maxScorePicker was not reused")
+			}
+		})
+	}
+
+	if _, err := ReloadConfig([]byte(errorBadPluginJsonText), utils.NewTestHandle(context.Background()), previous, logger); err == nil {
+		t.Errorf("ReloadConfig did not return an expected error")
+	}
+}
+
 func registerNeededPlgugins() {
 	plugins.Register(prefix.PrefixCachePluginType, prefix.PrefixCachePluginFactory)
 	plugins.Register(picker.MaxScorePickerType, picker.MaxScorePickerFactory)
@@ -801,6 +910,28 @@ schedulingProfiles:
   - pluginRef: prefixCacheScorer
 `
 
+// valid configuration, with default weight for a scorer listed after the picker
+//
+//nolint:dupword
+const successWithScorerAfterPickerText = `
+apiVersion: inference.networking.x-k8s.io/v1alpha1
+kind: EndpointPickerConfig
+plugins:
+- name: profileHandler
+  type: single-profile-handler
+- name: maxScorePicker
+  type: max-score-picker
+- name: prefixCacheScorer
+  type: prefix-cache-scorer
+  parameters:
+    hashBlockSize: 32
+schedulingProfiles:
+- name: default
+  plugins:
+  - pluginRef: maxScorePicker
+  - pluginRef: prefixCacheScorer
+`
+
 // valid configuration using default profile handler
 //
 //nolint:dupword
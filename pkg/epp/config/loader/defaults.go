@@ -120,8 +120,8 @@ func setDefaultsPhaseTwo(cfg *configapi.EndpointPickerConfig, handle plugins.Han
 				theProfile.Plugins[pluginIdx].Weight = &defaultScorerWeight
 				cfg.SchedulingProfiles[idx] = theProfile
 			} else if _, ok := referencedPlugin.(framework.Picker); ok {
+				// Keep going, the scorers after the picker need their default weight too.
 				hasPicker = true
-				break
 			}
 		}
 		if !hasPicker {
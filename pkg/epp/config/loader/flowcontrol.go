@@ -0,0 +1,203 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loader
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	configapi "sigs.k8s.io/gateway-api-inference-extension/apix/config/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/config"
+	inter "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/flowcontrol/framework/plugins/policies/interflow/dispatch"
+	intra "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/flowcontrol/framework/plugins/policies/intraflow/dispatch"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/flowcontrol/framework/plugins/queue"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/flowcontrol/registry"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/saturationdetector"
+)
+
+// loadSaturationDetectorConfig validates the saturationDetector section and applies its defaults.
+// It returns nil if the section is omitted.
+func loadSaturationDetectorConfig(raw *configapi.SaturationDetectorConfig) (*saturationdetector.Config, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	cfg := &saturationdetector.Config{
+		QueueDepthThreshold:       saturationdetector.DefaultQueueDepthThreshold,
+		KVCacheUtilThreshold:      saturationdetector.DefaultKVCacheUtilThreshold,
+		MetricsStalenessThreshold: saturationdetector.DefaultMetricsStalenessThreshold,
+		BypassCriticality:         saturationdetector.DefaultBypassCriticality,
+	}
+
+	var errs []error
+	if raw.QueueDepthThreshold != nil {
+		if *raw.QueueDepthThreshold <= 0 {
+			errs = append(errs, fmt.Errorf("saturationDetector.queueDepthThreshold must be positive, got %d", *raw.QueueDepthThreshold))
+		}
+		cfg.QueueDepthThreshold = *raw.QueueDepthThreshold
+	}
+	if raw.KVCacheUtilThreshold != nil {
+		if *raw.KVCacheUtilThreshold <= 0 || *raw.KVCacheUtilThreshold >= 1 {
+			errs = append(errs, fmt.Errorf("saturationDetector.kvCacheUtilThreshold must be between 0 and 1 exclusive, got %g", *raw.KVCacheUtilThreshold))
+		}
+		cfg.KVCacheUtilThreshold = *raw.KVCacheUtilThreshold
+	}
+	if raw.MetricsStalenessThreshold != nil {
+		if raw.MetricsStalenessThreshold.Duration <= 0 {
+			errs = append(errs, fmt.Errorf("saturationDetector.metricsStalenessThreshold must be positive, got %s", raw.MetricsStalenessThreshold.Duration))
+		}
+		cfg.MetricsStalenessThreshold = raw.MetricsStalenessThreshold.Duration
+	}
+	if raw.BypassCriticality != nil {
+		cfg.BypassCriticality = *raw.BypassCriticality
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return cfg, nil
+}
+
+// loadFlowControlConfig validates the flowControl section, maps the criticality ranges to the
+// priorities of the bands, and applies the defaults of the flow registry. It returns nil if the
+// section is omitted.
+func loadFlowControlConfig(raw *configapi.FlowControlConfig) (*config.FlowControlConfig, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	if len(raw.PriorityBands) == 0 {
+		return nil, errors.New("flowControl.priorityBands must have at least one band")
+	}
+
+	var errs []error
+	names := sets.New[string]()
+	for i, band := range raw.PriorityBands {
+		field := fmt.Sprintf("flowControl.priorityBands[%d]", i)
+		if band.Name == "" {
+			errs = append(errs, fmt.Errorf("%s.name is required", field))
+		} else if names.Has(band.Name) {
+			errs = append(errs, fmt.Errorf("%s.name %q is used more than once", field, band.Name))
+		}
+		names.Insert(band.Name)
+		if band.Criticality.Min != nil && band.Criticality.Max != nil && *band.Criticality.Min > *band.Criticality.Max {
+			errs = append(errs, fmt.Errorf("%s.criticality min %d is greater than max %d", field,
+				*band.Criticality.Min, *band.Criticality.Max))
+		}
+		if band.Queue != "" && !registered(queue.RegisteredQueues, band.Queue) {
+			errs = append(errs, fmt.Errorf("%s.queue %q is not a registered queue, must be one of %v", field,
+				band.Queue, registeredNames(queue.RegisteredQueues)))
+		}
+		if band.IntraFlowDispatchPolicy != "" && !registered(intra.RegisteredPolicies, band.IntraFlowDispatchPolicy) {
+			errs = append(errs, fmt.Errorf("%s.intraFlowDispatchPolicy %q is not a registered policy, must be one of %v", field,
+				band.IntraFlowDispatchPolicy, registeredNames(intra.RegisteredPolicies)))
+		}
+		if band.InterFlowDispatchPolicy != "" && !registered(inter.RegisteredPolicies, band.InterFlowDispatchPolicy) {
+			errs = append(errs, fmt.Errorf("%s.interFlowDispatchPolicy %q is not a registered policy, must be one of %v", field,
+				band.InterFlowDispatchPolicy, registeredNames(inter.RegisteredPolicies)))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	// Sort the bands from the lowest criticalities, so that the ranges are checked in order and the
+	// band with the highest criticalities gets the highest priority, i.e. 0.
+	bands := slices.Clone(raw.PriorityBands)
+	slices.SortStableFunc(bands, func(a, b configapi.PriorityBand) int {
+		return cmp.Compare(lowerBound(a.Criticality), lowerBound(b.Criticality))
+	})
+	for i := range bands {
+		if i == 0 {
+			if bands[i].Criticality.Min != nil {
+				errs = append(errs, fmt.Errorf("flowControl.priorityBands: criticalities below %d are not served by any band",
+					*bands[i].Criticality.Min))
+			}
+			continue
+		}
+		previous, current := bands[i-1], bands[i]
+		if previous.Criticality.Max == nil || lowerBound(current.Criticality) <= *previous.Criticality.Max {
+			errs = append(errs, fmt.Errorf("flowControl.priorityBands: the criticality ranges of bands %q %v and %q %v overlap",
+				previous.Name, previous.Criticality, current.Name, current.Criticality))
+		} else if *current.Criticality.Min > *previous.Criticality.Max+1 {
+			errs = append(errs, fmt.Errorf("flowControl.priorityBands: criticalities from %d to %d are not served by any band",
+				*previous.Criticality.Max+1, *current.Criticality.Min-1))
+		}
+	}
+	if last := bands[len(bands)-1]; last.Criticality.Max != nil {
+		errs = append(errs, fmt.Errorf("flowControl.priorityBands: criticalities above %d are not served by any band",
+			*last.Criticality.Max))
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	cfg := &config.FlowControlConfig{
+		Registry: registry.Config{
+			MaxBytes:      raw.MaxBytes,
+			PriorityBands: make([]registry.PriorityBandConfig, 0, len(bands)),
+		},
+		Bands: make([]config.CriticalityBand, 0, len(bands)),
+	}
+	for i, band := range bands {
+		priority := uint(len(bands) - 1 - i)
+		cfg.Registry.PriorityBands = append(cfg.Registry.PriorityBands, registry.PriorityBandConfig{
+			Priority:                priority,
+			PriorityName:            band.Name,
+			IntraFlowDispatchPolicy: intra.RegisteredPolicyName(band.IntraFlowDispatchPolicy),
+			InterFlowDispatchPolicy: inter.RegisteredPolicyName(band.InterFlowDispatchPolicy),
+			Queue:                   queue.RegisteredQueueName(band.Queue),
+			MaxBytes:                band.MaxBytes,
+		})
+		cfg.Bands = append(cfg.Bands, config.CriticalityBand{
+			Min:      band.Criticality.Min,
+			Max:      band.Criticality.Max,
+			Priority: priority,
+		})
+	}
+	// The registry checks the compatibility of the policies with the queues.
+	if err := cfg.Registry.ValidateAndApplyDefaults(); err != nil {
+		return nil, fmt.Errorf("flowControl: %w", err)
+	}
+	return cfg, nil
+}
+
+func lowerBound(r configapi.CriticalityRange) int {
+	if r.Min == nil {
+		return math.MinInt
+	}
+	return *r.Min
+}
+
+// registered returns whether a policy or a queue is registered with the given name. The policies and
+// the queues are registered by the init functions of their packages.
+func registered[K ~string, V any](registered map[K]V, name string) bool {
+	_, ok := registered[K(name)]
+	return ok
+}
+
+// registeredNames returns the sorted names of a registry of policies or queues.
+func registeredNames[K ~string, V any](registered map[K]V) []string {
+	names := make([]string, 0, len(registered))
+	for name := range registered {
+		names = append(names, string(name))
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	configapi "sigs.k8s.io/gateway-api-inference-extension/apix/config/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/flowcontrol/registry"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/saturationdetector"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+	"sigs.k8s.io/gateway-api-inference-extension/test/utils"
+)
+
+func TestLoadSaturationDetectorConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     *configapi.SaturationDetectorConfig
+		want    *saturationdetector.Config
+		wantErr string
+	}{
+		{
+			name: "omitted section",
+		},
+		{
+			name: "defaults",
+			raw:  &configapi.SaturationDetectorConfig{},
+			want: &saturationdetector.Config{
+				QueueDepthThreshold:       saturationdetector.DefaultQueueDepthThreshold,
+				KVCacheUtilThreshold:      saturationdetector.DefaultKVCacheUtilThreshold,
+				MetricsStalenessThreshold: saturationdetector.DefaultMetricsStalenessThreshold,
+				BypassCriticality:         saturationdetector.DefaultBypassCriticality,
+			},
+		},
+		{
+			name: "all fields",
+			raw: &configapi.SaturationDetectorConfig{
+				QueueDepthThreshold:       ptr.To(10),
+				KVCacheUtilThreshold:      ptr.To(0.9),
+				MetricsStalenessThreshold: &metav1.Duration{Duration: time.Second},
+				BypassCriticality:         ptr.To(1),
+			},
+			want: &saturationdetector.Config{
+				QueueDepthThreshold:       10,
+				KVCacheUtilThreshold:      0.9,
+				MetricsStalenessThreshold: time.Second,
+				BypassCriticality:         1,
+			},
+		},
+		{
+			name:    "non positive queue depth",
+			raw:     &configapi.SaturationDetectorConfig{QueueDepthThreshold: ptr.To(0)},
+			wantErr: "saturationDetector.queueDepthThreshold must be positive, got 0",
+		},
+		{
+			name:    "KV cache utilization out of range",
+			raw:     &configapi.SaturationDetectorConfig{KVCacheUtilThreshold: ptr.To(1.5)},
+			wantErr: "saturationDetector.kvCacheUtilThreshold must be between 0 and 1 exclusive, got 1.5",
+		},
+		{
+			name:    "negative staleness",
+			raw:     &configapi.SaturationDetectorConfig{MetricsStalenessThreshold: &metav1.Duration{Duration: -time.Second}},
+			wantErr: "saturationDetector.metricsStalenessThreshold must be positive, got -1s",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := loadSaturationDetectorConfig(test.raw)
+			if test.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestLoadFlowControlConfig(t *testing.T) {
+	band := func(name string, min, max *int) configapi.PriorityBand {
+		return configapi.PriorityBand{Name: name, Criticality: configapi.CriticalityRange{Min: min, Max: max}}
+	}
+
+	tests := []struct {
+		name           string
+		raw            *configapi.FlowControlConfig
+		wantPriorities map[int]uint
+		wantErr        string
+	}{
+		{
+			name: "omitted section",
+		},
+		{
+			name: "single band",
+			raw: &configapi.FlowControlConfig{
+				PriorityBands: []configapi.PriorityBand{band("All", nil, nil)},
+			},
+			wantPriorities: map[int]uint{-10: 0, 0: 0, 10: 0},
+		},
+		{
+			name: "bands in any order",
+			raw: &configapi.FlowControlConfig{
+				PriorityBands: []configapi.PriorityBand{
+					band("Standard", ptr.To(0), ptr.To(1)),
+					band("Critical", ptr.To(2), nil),
+					band("Sheddable", nil, ptr.To(-1)),
+				},
+			},
+			wantPriorities: map[int]uint{-5: 2, -1: 2, 0: 1, 1: 1, 2: 0, 5: 0},
+		},
+		{
+			name:    "no band",
+			raw:     &configapi.FlowControlConfig{},
+			wantErr: "flowControl.priorityBands must have at least one band",
+		},
+		{
+			name: "missing name",
+			raw: &configapi.FlowControlConfig{
+				PriorityBands: []configapi.PriorityBand{band("", nil, nil)},
+			},
+			wantErr: "flowControl.priorityBands[0].name is required",
+		},
+		{
+			name: "duplicated name",
+			raw: &configapi.FlowControlConfig{
+				PriorityBands: []configapi.PriorityBand{band("Band", nil, ptr.To(0)), band("Band", ptr.To(1), nil)},
+			},
+			wantErr: `flowControl.priorityBands[1].name "Band" is used more than once`,
+		},
+		{
+			name: "empty range",
+			raw: &configapi.FlowControlConfig{
+				PriorityBands: []configapi.PriorityBand{band("All", ptr.To(2), ptr.To(1))},
+			},
+			wantErr: "flowControl.priorityBands[0].criticality min 2 is greater than max 1",
+		},
+		{
+			name: "overlapping ranges",
+			raw: &configapi.FlowControlConfig{
+				PriorityBands: []configapi.PriorityBand{band("Low", nil, ptr.To(2)), band("High", ptr.To(2), nil)},
+			},
+			wantErr: `the criticality ranges of bands "Low" [-inf, 2] and "High" [2, +inf] overlap`,
+		},
+		{
+			name: "gap between ranges",
+			raw: &configapi.FlowControlConfig{
+				PriorityBands: []configapi.PriorityBand{band("Low", nil, ptr.To(0)), band("High", ptr.To(3), nil)},
+			},
+			wantErr: "criticalities from 1 to 2 are not served by any band",
+		},
+		{
+			name: "uncovered lowest criticalities",
+			raw: &configapi.FlowControlConfig{
+				PriorityBands: []configapi.PriorityBand{band("All", ptr.To(0), nil)},
+			},
+			wantErr: "criticalities below 0 are not served by any band",
+		},
+		{
+			name: "uncovered highest criticalities",
+			raw: &configapi.FlowControlConfig{
+				PriorityBands: []configapi.PriorityBand{band("All", nil, ptr.To(5))},
+			},
+			wantErr: "criticalities above 5 are not served by any band",
+		},
+		{
+			name: "unknown queue",
+			raw: &configapi.FlowControlConfig{
+				PriorityBands: []configapi.PriorityBand{{Name: "All", Queue: "PriorityQueue"}},
+			},
+			wantErr: `flowControl.priorityBands[0].queue "PriorityQueue" is not a registered queue, must be one of [ListQueue MaxMinHeap]`,
+		},
+		{
+			name: "unknown policies",
+			raw: &configapi.FlowControlConfig{
+				PriorityBands: []configapi.PriorityBand{{Name: "All", IntraFlowDispatchPolicy: "LIFO", InterFlowDispatchPolicy: "Random"}},
+			},
+			wantErr: `flowControl.priorityBands[0].intraFlowDispatchPolicy "LIFO" is not a registered policy`,
+		},
+		{
+			name: "policy incompatible with the queue",
+			raw: &configapi.FlowControlConfig{
+				PriorityBands: []configapi.PriorityBand{{Name: "All", Queue: "MaxMinHeap"}},
+			},
+			wantErr: `policy "FCFS" is not compatible with queue "MaxMinHeap"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := loadFlowControlConfig(test.raw)
+			if test.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if test.raw == nil {
+				assert.Nil(t, got)
+				return
+			}
+			for criticality, want := range test.wantPriorities {
+				assert.Equal(t, want, got.Priority(criticality), "priority of criticality %d", criticality)
+			}
+		})
+	}
+}
+
+func TestLoadConfigWithFlowControl(t *testing.T) {
+	registerNeededPlgugins()
+	config, err := LoadConfig([]byte(`
+apiVersion: inference.networking.x-k8s.io/v1alpha1
+kind: EndpointPickerConfig
+plugins:
+- type: prefix-cache-scorer
+saturationDetector:
+  queueDepthThreshold: 10
+  metricsStalenessThreshold: 500ms
+  bypassCriticality: 3
+flowControl:
+  maxBytes: 1000000
+  priorityBands:
+  - name: Critical
+    criticality:
+      min: 3
+    maxBytes: 600000
+  - name: Standard
+    criticality:
+      max: 2
+    interFlowDispatchPolicy: RoundRobin
+`), utils.NewTestHandle(context.Background()), logging.NewTestLogger())
+	require.NoError(t, err)
+
+	assert.Equal(t, &saturationdetector.Config{
+		QueueDepthThreshold:       10,
+		KVCacheUtilThreshold:      saturationdetector.DefaultKVCacheUtilThreshold,
+		MetricsStalenessThreshold: 500 * time.Millisecond,
+		BypassCriticality:         3,
+	}, config.SaturationDetectorConfig)
+
+	require.NotNil(t, config.FlowControlConfig)
+	assert.Equal(t, registry.Config{
+		MaxBytes: 1000000,
+		PriorityBands: []registry.PriorityBandConfig{
+			{Priority: 1, PriorityName: "Standard", Queue: "ListQueue", IntraFlowDispatchPolicy: "FCFS", InterFlowDispatchPolicy: "RoundRobin"},
+			{Priority: 0, PriorityName: "Critical", Queue: "ListQueue", IntraFlowDispatchPolicy: "FCFS", InterFlowDispatchPolicy: "BestHead", MaxBytes: 600000},
+		},
+	}, config.FlowControlConfig.Registry)
+	assert.Equal(t, uint(0), config.FlowControlConfig.Priority(3))
+	assert.Equal(t, uint(1), config.FlowControlConfig.Priority(2))
+
+	// The unknown fields are rejected.
+	_, err = LoadConfig([]byte(`
+apiVersion: inference.networking.x-k8s.io/v1alpha1
+kind: EndpointPickerConfig
+plugins:
+- type: prefix-cache-scorer
+flowControl:
+  bands: []
+`), utils.NewTestHandle(context.Background()), logging.NewTestLogger())
+	assert.ErrorContains(t, err, `unknown field "flowControl.bands"`)
+}
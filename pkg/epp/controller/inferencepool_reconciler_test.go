@@ -319,15 +319,12 @@ func xDiffStore(t *testing.T, datastore datastore.Datastore, params xDiffStorePa
 	if gotPool == nil && params.wantPool == nil {
 		return ""
 	}
-	uns, err := common.ToUnstructured(gotPool)
-	if err != nil {
-		t.Fatalf("failed to convert XInferencePool to Unstructured: %v", err)
+	gotXPool := &v1alpha2.InferencePool{}
+	if err := gotXPool.ConvertFrom(gotPool); err != nil {
+		t.Fatalf("failed to convert InferencePool to v1alpha2: %v", err)
 	}
-	gotXPool, err := common.ToXInferencePool(uns)
-	if err != nil {
-		t.Fatalf("failed to convert unstructured to InferencePool: %v", err)
-	}
-	if diff := cmp.Diff(params.wantPool, gotXPool); diff != "" {
+	// The conversion leaves the type meta empty.
+	if diff := cmp.Diff(params.wantPool, gotXPool, cmpopts.IgnoreFields(v1alpha2.InferencePool{}, "TypeMeta")); diff != "" {
 		return "pool:" + diff
 	}
 
@@ -95,8 +95,8 @@ func (c *PodReconciler) updateDatastore(logger logr.Logger, pod *corev1.Pod) {
 		logger.V(logutil.DEBUG).Info("Pod removed or not added", "name", namespacedName)
 		c.Datastore.PodDelete(namespacedName)
 	} else {
-		if c.Datastore.PodUpdateOrAddIfNotExist(pod) {
-			logger.V(logutil.DEFAULT).Info("Pod added", "name", namespacedName)
+		if added := c.Datastore.PodUpdateOrAddIfNotExist(pod); len(added) > 0 {
+			logger.V(logutil.DEFAULT).Info("Pod added", "name", namespacedName, "endpoints", added)
 		} else {
 			logger.V(logutil.DEFAULT).Info("Pod already exists", "name", namespacedName)
 		}
@@ -27,12 +27,10 @@ import (
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 )
 
-// TODO:
-// currently the data store is expected to manage the state of multiple
-// Collectors (e.g., using sync.Map mapping pod to its Collector). Alternatively,
-// this can be encapsulated in this file, providing the data store with an interface
-// to only update on endpoint addition/change and deletion. This can also be used
-// to centrally track statistics such errors, active routines, etc.
+// The EndpointLifecycle (see factory.go), which the data store notifies on endpoint
+// addition and deletion, collects all the endpoints from a shared ScrapeScheduler,
+// which also tracks the scrape statistics. A Collector runs the collection of a single
+// endpoint on its own Ticker instead.
 
 const (
 	defaultCollectionTimeout = time.Second
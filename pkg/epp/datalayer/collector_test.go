@@ -54,7 +54,7 @@ func defaultEndpoint() Endpoint {
 			PodIP: "1.2.3.4",
 		},
 	}
-	ms.UpdatePod(pod)
+	ms.UpdatePod(ToPodInfo(pod))
 	return ms
 }
 
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datalayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// PoolInfo represents the data store information needed by endpoints.
+type PoolInfo interface {
+	PoolGet() (*v1.InferencePool, error)
+}
+
+// EndpointFactory defines an interface for allocating and releasing endpoints.
+// The data store uses the factory to create an Endpoint when a pod (or a data
+// parallel rank of a pod) is added to the pool and to release it (e.g., stop its
+// data collection) when the pod is removed.
+type EndpointFactory interface {
+	NewEndpoint(parent context.Context, pod *PodInfo, poolInfo PoolInfo) Endpoint
+	ReleaseEndpoint(ep Endpoint)
+}
+
+// EndpointLifecycle manages the life cycle (creation and termination) of
+// endpoints, collecting the data of each endpoint from the configured sources.
+// Collections of all endpoints are driven by a shared ScrapeScheduler.
+type EndpointLifecycle struct {
+	sources   []DataSource // data sources for collectors
+	endpoints sync.Map     // key: types.NamespacedName, value: Endpoint
+	scheduler *ScrapeScheduler
+}
+
+// NewEndpointFactory returns a new endpoint factory, collecting data from the
+// given sources at the provided interval, using the default settings for the
+// other scrape parameters.
+func NewEndpointFactory(sources []DataSource, refreshInterval time.Duration) *EndpointLifecycle {
+	return NewEndpointFactoryWithConfig(sources, DefaultScrapeConfig(refreshInterval))
+}
+
+// NewEndpointFactoryWithConfig returns a new endpoint factory, collecting data
+// from the given sources according to the given ScrapeConfig.
+func NewEndpointFactoryWithConfig(sources []DataSource, config ScrapeConfig) *EndpointLifecycle {
+	return &EndpointLifecycle{
+		sources:   sources,
+		scheduler: NewScrapeScheduler(config),
+	}
+}
+
+// NewEndpoint returns a new endpoint for the given pod and starts its collection.
+// Nil is returned if the pod is already collected.
+func (lc *EndpointLifecycle) NewEndpoint(parent context.Context, pod *PodInfo, _ PoolInfo) Endpoint {
+	key := pod.GetNamespacedName()
+	logger := log.FromContext(parent).WithValues("pod", key)
+
+	endpoint := NewEndpoint()
+	endpoint.UpdatePod(pod)
+	endpoint.UpdateMetrics(NewMetrics())
+
+	if _, loaded := lc.endpoints.LoadOrStore(key, endpoint); loaded {
+		logger.V(logging.DEFAULT).Info("collector already running for endpoint")
+		return nil
+	}
+
+	lc.scheduler.Start(parent)
+	lc.scheduler.Register(endpoint, logger, lc.collectFunc(endpoint))
+	return endpoint
+}
+
+// ReleaseEndpoint stops the collection of the endpoint.
+func (lc *EndpointLifecycle) ReleaseEndpoint(ep Endpoint) {
+	key := ep.GetPod().GetNamespacedName()
+	if value, ok := lc.endpoints.LoadAndDelete(key); ok {
+		lc.scheduler.Unregister(value.(Endpoint))
+	}
+}
+
+// SetScrapeObserver sets the observer notified of the outcome of each collection.
+func (lc *EndpointLifecycle) SetScrapeObserver(observer ScrapeObserver) {
+	lc.scheduler.SetObserver(observer)
+}
+
+// collectFunc returns the function collecting the data of the endpoint from all
+// the sources. The endpoint is reported as updated if any source updated its
+// metrics, even if another source failed.
+func (lc *EndpointLifecycle) collectFunc(ep Endpoint) ScrapeFunc {
+	return func(ctx context.Context) (bool, error) {
+		before := ep.GetMetrics().UpdateTime
+		var errs []error
+		for _, src := range lc.sources {
+			if err := src.Collect(ctx, ep); err != nil {
+				errs = append(errs, fmt.Errorf("failed to collect from %s - %w", src.Name(), err))
+			}
+		}
+		return ep.GetMetrics().UpdateTime.After(before), errors.Join(errs...)
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datalayer
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+type fakePoolInfo struct{}
+
+func (f *fakePoolInfo) PoolGet() (*v1.InferencePool, error) {
+	return &v1.InferencePool{Spec: v1.InferencePoolSpec{TargetPortNumber: 8000}}, nil
+}
+
+func TestEndpointLifecycle(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	src := &DummySource{}
+	factory := NewEndpointFactory([]DataSource{src}, time.Millisecond)
+	pod := ToPodInfos(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod1",
+			Namespace: "default",
+		},
+		Status: corev1.PodStatus{
+			PodIP: "1.2.3.4",
+		},
+	}, []int32{8000})[0]
+
+	ep := factory.NewEndpoint(ctx, pod, &fakePoolInfo{})
+	require.NotNil(t, ep, "expected a new endpoint")
+	assert.Equal(t, "1.2.3.4", ep.GetPod().GetIPAddress())
+	assert.NotNil(t, ep.GetMetrics(), "expected initialized metrics")
+	assert.Equal(t, int32(8000), ep.GetPod().GetPort())
+
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt64(&src.callCount) > 0
+	}, time.Second, time.Millisecond, "expected the collector to invoke the data source")
+
+	assert.Nil(t, factory.NewEndpoint(ctx, pod, &fakePoolInfo{}), "duplicate endpoint should not be created")
+
+	factory.ReleaseEndpoint(ep)
+	time.Sleep(10 * time.Millisecond) // allow an in-flight collection to complete
+	count := atomic.LoadInt64(&src.callCount)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, count, atomic.LoadInt64(&src.callCount), "expected collection to stop after release")
+
+	assert.NotNil(t, factory.NewEndpoint(ctx, pod, nil), "endpoint should be re-created after release")
+}
@@ -23,6 +23,8 @@ import (
 
 // Metrics holds the latest metrics snapshot scraped from a pod.
 type Metrics struct {
+	// BaseModel is the name of the base model served by the pod, when reported by its metrics.
+	BaseModel string
 	// ActiveModels is a set of models(including LoRA adapters) that are currently cached to GPU.
 	ActiveModels  map[string]int
 	WaitingModels map[string]int
@@ -68,6 +70,7 @@ func (m *Metrics) Clone() *Metrics {
 		waitingModels[key] = value
 	}
 	return &Metrics{
+		BaseModel:               m.BaseModel,
 		ActiveModels:            activeModels,
 		WaitingModels:           waitingModels,
 		MaxActiveModels:         m.MaxActiveModels,
@@ -57,12 +57,17 @@ type client struct {
 	http.Client
 }
 
+// NewClient returns a metrics Client that uses the given http.Client.
+func NewClient(httpClient *http.Client) Client {
+	return &client{Client: *httpClient}
+}
+
 func (cl *client) Get(ctx context.Context, target *url.URL, ep datalayer.Addressable) (PrometheusMetricMap, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %v", err)
 	}
-	resp, err := defaultClient.Do(req)
+	resp, err := cl.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch metrics from %s: %w", ep.GetNamespacedName(), err)
 	}
@@ -39,6 +39,7 @@ type DataSource struct {
 	metricsScheme string                 // scheme to use in metrics URL
 	metricsPort   atomic.Pointer[string] // target port to use in metrics URL
 	metricsPath   string                 // path to use in metrics URL
+	portFromPool  bool                   // use the endpoint's target port when no metrics port is set
 
 	client     Client   // client (e.g. a wrapped http.Client) used to get metrics
 	extractors sync.Map // key: name, value: extractor
@@ -54,6 +55,7 @@ func NewDataSource(metricsScheme string, metricsPort int32, metricsPath string,
 	dataSrc := &DataSource{
 		metricsScheme: metricsScheme,
 		metricsPath:   metricsPath,
+		portFromPool:  metricsPort == 0,
 		client:        cl,
 	}
 	dataSrc.SetPort(metricsPort)
@@ -109,10 +111,17 @@ func (dataSrc *DataSource) Collect(ctx context.Context, ep datalayer.Endpoint) e
 	return nil
 }
 
+// getMetricsEndpoint returns the metrics URL of the endpoint. Unless a metrics port is configured,
+// the metrics are scraped from the endpoint's target port, so that every data parallel rank of a
+// pod is scraped separately.
 func (dataSrc *DataSource) getMetricsEndpoint(ep datalayer.Addressable) *url.URL {
+	port := *dataSrc.metricsPort.Load()
+	if dataSrc.portFromPool && ep.GetPort() != 0 {
+		port = strconv.Itoa(int(ep.GetPort()))
+	}
 	return &url.URL{
 		Scheme: dataSrc.metricsScheme,
-		Host:   net.JoinHostPort(ep.GetIPAddress(), *dataSrc.metricsPort.Load()),
+		Host:   net.JoinHostPort(ep.GetIPAddress(), port),
 		Path:   dataSrc.metricsPath,
 	}
 }
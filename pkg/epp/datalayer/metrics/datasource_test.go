@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datalayer"
+)
+
+// recordingClient records the URLs it is asked to scrape.
+type recordingClient struct {
+	mu      sync.Mutex
+	targets []string
+}
+
+func (cl *recordingClient) Get(_ context.Context, target *url.URL, _ datalayer.Addressable) (PrometheusMetricMap, error) {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	cl.targets = append(cl.targets, target.String())
+	return PrometheusMetricMap{}, nil
+}
+
+func (cl *recordingClient) lastTarget() string {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	if len(cl.targets) == 0 {
+		return ""
+	}
+	return cl.targets[len(cl.targets)-1]
+}
+
+func TestGetMetricsEndpoint(t *testing.T) {
+	tests := []struct {
+		name        string
+		metricsPort int32
+		pod         *datalayer.PodInfo
+		want        string
+	}{
+		{
+			name:        "metrics port",
+			metricsPort: 9090,
+			pod:         &datalayer.PodInfo{Address: "10.0.0.1", Port: 8000},
+			want:        "http://10.0.0.1:9090/metrics",
+		},
+		{
+			name: "target port",
+			pod:  &datalayer.PodInfo{Address: "10.0.0.1", Port: 8001},
+			want: "http://10.0.0.1:8001/metrics",
+		},
+		{
+			name: "IPv6 address",
+			pod:  &datalayer.PodInfo{Address: "fd00::1", Port: 8000},
+			want: "http://[fd00::1]:8000/metrics",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dataSrc := NewDataSource("http", test.metricsPort, "/metrics", nil)
+			assert.Equal(t, test.want, dataSrc.getMetricsEndpoint(test.pod).String())
+		})
+	}
+}
+
+func TestCollectScrapesTargetPort(t *testing.T) {
+	tests := []struct {
+		name        string
+		metricsPort int32
+		want        string
+	}{
+		{
+			name: "metrics port unset",
+			want: "http://10.0.0.1:8000/metrics",
+		},
+		{
+			name:        "metrics port set",
+			metricsPort: 9090,
+			want:        "http://10.0.0.1:9090/metrics",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			cl := &recordingClient{}
+			dataSrc := NewDataSource("http", test.metricsPort, "/metrics", cl)
+			factory := datalayer.NewEndpointFactory([]datalayer.DataSource{dataSrc}, time.Millisecond)
+			pod := &datalayer.PodInfo{
+				NamespacedName: types.NamespacedName{Namespace: "default", Name: "pod1"},
+				Address:        "10.0.0.1",
+				Port:           8000,
+			}
+			ep := factory.NewEndpoint(ctx, pod, nil)
+			require.NotNil(t, ep)
+			defer factory.ReleaseEndpoint(ep)
+
+			assert.Eventually(t, func() bool {
+				return cl.lastTarget() == test.want
+			}, time.Second, time.Millisecond, "expected the endpoint to be scraped at %s", test.want)
+		})
+	}
+}
@@ -37,28 +37,57 @@ const (
 	LoraInfoRunningAdaptersMetricName = "running_lora_adapters"
 	LoraInfoWaitingAdaptersMetricName = "waiting_lora_adapters"
 	LoraInfoMaxAdaptersMetricName     = "max_lora"
+
+	// ModelNameLabel is the label of the model server metrics holding the base model name.
+	ModelNameLabel = "model_name"
+
+	// Cache config metrics based on MSP
+	CacheConfigBlockSizeInfoMetricName = "block_size"
+	CacheConfigNumGPUBlocksMetricName  = "num_gpu_blocks"
 )
 
 // Extractor implements the metrics extraction based on the model
 // server protocol standard.
 type Extractor struct {
 	mapping *Mapping
+	// mappings holds per model server type mappings, used for endpoints that
+	// override the model server type with an annotation.
+	mappings           map[string]*Mapping
+	serverTypeOverride string // annotation key used to override the model server type
 }
 
 // NewExtractor returns a new model server protocol (MSP) metrics extractor,
 // configured with the given metrics' specifications.
 // These are mandatory metrics per the MSP specification, and are used
 // as the basis for the built-in scheduling plugins.
-func NewExtractor(queueSpec, kvusageSpec, loraSpec string) (*Extractor, error) {
-	mapping, err := NewMapping(queueSpec, kvusageSpec, loraSpec)
+func NewExtractor(queueSpec, runningSpec, kvusageSpec, loraSpec, cacheInfoSpec string) (*Extractor, error) {
+	mapping, err := NewMapping(queueSpec, runningSpec, kvusageSpec, loraSpec, cacheInfoSpec)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create extractor metrics Mapping - %w", err)
 	}
 	return &Extractor{
-		mapping: mapping,
+		mapping:  mapping,
+		mappings: map[string]*Mapping{},
 	}, nil
 }
 
+// SetModelServerMappings configures the mappings used for endpoints whose annotation
+// (under annotationKey) selects a specific model server type.
+func (ext *Extractor) SetModelServerMappings(annotationKey string, mappings map[string]*Mapping) {
+	ext.serverTypeOverride = annotationKey
+	ext.mappings = mappings
+}
+
+// mappingFor returns the Mapping to use for the given endpoint.
+func (ext *Extractor) mappingFor(ep datalayer.Endpoint) *Mapping {
+	if pod := ep.GetPod(); pod != nil && ext.serverTypeOverride != "" {
+		if mapping, ok := ext.mappings[pod.Annotations[ext.serverTypeOverride]]; ok {
+			return mapping
+		}
+	}
+	return ext.mapping
+}
+
 // Name returns the name of the metrics.Extractor.
 func (ext *Extractor) Name() string {
 	return extractorName
@@ -82,17 +111,30 @@ func (ext *Extractor) Extract(ctx context.Context, data any, ep datalayer.Endpoi
 	current := ep.GetMetrics()
 	clone := current.Clone()
 	updated := false
+	mapping := ext.mappingFor(ep)
 
-	if spec := ext.mapping.TotalQueuedRequests; spec != nil { // extract queued requests
+	if spec := mapping.TotalQueuedRequests; spec != nil { // extract queued requests
 		if metric, err := spec.getLatestMetric(families); err != nil {
 			errs = append(errs, err)
 		} else {
 			clone.WaitingQueueSize = int(extractValue(metric))
+			if model := labelValue(metric, ModelNameLabel); model != "" {
+				clone.BaseModel = model
+			}
+			updated = true
+		}
+	}
+
+	if spec := mapping.TotalRunningRequests; spec != nil { // extract running requests
+		if metric, err := spec.getLatestMetric(families); err != nil {
+			errs = append(errs, err)
+		} else {
+			clone.RunningQueueSize = int(extractValue(metric))
 			updated = true
 		}
 	}
 
-	if spec := ext.mapping.KVCacheUtilization; spec != nil { // extract KV cache usage
+	if spec := mapping.KVCacheUtilization; spec != nil { // extract KV cache usage
 		if metric, err := spec.getLatestMetric(families); err != nil {
 			errs = append(errs, err)
 		} else {
@@ -101,7 +143,7 @@ func (ext *Extractor) Extract(ctx context.Context, data any, ep datalayer.Endpoi
 		}
 	}
 
-	if spec := ext.mapping.LoraRequestInfo; spec != nil { // extract LoRA-specific metrics
+	if spec := mapping.LoraRequestInfo; spec != nil { // extract LoRA-specific metrics
 		metric, err := spec.getLatestMetric(families)
 		if err != nil {
 			errs = append(errs, err)
@@ -111,6 +153,17 @@ func (ext *Extractor) Extract(ctx context.Context, data any, ep datalayer.Endpoi
 		}
 	}
 
+	if spec := mapping.CacheConfigInfo; spec != nil { // extract KV cache capacity
+		if metric, err := spec.getLatestMetric(families); err != nil {
+			errs = append(errs, err)
+		} else if capacity, err := KVCacheMaxTokenCapacity(metric); err != nil {
+			errs = append(errs, err)
+		} else {
+			clone.KvCacheMaxTokenCapacity = capacity
+			updated = true
+		}
+	}
+
 	if updated {
 		clone.UpdateTime = time.Now()
 		ep.UpdateMetrics(clone)
@@ -145,6 +198,43 @@ func populateLoRAMetrics(clone *datalayer.Metrics, metric *dto.Metric, errs *[]e
 	}
 }
 
+// labelValue returns the value of a label of the metric, empty if it isn't set.
+func labelValue(metric *dto.Metric, name string) string {
+	for _, label := range metric.GetLabel() {
+		if label.GetName() == name {
+			return label.GetValue()
+		}
+	}
+	return ""
+}
+
+// KVCacheMaxTokenCapacity computes the KV cache capacity in tokens from a cache config metric.
+// When the metric has the vLLM cache config info labels, the capacity is the product of the number
+// of GPU blocks and the block size. Otherwise, the metric value itself is the capacity.
+func KVCacheMaxTokenCapacity(metric *dto.Metric) (int, error) {
+	var blockSize, numGPUBlocks string
+	for _, label := range metric.GetLabel() {
+		switch label.GetName() {
+		case CacheConfigBlockSizeInfoMetricName:
+			blockSize = label.GetValue()
+		case CacheConfigNumGPUBlocksMetricName:
+			numGPUBlocks = label.GetValue()
+		}
+	}
+	if blockSize == "" && numGPUBlocks == "" {
+		return int(extractValue(metric)), nil
+	}
+	size, err := strconv.Atoi(blockSize)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %q label in cache config metric: %w", CacheConfigBlockSizeInfoMetricName, err)
+	}
+	blocks, err := strconv.Atoi(numGPUBlocks)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %q label in cache config metric: %w", CacheConfigNumGPUBlocksMetricName, err)
+	}
+	return size * blocks, nil
+}
+
 // addAdapters splits a comma-separated adapter list and stores keys with default value 0.
 func addAdapters(m map[string]int, csv string) {
 	for _, name := range strings.Split(csv, ",") {
@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+func TestKVCacheMaxTokenCapacity(t *testing.T) {
+	label := func(name, value string) *dto.LabelPair {
+		return &dto.LabelPair{Name: ptr.To(name), Value: ptr.To(value)}
+	}
+
+	tests := []struct {
+		name    string
+		metric  *dto.Metric
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "vLLM cache config info labels",
+			metric: &dto.Metric{
+				Label: []*dto.LabelPair{label(CacheConfigBlockSizeInfoMetricName, "16"), label(CacheConfigNumGPUBlocksMetricName, "1000")},
+				Gauge: &dto.Gauge{Value: ptr.To(1.0)},
+			},
+			want: 16000,
+		},
+		{
+			name:   "metric value",
+			metric: &dto.Metric{Gauge: &dto.Gauge{Value: ptr.To(32768.0)}},
+			want:   32768,
+		},
+		{
+			name: "invalid block size",
+			metric: &dto.Metric{
+				Label: []*dto.LabelPair{label(CacheConfigBlockSizeInfoMetricName, "x"), label(CacheConfigNumGPUBlocksMetricName, "1000")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing number of blocks",
+			metric: &dto.Metric{
+				Label: []*dto.LabelPair{label(CacheConfigBlockSizeInfoMetricName, "16")},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := KVCacheMaxTokenCapacity(test.metric)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
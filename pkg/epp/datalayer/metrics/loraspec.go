@@ -32,8 +32,8 @@ type LoRASpec struct {
 // wraps the return in a LoRASpec.
 func parseStringToLoRASpec(spec string) (*LoRASpec, error) {
 	baseSpec, err := parseStringToSpec(spec)
-	if err != nil {
-		return nil, err
+	if err != nil || baseSpec == nil {
+		return nil, err // allow empty string to represent the nil LoRASpec
 	}
 	return &LoRASpec{
 		Spec: baseSpec,
@@ -18,22 +18,41 @@ package datalayer
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
-// Addressable supports getting an IP address and a namespaced name.
+// TargetPortsAnnotation overrides the target ports of the pool for the endpoints of a pod, as a
+// comma separated list of ports. The standalone mode sets it on the pods standing for the endpoints
+// configured with their own ports.
+const TargetPortsAnnotation = "inference.networking.k8s.io/target-ports"
+
+// Addressable supports getting an IP address, a port and a namespaced name.
 type Addressable interface {
 	GetIPAddress() string
+	GetPort() int32
 	GetNamespacedName() types.NamespacedName
 }
 
 // PodInfo represents the relevant Kubernetes Pod state of an inference server.
+//
+// A pod serving several data parallel ranks, one per target port of the pool, has one PodInfo per
+// rank. The NamespacedName of a rank is the one of its pod, with the port of the rank appended to
+// the name, so that the ranks are scheduled, scraped and indexed as distinct endpoints.
 type PodInfo struct {
 	NamespacedName types.NamespacedName
 	Address        string
-	Labels         map[string]string
+	// Port is the target port of the endpoint. It is zero when the pool's ports aren't known.
+	Port   int32
+	Labels map[string]string
+	// Annotations holds the pod's annotations. It is nil when the pod has none.
+	Annotations map[string]string
+	// PodName is the name of the pod of a data parallel rank. It is empty when the endpoint is
+	// the pod itself.
+	PodName string
 }
 
 // ToPodInfo converts a Kubernetes API Pod to its internal representation.
@@ -47,9 +66,58 @@ func ToPodInfo(pod *corev1.Pod) *PodInfo {
 			Name:      pod.Name,
 			Namespace: pod.Namespace,
 		},
-		Address: pod.Status.PodIP,
-		Labels:  labels,
+		Address:     pod.Status.PodIP,
+		Labels:      labels,
+		Annotations: cloneAnnotations(pod.GetAnnotations()),
+	}
+}
+
+// ToPodInfos converts a Kubernetes API Pod to the internal representations of its endpoints, one
+// per target port. The endpoints of a pod with several target ports are its data parallel ranks,
+// named "<pod>:<port>". The ports of the TargetPortsAnnotation of the pod, if
+// any, override the given ports.
+func ToPodInfos(pod *corev1.Pod, ports []int32) []*PodInfo {
+	ports = podTargetPorts(pod, ports)
+	if len(ports) <= 1 {
+		podInfo := ToPodInfo(pod)
+		if len(ports) == 1 {
+			podInfo.Port = ports[0]
+		}
+		return []*PodInfo{podInfo}
+	}
+	podInfos := make([]*PodInfo, len(ports))
+	for rank, port := range ports {
+		podInfo := ToPodInfo(pod)
+		podInfo.NamespacedName.Name = RankName(pod.Name, port)
+		podInfo.Port = port
+		podInfo.PodName = pod.Name
+		podInfos[rank] = podInfo
 	}
+	return podInfos
+}
+
+// podTargetPorts returns the ports of the TargetPortsAnnotation of the pod, or the given ports if
+// the pod has no valid annotation.
+func podTargetPorts(pod *corev1.Pod, ports []int32) []int32 {
+	value, ok := pod.GetAnnotations()[TargetPortsAnnotation]
+	if !ok {
+		return ports
+	}
+	var podPorts []int32
+	for _, field := range strings.Split(value, ",") {
+		port, err := strconv.ParseInt(strings.TrimSpace(field), 10, 32)
+		if err != nil || port < 1 || port > 65535 {
+			return ports
+		}
+		podPorts = append(podPorts, int32(port))
+	}
+	return podPorts
+}
+
+// RankName returns the name of the endpoint of the data parallel rank of a pod listening on the
+// given port. The name can't collide with the name of a pod, which can't contain a colon.
+func RankName(podName string, port int32) string {
+	return podName + ":" + strconv.Itoa(int(port))
 }
 
 // String returns a string representation of the pod.
@@ -75,17 +143,45 @@ func (p *PodInfo) Clone() *PodInfo {
 			Name:      p.NamespacedName.Name,
 			Namespace: p.NamespacedName.Namespace,
 		},
-		Address: p.Address,
-		Labels:  clonedLabels,
+		Address:     p.Address,
+		Port:        p.Port,
+		Labels:      clonedLabels,
+		Annotations: cloneAnnotations(p.Annotations),
+		PodName:     p.PodName,
 	}
 }
 
+// cloneAnnotations copies the annotations map, returning nil for empty input.
+func cloneAnnotations(annotations map[string]string) map[string]string {
+	if len(annotations) == 0 {
+		return nil
+	}
+	cloned := make(map[string]string, len(annotations))
+	for key, value := range annotations {
+		cloned[key] = value
+	}
+	return cloned
+}
+
 // GetNamespacedName gets the namespace name of the Pod.
 func (p *PodInfo) GetNamespacedName() types.NamespacedName {
 	return p.NamespacedName
 }
 
+// GetPodName returns the name of the Pod serving the endpoint.
+func (p *PodInfo) GetPodName() string {
+	if p.PodName != "" {
+		return p.PodName
+	}
+	return p.NamespacedName.Name
+}
+
 // GetIPAddress returns the Pod's IP address.
 func (p *PodInfo) GetIPAddress() string {
 	return p.Address
 }
+
+// GetPort returns the endpoint's target port, or zero if it isn't known.
+func (p *PodInfo) GetPort() int32 {
+	return p.Port
+}
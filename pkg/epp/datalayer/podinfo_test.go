@@ -81,3 +81,95 @@ func TestPodInfoString(t *testing.T) {
 	assert.Contains(t, s, namespace)
 	assert.Contains(t, s, podip)
 }
+
+func TestToPodInfos(t *testing.T) {
+	tests := []struct {
+		name  string
+		ports []int32
+		want  []*PodInfo
+	}{
+		{
+			name:  "unknown ports",
+			ports: nil,
+			want:  []*PodInfo{expected},
+		},
+		{
+			name:  "single port",
+			ports: []int32{8000},
+			want: []*PodInfo{{
+				NamespacedName: types.NamespacedName{Name: name, Namespace: namespace},
+				Address:        podip,
+				Port:           8000,
+				Labels:         labels,
+			}},
+		},
+		{
+			name:  "data parallel ranks",
+			ports: []int32{8000, 8001},
+			want: []*PodInfo{
+				{
+					NamespacedName: types.NamespacedName{Name: name + ":8000", Namespace: namespace},
+					Address:        podip,
+					Port:           8000,
+					Labels:         labels,
+					PodName:        name,
+				},
+				{
+					NamespacedName: types.NamespacedName{Name: name + ":8001", Namespace: namespace},
+					Address:        podip,
+					Port:           8001,
+					Labels:         labels,
+					PodName:        name,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ToPodInfos(pod, test.ports)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("Unexpected output (-want +got): %v", diff)
+			}
+			for _, podInfo := range got {
+				assert.Equal(t, name, podInfo.GetPodName())
+			}
+		})
+	}
+}
+
+func TestToPodInfosTargetPortsAnnotation(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation string
+		want       []int32
+	}{
+		{
+			name:       "single port",
+			annotation: "8001",
+			want:       []int32{8001},
+		},
+		{
+			name:       "data parallel ranks",
+			annotation: "8001, 8002",
+			want:       []int32{8001, 8002},
+		},
+		{
+			name:       "invalid port falls back to the pool's ports",
+			annotation: "8001,70000",
+			want:       []int32{8000},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			annotated := pod.DeepCopy()
+			annotated.Annotations = map[string]string{TargetPortsAnnotation: test.annotation}
+			got := []int32{}
+			for _, podInfo := range ToPodInfos(annotated, []int32{8000}) {
+				got = append(got, podInfo.Port)
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
@@ -0,0 +1,389 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datalayer
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	DefaultScrapeInterval   = 50 * time.Millisecond
+	DefaultScrapeTimeout    = 5 * time.Second
+	DefaultScrapeWorkers    = 64
+	DefaultScrapeJitter     = 0.1
+	DefaultScrapeMaxBackoff = 5 * time.Second
+
+	// Reasons reported on the scrape error and skip metrics.
+	scrapeErrorFetch       = "fetch"
+	scrapeErrorPartial     = "partial"
+	scrapeSkippedInFlight  = "in_flight"
+	scrapeSkippedWorkerCap = "workers_busy"
+	scrapeSkippedFresh     = "fresh"
+)
+
+// ErrScrapeNotReady is returned by a ScrapeFunc when the endpoint can't be scraped yet, e.g. until
+// the InferencePool is synced. Such scrapes are neither recorded nor backed off.
+var ErrScrapeNotReady = errors.New("endpoint is not ready to be scraped")
+
+// ScrapeFunc refreshes the data of an endpoint. It returns whether the data was updated, which may
+// be the case even if an error is returned, e.g. on a partial scrape.
+type ScrapeFunc func(ctx context.Context) (bool, error)
+
+// ScrapeConfig configures how model server metrics are scraped.
+type ScrapeConfig struct {
+	// Interval is the nominal time between two scrapes of the same pod.
+	Interval time.Duration
+	// Timeout bounds the duration of a single scrape.
+	Timeout time.Duration
+	// Workers is the maximum number of concurrent scrapes.
+	Workers int
+	// Jitter is the fraction of the interval by which each scrape is randomly shifted, spreading
+	// the scrapes of different pods over time. Must be in [0, 1].
+	Jitter float64
+	// MaxBackoff caps the exponential backoff applied to pods whose scrapes keep failing.
+	// Backoff is disabled if MaxBackoff is not larger than Interval.
+	MaxBackoff time.Duration
+	// MaxInterval bounds the time between two scrapes of a pod whose metrics are kept fresh by
+	// in-band load reports. Scrapes of such a pod are skipped while its metrics were updated
+	// within the last Interval, until MaxInterval elapsed since its last scrape. Zero disables
+	// skipping.
+	MaxInterval time.Duration
+	// AllowConcurrent allows a pod to be scraped while its previous scrape is still running. By
+	// default, such a scrape is skipped, so that a slow pod holds at most one worker.
+	AllowConcurrent bool
+}
+
+// DefaultScrapeConfig returns the default ScrapeConfig for the given scrape interval.
+func DefaultScrapeConfig(interval time.Duration) ScrapeConfig {
+	return ScrapeConfig{
+		Interval:   interval,
+		Timeout:    DefaultScrapeTimeout,
+		Workers:    DefaultScrapeWorkers,
+		Jitter:     DefaultScrapeJitter,
+		MaxBackoff: DefaultScrapeMaxBackoff,
+	}
+}
+
+// ScrapeObserver is notified of the outcome of each model server metrics scrape. Partial scrapes are
+// reported as successful.
+type ScrapeObserver interface {
+	ObserveScrape(pod types.NamespacedName, err error)
+}
+
+// scrapeTarget holds the scheduling state of a single endpoint. All fields but the immutable
+// ep, scrape and logger are guarded by the scheduler mutex.
+type scrapeTarget struct {
+	ep         Endpoint
+	scrape     ScrapeFunc
+	logger     logr.Logger
+	pod        types.NamespacedName
+	nextDue    time.Time
+	failures   int
+	lastScrape time.Time
+	inFlight   int
+	removed    bool
+	index      int // index in the scheduler heap, -1 when not queued
+}
+
+// targetHeap is a min-heap of scrape targets ordered by their next due time.
+type targetHeap []*scrapeTarget
+
+func (h targetHeap) Len() int           { return len(h) }
+func (h targetHeap) Less(i, j int) bool { return h[i].nextDue.Before(h[j].nextDue) }
+func (h targetHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *targetHeap) Push(x any) {
+	t := x.(*scrapeTarget)
+	t.index = len(*h)
+	*h = append(*h, t)
+}
+
+func (h *targetHeap) Pop() any {
+	old := *h
+	n := len(old)
+	t := old[n-1]
+	old[n-1] = nil
+	t.index = -1
+	*h = old[:n-1]
+	return t
+}
+
+// ScrapeScheduler scrapes all registered endpoints from a single scheduling loop feeding a
+// bounded pool of workers. Scrapes are spread over time with a random phase and per-scrape
+// jitter, failing endpoints are backed off exponentially, and unless configured otherwise, an
+// endpoint whose previous scrape is still running is skipped rather than scraped concurrently.
+// It is shared by the legacy metrics refresher and the pluggable data layer.
+type ScrapeScheduler struct {
+	config ScrapeConfig
+
+	mu       sync.Mutex
+	targets  map[Endpoint]*scrapeTarget
+	queue    targetHeap
+	rand     *rand.Rand
+	observer ScrapeObserver
+
+	jobs      chan *scrapeTarget
+	wake      chan struct{}
+	startOnce sync.Once
+}
+
+// NewScrapeScheduler returns a new ScrapeScheduler. Unset fields of the configuration take their
+// default values.
+func NewScrapeScheduler(config ScrapeConfig) *ScrapeScheduler {
+	if config.Interval <= 0 {
+		config.Interval = DefaultScrapeInterval
+	}
+	if config.Workers <= 0 {
+		config.Workers = DefaultScrapeWorkers
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultScrapeTimeout
+	}
+	config.Jitter = min(max(config.Jitter, 0), 1)
+	return &ScrapeScheduler{
+		config:  config,
+		targets: make(map[Endpoint]*scrapeTarget),
+		rand:    rand.New(rand.NewSource(time.Now().UnixNano())),
+		jobs:    make(chan *scrapeTarget, config.Workers),
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// Start launches the scheduling loop and the workers. It is safe to call multiple times, only
+// the first call has an effect.
+func (s *ScrapeScheduler) Start(ctx context.Context) {
+	s.startOnce.Do(func() {
+		for range s.config.Workers {
+			go s.worker(ctx)
+		}
+		go s.run(ctx)
+	})
+}
+
+// Register adds an endpoint to the set of scraped endpoints, refreshed by the scrape function.
+// Its first scrape happens at a random point within the first interval.
+func (s *ScrapeScheduler) Register(ep Endpoint, logger logr.Logger, scrape ScrapeFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.targets[ep]; ok {
+		return
+	}
+	t := &scrapeTarget{
+		ep:      ep,
+		scrape:  scrape,
+		logger:  logger,
+		pod:     ep.GetPod().NamespacedName,
+		nextDue: time.Now().Add(time.Duration(s.rand.Int63n(int64(max(s.config.Interval, 1))))),
+	}
+	s.targets[ep] = t
+	heap.Push(&s.queue, t)
+	s.notify()
+}
+
+// Unregister stops scraping an endpoint and removes its scrape metrics. A scrape already in
+// flight is allowed to complete.
+func (s *ScrapeScheduler) Unregister(ep Endpoint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.targets[ep]
+	if !ok {
+		return
+	}
+	t.removed = true
+	delete(s.targets, ep)
+	if t.index >= 0 {
+		heap.Remove(&s.queue, t.index)
+	}
+	metrics.DeleteModelServerScrapeMetrics(t.pod)
+}
+
+// SetObserver sets the observer notified of the outcome of each scrape.
+func (s *ScrapeScheduler) SetObserver(observer ScrapeObserver) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.observer = observer
+}
+
+func (s *ScrapeScheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *ScrapeScheduler) run(ctx context.Context) {
+	timer := time.NewTimer(s.config.Interval)
+	defer timer.Stop()
+	for {
+		s.mu.Lock()
+		now := time.Now()
+		for s.queue.Len() > 0 && !s.queue[0].nextDue.After(now) {
+			s.dispatchLocked(s.queue[0], now)
+		}
+		wait := s.config.Interval
+		if s.queue.Len() > 0 {
+			wait = s.queue[0].nextDue.Sub(now)
+		}
+		s.mu.Unlock()
+
+		timer.Reset(wait)
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-timer.C:
+		}
+	}
+}
+
+// dispatchLocked hands a due target to the workers, or records a skip if it can't be scraped
+// right now, and schedules its next scrape. Must be called with the mutex held.
+func (s *ScrapeScheduler) dispatchLocked(t *scrapeTarget, now time.Time) {
+	switch {
+	case t.inFlight > 0 && !s.config.AllowConcurrent:
+		metrics.RecordModelServerScrapeSkipped(t.pod, scrapeSkippedInFlight)
+	case s.freshLocked(t, now):
+		metrics.RecordModelServerScrapeSkipped(t.pod, scrapeSkippedFresh)
+	default:
+		select {
+		case s.jobs <- t:
+			t.inFlight++
+		default:
+			metrics.RecordModelServerScrapeSkipped(t.pod, scrapeSkippedWorkerCap)
+		}
+	}
+	t.nextDue = now.Add(s.jitteredLocked(s.config.Interval))
+	heap.Fix(&s.queue, t.index)
+}
+
+// freshLocked returns true if the metrics of the target were updated by other means than a
+// scrape within the last interval, and the target doesn't need a full scrape yet.
+func (s *ScrapeScheduler) freshLocked(t *scrapeTarget, now time.Time) bool {
+	if s.config.MaxInterval <= 0 || now.Sub(t.lastScrape) >= s.config.MaxInterval {
+		return false
+	}
+	updateTime := t.ep.GetMetrics().UpdateTime
+	return updateTime.After(t.lastScrape) && now.Sub(updateTime) < s.config.Interval
+}
+
+func (s *ScrapeScheduler) worker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case t := <-s.jobs:
+			s.runScrape(ctx, t)
+		}
+	}
+}
+
+func (s *ScrapeScheduler) runScrape(ctx context.Context, t *scrapeTarget) {
+	start := time.Now()
+	scrapeCtx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	updated, err := t.scrape(scrapeCtx)
+	cancel()
+	duration := time.Since(start)
+
+	observer, report := s.complete(t, updated, err, duration)
+	if report && observer != nil {
+		if updated {
+			// A partial scrape still reached the model server.
+			err = nil
+		}
+		observer.ObserveScrape(t.pod, err)
+	}
+}
+
+// complete updates the scheduling state of the target with the outcome of its scrape. It returns
+// the scrape observer, and whether the outcome should be reported to it.
+func (s *ScrapeScheduler) complete(t *scrapeTarget, updated bool, err error, duration time.Duration) (ScrapeObserver, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t.inFlight--
+	if t.removed || errors.Is(err, ErrScrapeNotReady) {
+		return nil, false
+	}
+	if updated {
+		t.lastScrape = time.Now()
+	}
+	metrics.RecordModelServerScrapeLatency(t.pod, duration)
+	switch {
+	case err == nil:
+		if t.failures > 0 {
+			t.logger.V(logutil.DEFAULT).Info("Model server metrics scrape recovered", "failures", t.failures)
+		}
+		t.failures = 0
+	case updated:
+		metrics.RecordModelServerScrapeError(t.pod, scrapeErrorPartial)
+		t.failures = 0
+	default:
+		metrics.RecordModelServerScrapeError(t.pod, scrapeErrorFetch)
+		t.failures++
+		backoff := backoffInterval(s.config.Interval, s.config.MaxBackoff, t.failures)
+		if t.failures == 1 {
+			t.logger.V(logutil.DEFAULT).Info("Failed to scrape model server metrics, backing off", "err", err, "backoff", backoff)
+		} else {
+			t.logger.V(logutil.DEBUG).Info("Failed to scrape model server metrics", "err", err, "failures", t.failures, "backoff", backoff)
+		}
+		if next := time.Now().Add(s.jitteredLocked(backoff)); next.After(t.nextDue) {
+			t.nextDue = next
+			heap.Fix(&s.queue, t.index)
+			s.notify()
+		}
+	}
+	return s.observer, true
+}
+
+// jitteredLocked randomly shifts the duration by up to half the configured jitter fraction in
+// either direction. Must be called with the mutex held.
+func (s *ScrapeScheduler) jitteredLocked(d time.Duration) time.Duration {
+	if s.config.Jitter == 0 {
+		return d
+	}
+	return time.Duration(float64(d) * (1 + s.config.Jitter*(s.rand.Float64()-0.5)))
+}
+
+// backoffInterval returns the interval before the next scrape of a pod after the given number
+// of consecutive failures: the scrape interval doubled on each failure, capped at maxBackoff.
+func backoffInterval(interval, maxBackoff time.Duration, failures int) time.Duration {
+	if maxBackoff <= interval {
+		return interval
+	}
+	backoff := interval
+	for range failures {
+		backoff *= 2
+		if backoff >= maxBackoff || backoff <= 0 {
+			return maxBackoff
+		}
+	}
+	return backoff
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datalayer
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestBackoffInterval(t *testing.T) {
+	tests := []struct {
+		name       string
+		interval   time.Duration
+		maxBackoff time.Duration
+		failures   int
+		want       time.Duration
+	}{
+		{name: "no failures", interval: time.Second, maxBackoff: 10 * time.Second, failures: 0, want: time.Second},
+		{name: "one failure", interval: time.Second, maxBackoff: 10 * time.Second, failures: 1, want: 2 * time.Second},
+		{name: "three failures", interval: time.Second, maxBackoff: 10 * time.Second, failures: 3, want: 8 * time.Second},
+		{name: "capped", interval: time.Second, maxBackoff: 10 * time.Second, failures: 4, want: 10 * time.Second},
+		{name: "many failures", interval: time.Second, maxBackoff: 10 * time.Second, failures: 100, want: 10 * time.Second},
+		{name: "backoff disabled", interval: time.Second, maxBackoff: time.Second, failures: 3, want: time.Second},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, backoffInterval(test.interval, test.maxBackoff, test.failures))
+		})
+	}
+}
+
+func TestScrapeSchedulerInFlightScrapes(t *testing.T) {
+	tests := []struct {
+		name            string
+		allowConcurrent bool
+		wantConcurrent  bool
+	}{
+		{
+			name: "skipped by default",
+		},
+		{
+			name:            "concurrent when allowed",
+			allowConcurrent: true,
+			wantConcurrent:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var inFlight, maxConcurrent atomic.Int64
+			scrape := func(ctx context.Context) (bool, error) {
+				current := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					prev := maxConcurrent.Load()
+					if current <= prev || maxConcurrent.CompareAndSwap(prev, current) {
+						break
+					}
+				}
+				select {
+				case <-time.After(50 * time.Millisecond):
+				case <-ctx.Done():
+				}
+				return true, nil
+			}
+
+			scheduler := NewScrapeScheduler(ScrapeConfig{
+				Interval:        time.Millisecond,
+				Timeout:         time.Second,
+				Workers:         4,
+				AllowConcurrent: test.allowConcurrent,
+			})
+			scheduler.Start(ctx)
+			ep := NewEndpoint()
+			ep.UpdatePod(&PodInfo{NamespacedName: types.NamespacedName{Namespace: "default", Name: "pod1"}})
+			ep.UpdateMetrics(NewMetrics())
+			scheduler.Register(ep, logr.Discard(), scrape)
+
+			time.Sleep(100 * time.Millisecond)
+			if test.wantConcurrent {
+				assert.Greater(t, maxConcurrent.Load(), int64(1), "expected concurrent scrapes of the pod")
+			} else {
+				assert.Equal(t, int64(1), maxConcurrent.Load(), "expected a single scrape of the pod at a time")
+			}
+		})
+	}
+}
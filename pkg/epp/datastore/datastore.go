@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"sync"
 
 	corev1 "k8s.io/api/core/v1"
@@ -31,7 +32,9 @@ import (
 
 	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
 	"sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/common"
 	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datalayer"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 	podutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/pod"
 )
@@ -57,22 +60,32 @@ type Datastore interface {
 	ObjectiveDelete(namespacedName types.NamespacedName)
 	ObjectiveGetAll() []*v1alpha2.InferenceObjective
 
-	// PodList lists pods matching the given predicate.
+	// PodList lists pods matching the given predicate. A pod with several target ports is listed
+	// once per data parallel rank.
 	PodList(predicate func(backendmetrics.PodMetrics) bool) []backendmetrics.PodMetrics
-	PodUpdateOrAddIfNotExist(pod *corev1.Pod) bool
+	// PodGet returns the endpoint of the given name, or nil if it isn't in the datastore.
+	PodGet(namespacedName types.NamespacedName) backendmetrics.PodMetrics
+	// PodUpdateOrAddIfNotExist adds or updates the endpoints of the pod, one per target port of the
+	// pool. It returns the names of the endpoints which were added, which is empty if all of them
+	// already existed. A pod whose target ports were extended has only its new endpoints added.
+	PodUpdateOrAddIfNotExist(pod *corev1.Pod) []types.NamespacedName
+	// PodDelete deletes the endpoints of the pod.
 	PodDelete(namespacedName types.NamespacedName)
 
 	// Clears the store state, happens when the pool gets deleted.
 	Clear()
 }
 
-func NewDatastore(parentCtx context.Context, pmf *backendmetrics.PodMetricsFactory) Datastore {
+// NewDatastore returns a new Datastore. The endpoint factory is used to create (and release)
+// the endpoints of pods in the pool, including the collection of their metrics and attributes.
+func NewDatastore(parentCtx context.Context, epf datalayer.EndpointFactory) Datastore {
 	store := &datastore{
 		parentCtx:           parentCtx,
 		poolAndObjectivesMu: sync.RWMutex{},
 		objectives:          make(map[string]*v1alpha2.InferenceObjective),
 		pods:                &sync.Map{},
-		pmf:                 pmf,
+		podEndpoints:        make(map[types.NamespacedName]map[types.NamespacedName]bool),
+		epf:                 epf,
 	}
 	return store
 }
@@ -85,9 +98,13 @@ type datastore struct {
 	pool                *v1.InferencePool
 	// key: InferenceObjective.Spec.ModelName, value: *InferenceObjective
 	objectives map[string]*v1alpha2.InferenceObjective
-	// key: types.NamespacedName, value: backendmetrics.PodMetrics
+	// key: types.NamespacedName of the endpoint, value: backendmetrics.PodMetrics
 	pods *sync.Map
-	pmf  *backendmetrics.PodMetricsFactory
+	// podEndpointsMu is used to synchronize access to the podEndpoints index.
+	podEndpointsMu sync.Mutex
+	// key: types.NamespacedName of the pod, value: the set of the names of its endpoints
+	podEndpoints map[types.NamespacedName]map[types.NamespacedName]bool
+	epf          datalayer.EndpointFactory
 }
 
 func (ds *datastore) Clear() {
@@ -97,10 +114,13 @@ func (ds *datastore) Clear() {
 	ds.objectives = make(map[string]*v1alpha2.InferenceObjective)
 	// stop all pods go routines before clearing the pods map.
 	ds.pods.Range(func(_, v any) bool {
-		v.(backendmetrics.PodMetrics).StopRefreshLoop()
+		ds.epf.ReleaseEndpoint(v.(backendmetrics.PodMetrics))
 		return true
 	})
 	ds.pods.Clear()
+	ds.podEndpointsMu.Lock()
+	ds.podEndpoints = make(map[types.NamespacedName]map[types.NamespacedName]bool)
+	ds.podEndpointsMu.Unlock()
 }
 
 // /// InferencePool APIs ///
@@ -115,14 +135,18 @@ func (ds *datastore) PoolSet(ctx context.Context, reader client.Reader, pool *v1
 
 	oldPool := ds.pool
 	ds.pool = pool
-	if oldPool == nil || !reflect.DeepEqual(pool.Spec.Selector, oldPool.Spec.Selector) {
-		logger.V(logutil.DEFAULT).Info("Updating inference pool endpoints", "selector", pool.Spec.Selector)
-		// A full resync is required to address two cases:
+	if oldPool == nil || !reflect.DeepEqual(pool.Spec.Selector, oldPool.Spec.Selector) ||
+		!slices.Equal(common.TargetPorts(&pool.Spec), common.TargetPorts(&oldPool.Spec)) {
+		logger.V(logutil.DEFAULT).Info("Updating inference pool endpoints", "selector", pool.Spec.Selector,
+			"targetPorts", common.TargetPorts(&pool.Spec))
+		// A full resync is required to address three cases:
 		// 1) At startup, the pod events may get processed before the pool is synced with the datastore,
 		//    and hence they will not be added to the store since pool selector is not known yet
 		// 2) If the selector on the pool was updated, then we will not get any pod events, and so we need
 		//    to resync the whole pool: remove pods in the store that don't match the new selector and add
 		//    the ones that may have existed already to the store.
+		// 3) If the target ports of the pool were updated, then the endpoints of the pods are replaced
+		//    by one endpoint per new port.
 		if err := ds.podResyncAll(ctx, reader); err != nil {
 			return fmt.Errorf("failed to update pods according to the pool selector - %w", err)
 		}
@@ -207,29 +231,91 @@ func (ds *datastore) PodList(predicate func(backendmetrics.PodMetrics) bool) []b
 	return res
 }
 
-func (ds *datastore) PodUpdateOrAddIfNotExist(pod *corev1.Pod) bool {
-	namespacedName := types.NamespacedName{
-		Name:      pod.Name,
-		Namespace: pod.Namespace,
+func (ds *datastore) PodGet(namespacedName types.NamespacedName) backendmetrics.PodMetrics {
+	if v, ok := ds.pods.Load(namespacedName); ok {
+		return v.(backendmetrics.PodMetrics)
 	}
-	var pm backendmetrics.PodMetrics
-	existing, ok := ds.pods.Load(namespacedName)
-	if !ok {
-		pm = ds.pmf.NewPodMetrics(ds.parentCtx, pod, ds)
-		ds.pods.Store(namespacedName, pm)
-	} else {
-		pm = existing.(backendmetrics.PodMetrics)
+	return nil
+}
+
+func (ds *datastore) PodUpdateOrAddIfNotExist(pod *corev1.Pod) []types.NamespacedName {
+	var ports []int32
+	if pool, err := ds.PoolGet(); err == nil {
+		ports = common.TargetPorts(&pool.Spec)
+	}
+	return ds.podUpdateOrAddIfNotExist(pod, ports)
+}
+
+// podUpdateOrAddIfNotExist adds or updates the endpoints of the pod for the given target ports. It
+// doesn't lock the pool, since it's called by the resync of the pods while the pool is locked.
+func (ds *datastore) podUpdateOrAddIfNotExist(pod *corev1.Pod, ports []int32) []types.NamespacedName {
+	var added []types.NamespacedName
+	for _, podInfo := range datalayer.ToPodInfos(pod, ports) {
+		namespacedName := podInfo.GetNamespacedName()
+		existing, ok := ds.pods.Load(namespacedName)
+		if !ok {
+			pm := ds.epf.NewEndpoint(ds.parentCtx, podInfo, ds)
+			if pm == nil {
+				continue
+			}
+			ds.pods.Store(namespacedName, pm)
+			ds.indexEndpoint(podInfo)
+			added = append(added, namespacedName)
+			continue
+		}
+		// Update pod properties if anything changed.
+		existing.(backendmetrics.PodMetrics).UpdatePod(podInfo)
 	}
-	// Update pod properties if anything changed.
-	pm.UpdatePod(pod)
-	return ok
+	return added
 }
 
+// PodDelete deletes the endpoints of the pod, including all its data parallel ranks.
 func (ds *datastore) PodDelete(namespacedName types.NamespacedName) {
+	ds.podEndpointsMu.Lock()
+	endpoints := ds.podEndpoints[namespacedName]
+	delete(ds.podEndpoints, namespacedName)
+	ds.podEndpointsMu.Unlock()
+
+	for endpoint := range endpoints {
+		if v, ok := ds.pods.LoadAndDelete(endpoint); ok {
+			ds.epf.ReleaseEndpoint(v.(backendmetrics.PodMetrics))
+		}
+	}
+}
+
+// podDeleteEndpoint deletes a single endpoint and releases its resources.
+func (ds *datastore) podDeleteEndpoint(namespacedName types.NamespacedName) {
 	v, ok := ds.pods.LoadAndDelete(namespacedName)
 	if ok {
 		pmr := v.(backendmetrics.PodMetrics)
-		pmr.StopRefreshLoop()
+		ds.unindexEndpoint(pmr.GetPod())
+		ds.epf.ReleaseEndpoint(pmr)
+	}
+}
+
+// indexEndpoint adds the endpoint to the endpoints of its pod.
+func (ds *datastore) indexEndpoint(podInfo *datalayer.PodInfo) {
+	pod := types.NamespacedName{Namespace: podInfo.NamespacedName.Namespace, Name: podInfo.GetPodName()}
+	ds.podEndpointsMu.Lock()
+	defer ds.podEndpointsMu.Unlock()
+	endpoints, ok := ds.podEndpoints[pod]
+	if !ok {
+		endpoints = make(map[types.NamespacedName]bool)
+		ds.podEndpoints[pod] = endpoints
+	}
+	endpoints[podInfo.NamespacedName] = true
+}
+
+// unindexEndpoint removes the endpoint from the endpoints of its pod.
+func (ds *datastore) unindexEndpoint(podInfo *datalayer.PodInfo) {
+	pod := types.NamespacedName{Namespace: podInfo.NamespacedName.Namespace, Name: podInfo.GetPodName()}
+	ds.podEndpointsMu.Lock()
+	defer ds.podEndpointsMu.Unlock()
+	if endpoints, ok := ds.podEndpoints[pod]; ok {
+		delete(endpoints, podInfo.NamespacedName)
+		if len(endpoints) == 0 {
+			delete(ds.podEndpoints, pod)
+		}
 	}
 }
 
@@ -243,26 +329,29 @@ func (ds *datastore) podResyncAll(ctx context.Context, reader client.Reader) err
 		return fmt.Errorf("failed to list pods - %w", err)
 	}
 
-	activePods := make(map[string]bool)
+	ports := common.TargetPorts(&ds.pool.Spec)
+	activeEndpoints := make(map[types.NamespacedName]bool)
 	for _, pod := range podList.Items {
 		if !podutil.IsPodReady(&pod) {
 			continue
 		}
 		namespacedName := types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}
-		activePods[pod.Name] = true
-		if ds.PodUpdateOrAddIfNotExist(&pod) {
-			logger.V(logutil.DEFAULT).Info("Pod added", "name", namespacedName)
+		for _, podInfo := range datalayer.ToPodInfos(&pod, ports) {
+			activeEndpoints[podInfo.GetNamespacedName()] = true
+		}
+		if added := ds.podUpdateOrAddIfNotExist(&pod, ports); len(added) > 0 {
+			logger.V(logutil.DEFAULT).Info("Pod added", "name", namespacedName, "endpoints", added)
 		} else {
 			logger.V(logutil.DEFAULT).Info("Pod already exists", "name", namespacedName)
 		}
 	}
 
-	// Remove pods that don't belong to the pool or not ready any more.
+	// Remove the endpoints of pods that don't belong to the pool or not ready any more, and the
+	// endpoints of target ports which were removed from the pool.
 	ds.pods.Range(func(k, v any) bool {
-		pm := v.(backendmetrics.PodMetrics)
-		if exist := activePods[pm.GetPod().NamespacedName.Name]; !exist {
-			logger.V(logutil.VERBOSE).Info("Removing pod", "pod", pm.GetPod())
-			ds.PodDelete(pm.GetPod().NamespacedName)
+		if namespacedName := k.(types.NamespacedName); !activeEndpoints[namespacedName] {
+			logger.V(logutil.VERBOSE).Info("Removing pod", "pod", v.(backendmetrics.PodMetrics).GetPod())
+			ds.podDeleteEndpoint(namespacedName)
 		}
 		return true
 	})
@@ -35,6 +35,7 @@ import (
 	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
 	"sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
 	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datalayer"
 	testutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/testing"
 )
 
@@ -429,3 +430,95 @@ func TestPods(t *testing.T) {
 		})
 	}
 }
+
+func TestPodGet(t *testing.T) {
+	pmf := backendmetrics.NewPodMetricsFactory(&backendmetrics.FakePodMetricsClient{}, time.Second)
+	ds := NewDatastore(t.Context(), pmf)
+	ds.PodUpdateOrAddIfNotExist(pod1)
+
+	pm := ds.PodGet(pod1NamespacedName)
+	if assert.NotNil(t, pm) {
+		assert.Equal(t, pod1NamespacedName, pm.GetPod().NamespacedName)
+	}
+	assert.Nil(t, ds.PodGet(pod2NamespacedName))
+
+	ds.PodDelete(pod1NamespacedName)
+	assert.Nil(t, ds.PodGet(pod1NamespacedName))
+}
+
+func TestPodRanks(t *testing.T) {
+	selector := map[string]string{"app": "vllm"}
+	readyPod := func(name, ip string) *corev1.Pod {
+		return testutil.MakePod(name).Namespace("default").Labels(selector).IP(ip).ReadyCondition().ObjRef()
+	}
+	endpoint := func(name, ip string, port int32, podName string) *backendmetrics.FakePodMetrics {
+		return &backendmetrics.FakePodMetrics{Pod: &datalayer.PodInfo{
+			NamespacedName: types.NamespacedName{Name: name, Namespace: "default"},
+			Address:        ip,
+			Port:           port,
+			Labels:         selector,
+			PodName:        podName,
+		}}
+	}
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(readyPod("pod1", "10.0.0.1")).Build()
+	pmf := backendmetrics.NewPodMetricsFactory(&backendmetrics.FakePodMetricsClient{}, time.Second)
+	ds := NewDatastore(t.Context(), pmf)
+
+	assertEndpoints := func(want ...*backendmetrics.FakePodMetrics) {
+		t.Helper()
+		var got []*datalayer.PodInfo
+		for _, pm := range ds.PodList(backendmetrics.AllPodsPredicate) {
+			got = append(got, pm.GetPod())
+		}
+		var wantPods []*datalayer.PodInfo
+		for _, pm := range want {
+			wantPods = append(wantPods, pm.GetPod())
+		}
+		if diff := cmp.Diff(wantPods, got, cmpopts.SortSlices(func(a, b *datalayer.PodInfo) bool {
+			return a.NamespacedName.Name < b.NamespacedName.Name
+		})); diff != "" {
+			t.Errorf("Unexpected endpoints (-want +got): %s", diff)
+		}
+	}
+
+	// The pods of the pool are resynced with one endpoint per target port.
+	pool := testutil.MakeInferencePool("pool").Namespace("default").Selector(selector).TargetPorts(8000, 8001).ObjRef()
+	assert.NoError(t, ds.PoolSet(t.Context(), fakeClient, pool))
+	assertEndpoints(endpoint("pod1:8000", "10.0.0.1", 8000, "pod1"), endpoint("pod1:8001", "10.0.0.1", 8001, "pod1"))
+
+	assert.Equal(t, []types.NamespacedName{{Name: "pod2:8000", Namespace: "default"}, {Name: "pod2:8001", Namespace: "default"}},
+		ds.PodUpdateOrAddIfNotExist(readyPod("pod2", "10.0.0.2")))
+	assert.Empty(t, ds.PodUpdateOrAddIfNotExist(readyPod("pod2", "10.0.0.2")))
+	assertEndpoints(endpoint("pod1:8000", "10.0.0.1", 8000, "pod1"), endpoint("pod1:8001", "10.0.0.1", 8001, "pod1"),
+		endpoint("pod2:8000", "10.0.0.2", 8000, "pod2"), endpoint("pod2:8001", "10.0.0.2", 8001, "pod2"))
+
+	// The ranks don't collide with pods named after them.
+	assert.Len(t, ds.PodUpdateOrAddIfNotExist(readyPod("pod1-rank-0", "10.0.0.3")), 2)
+	assert.Len(t, ds.PodList(backendmetrics.AllPodsPredicate), 6)
+	ds.PodDelete(types.NamespacedName{Name: "pod1-rank-0", Namespace: "default"})
+
+	// Extending the target ports of a pod only adds the endpoints of the new ports, and updates the
+	// others.
+	pod3 := readyPod("pod3", "10.0.0.3")
+	pod3.Annotations = map[string]string{datalayer.TargetPortsAnnotation: "9000,9001"}
+	assert.Len(t, ds.PodUpdateOrAddIfNotExist(pod3), 2)
+	pod3 = pod3.DeepCopy()
+	pod3.Annotations[datalayer.TargetPortsAnnotation] = "9000,9001,9002"
+	pod3.Labels["version"] = "v2"
+	assert.Equal(t, []types.NamespacedName{{Name: "pod3:9002", Namespace: "default"}}, ds.PodUpdateOrAddIfNotExist(pod3))
+	assert.Equal(t, "v2", ds.PodGet(types.NamespacedName{Name: "pod3:9000", Namespace: "default"}).GetPod().Labels["version"])
+	ds.PodDelete(types.NamespacedName{Name: "pod3", Namespace: "default"})
+
+	// Deleting a pod deletes all its ranks.
+	ds.PodDelete(types.NamespacedName{Name: "pod1", Namespace: "default"})
+	assertEndpoints(endpoint("pod2:8000", "10.0.0.2", 8000, "pod2"), endpoint("pod2:8001", "10.0.0.2", 8001, "pod2"))
+	assert.Nil(t, ds.PodGet(types.NamespacedName{Name: "pod1:8000", Namespace: "default"}))
+
+	// Updating the target ports replaces the endpoints of the pods.
+	pool = testutil.MakeInferencePool("pool").Namespace("default").Selector(selector).TargetPortNumber(8000).ObjRef()
+	assert.NoError(t, ds.PoolSet(t.Context(), fakeClient, pool))
+	assertEndpoints(endpoint("pod1", "10.0.0.1", 8000, ""))
+}
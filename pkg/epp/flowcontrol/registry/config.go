@@ -28,6 +28,10 @@ import (
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/flowcontrol/framework/plugins/policies/intraflow/dispatch/fcfs"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/flowcontrol/framework/plugins/queue"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/flowcontrol/framework/plugins/queue/listqueue"
+
+	// Register the other in-tree policies and queues, so that they can be configured by name.
+	_ "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/flowcontrol/framework/plugins/policies/interflow/dispatch/roundrobin"
+	_ "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/flowcontrol/framework/plugins/queue/maxminheap"
 )
 
 // Config holds the master configuration for the entire `FlowRegistry`. It serves as the top-level blueprint, defining
@@ -83,9 +87,10 @@ func (c *Config) partition(shardIndex, totalShards int) (*Config, error) {
 	return newCfg, nil
 }
 
-// validateAndApplyDefaults checks the configuration for validity and populates any empty fields with system defaults.
-// This method should be called once by the registry before it initializes any shards.
-func (c *Config) validateAndApplyDefaults() error {
+// ValidateAndApplyDefaults checks the configuration for validity and populates any empty fields with system defaults.
+// This method should be called once by the registry before it initializes any shards. It's also called when the
+// configuration is loaded from a file, so that an invalid configuration is reported at that time.
+func (c *Config) ValidateAndApplyDefaults() error {
 	if len(c.PriorityBands) == 0 {
 		return errors.New("config validation failed: at least one priority band must be defined")
 	}
@@ -120,24 +125,30 @@ func (c *Config) validateAndApplyDefaults() error {
 	return nil
 }
 
-// validateBandCompatibility verifies that a band's default policy is compatible with its default queue type.
+// validateBandCompatibility verifies that a band's policies and queue type are registered, and that its default policy
+// is compatible with its default queue type.
 func validateBandCompatibility(band PriorityBandConfig) error {
+	if _, err := inter.NewPolicyFromName(band.InterFlowDispatchPolicy); err != nil {
+		return fmt.Errorf("failed to validate inter-flow policy %q for priority band %d: %w",
+			band.InterFlowDispatchPolicy, band.Priority, err)
+	}
+
 	policy, err := intra.NewPolicyFromName(band.IntraFlowDispatchPolicy)
 	if err != nil {
 		return fmt.Errorf("failed to validate policy %q for priority band %d: %w",
 			band.IntraFlowDispatchPolicy, band.Priority, err)
 	}
 
-	requiredCapabilities := policy.RequiredQueueCapabilities()
-	if len(requiredCapabilities) == 0 {
-		return nil // Policy has no specific requirements.
-	}
-
 	// Create a temporary queue instance to inspect its capabilities.
 	tempQueue, err := queue.NewQueueFromName(band.Queue, nil)
 	if err != nil {
 		return fmt.Errorf("failed to inspect queue type %q for priority band %d: %w", band.Queue, band.Priority, err)
 	}
+
+	requiredCapabilities := policy.RequiredQueueCapabilities()
+	if len(requiredCapabilities) == 0 {
+		return nil // Policy has no specific requirements.
+	}
 	queueCapabilities := tempQueue.Capabilities()
 
 	// Build a set of the queue's capabilities for efficient lookup.
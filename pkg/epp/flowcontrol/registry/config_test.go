@@ -177,6 +177,24 @@ func TestConfig_ValidateAndApplyDefaults(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		{
+			name: "Error: Unknown inter-flow policy",
+			input: &Config{
+				PriorityBands: []PriorityBandConfig{
+					{Priority: 1, PriorityName: "High", InterFlowDispatchPolicy: "unknown-inter-flow-policy"},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Error: Unknown queue",
+			input: &Config{
+				PriorityBands: []PriorityBandConfig{
+					{Priority: 1, PriorityName: "High", Queue: "unknown-queue"},
+				},
+			},
+			expectErr: true,
+		},
 		{
 			name: "Error: Failing queue instantiation",
 			input: &Config{
@@ -196,7 +214,7 @@ func TestConfig_ValidateAndApplyDefaults(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
-			err := tc.input.validateAndApplyDefaults()
+			err := tc.input.ValidateAndApplyDefaults()
 			if tc.expectErr {
 				require.Error(t, err, "Expected an error for this test case")
 				if tc.expectedErrIs != nil {
@@ -60,7 +60,7 @@ func setupTestShard(t *testing.T) *shardTestFixture {
 		},
 	}
 	// Apply defaults to the master config first, as the parent registry would.
-	err := config.validateAndApplyDefaults()
+	err := config.ValidateAndApplyDefaults()
 	require.NoError(t, err, "Setup: validating and defaulting config should not fail")
 
 	// The parent registry would partition the config. For a single shard test, we can use the defaulted one directly.
@@ -150,7 +150,7 @@ func TestNewShard_ErrorPaths(t *testing.T) {
 			Queue:                   listqueue.ListQueueName,
 		}},
 	}
-	require.NoError(t, baseConfig.validateAndApplyDefaults(), "Setup: base config should be valid")
+	require.NoError(t, baseConfig.ValidateAndApplyDefaults(), "Setup: base config should be valid")
 
 	t.Run("Invalid InterFlow Policy", func(t *testing.T) {
 		// Register a mock policy that always fails to instantiate
@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"context"
+	"sync"
+)
+
+// Drainer tracks the in-flight ext-proc streams, so that they can complete before the EPP shuts
+// down. Once draining, new streams are refused with UNAVAILABLE, which the gateway can retry on
+// another EPP replica.
+type Drainer struct {
+	mu       sync.Mutex
+	draining bool
+	active   int
+	drained  chan struct{}
+}
+
+// NewDrainer returns a new Drainer.
+func NewDrainer() *Drainer {
+	return &Drainer{drained: make(chan struct{})}
+}
+
+// Start starts draining. It is safe to call it several times.
+func (d *Drainer) Start() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.draining {
+		return
+	}
+	d.draining = true
+	if d.active == 0 {
+		close(d.drained)
+	}
+}
+
+// Draining returns true once draining started.
+func (d *Drainer) Draining() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.draining
+}
+
+// Wait blocks until all the in-flight streams completed after draining started, or the context is
+// done.
+func (d *Drainer) Wait(ctx context.Context) error {
+	select {
+	case <-d.drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// acquire registers a new stream. It returns false if draining, in which case the stream must be
+// refused.
+func (d *Drainer) acquire() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.draining {
+		return false
+	}
+	d.active++
+	return true
+}
+
+// release unregisters a stream registered by acquire.
+func (d *Drainer) release() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.active--
+	if d.draining && d.active == 0 {
+		close(d.drained)
+	}
+}
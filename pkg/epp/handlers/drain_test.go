@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDrainer(t *testing.T) {
+	drainer := NewDrainer()
+	require.True(t, drainer.acquire())
+	require.True(t, drainer.acquire())
+
+	drainer.Start()
+	drainer.Start()
+	assert.True(t, drainer.Draining())
+	assert.False(t, drainer.acquire(), "New streams must be refused while draining")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, drainer.Wait(ctx), context.DeadlineExceeded)
+
+	drainer.release()
+	drainer.release()
+	assert.NoError(t, drainer.Wait(context.Background()))
+}
+
+func TestDrainerWithoutStreams(t *testing.T) {
+	drainer := NewDrainer()
+	assert.False(t, drainer.Draining())
+	drainer.Start()
+	assert.NoError(t, drainer.Wait(context.Background()))
+}
+
+func TestProcessWhileDraining(t *testing.T) {
+	drainer := NewDrainer()
+	drainer.Start()
+	server := NewStreamingServer(&fakeDatastore{}, &testDirector{}).WithDrainer(drainer)
+	stream := &fakeProcessServer{ctx: context.Background()}
+
+	err := server.Process(stream)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Empty(t, stream.responses)
+}
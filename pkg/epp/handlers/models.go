@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	envoyTypePb "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
+	requtil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/request"
+)
+
+const modelsPath = "/v1/models"
+
+// ModelsOptions configures the response to /v1/models requests, which is built by the EPP from the
+// models served across the pool.
+type ModelsOptions struct {
+	// Readiness adds the number of pods ready to serve each model to the response.
+	Readiness bool
+}
+
+// ModelList is the response to /v1/models requests, in the OpenAI API format.
+type ModelList struct {
+	Object string  `json:"object"`
+	Data   []Model `json:"data"`
+}
+
+// Model is a model served by the pool.
+type Model struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Created int64  `json:"created"`
+	OwnedBy string `json:"owned_by"`
+	// Root is the base model of the model, if known.
+	Root string `json:"root,omitempty"`
+	// Parent is the base model a LoRA adapter is served on, if known.
+	Parent string `json:"parent,omitempty"`
+	// ReadyReplicas is the number of pods with the model loaded, set when readiness is enabled.
+	ReadyReplicas *int `json:"ready_replicas,omitempty"`
+}
+
+// isModelsRequest returns true if the bodyless request lists the models.
+func isModelsRequest(req *extProcPb.ProcessingRequest_RequestHeaders) bool {
+	method := requtil.ExtractHeaderValue(req, ":method")
+	if method != "" && method != "GET" {
+		return false
+	}
+	path, _, _ := strings.Cut(requtil.ExtractHeaderValue(req, requtil.PathHeaderKey), "?")
+	return strings.TrimSuffix(path, "/") == modelsPath
+}
+
+// generateModelsResponse answers a /v1/models request with the models of the pool.
+func (s *StreamingServer) generateModelsResponse() (*extProcPb.ProcessingResponse, error) {
+	models, err := s.listModels()
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(models)
+	if err != nil {
+		return nil, errutil.Error{Code: errutil.Internal, Msg: "failed to encode the models: " + err.Error()}
+	}
+	return &extProcPb.ProcessingResponse{
+		Response: &extProcPb.ProcessingResponse_ImmediateResponse{
+			ImmediateResponse: &extProcPb.ImmediateResponse{
+				Status: &envoyTypePb.HttpStatus{Code: envoyTypePb.StatusCode_OK},
+				Headers: &extProcPb.HeaderMutation{
+					SetHeaders: []*configPb.HeaderValueOption{
+						{Header: &configPb.HeaderValue{Key: "content-type", RawValue: []byte("application/json")}},
+					},
+				},
+				Body: body,
+			},
+		},
+	}, nil
+}
+
+// listModels merges the base models and the LoRA adapters reported by the pods, and the
+// InferenceObjectives. Adapters waiting to be loaded are listed, but aren't counted as ready.
+func (s *StreamingServer) listModels() (*ModelList, error) {
+	pool, err := s.datastore.PoolGet()
+	if err != nil {
+		return nil, errutil.Error{Code: errutil.Internal, Msg: "failed to get the InferencePool: " + err.Error()}
+	}
+	created := pool.CreationTimestamp.Unix()
+	models := map[string]*Model{}
+	parents := map[string][]string{}
+	model := func(id string) *Model {
+		m, ok := models[id]
+		if !ok {
+			m = &Model{ID: id, Object: "model", Created: created, OwnedBy: pool.Name}
+			if s.models.Readiness {
+				m.ReadyReplicas = new(int)
+			}
+			models[id] = m
+		}
+		return m
+	}
+	ready := func(m *Model) {
+		if m.ReadyReplicas != nil {
+			*m.ReadyReplicas++
+		}
+	}
+
+	pods := s.datastore.PodList(backendmetrics.AllPodsPredicate)
+	for _, pod := range pods {
+		metrics := pod.GetMetrics()
+		if metrics == nil {
+			continue
+		}
+		if metrics.BaseModel != "" {
+			base := model(metrics.BaseModel)
+			base.Root = metrics.BaseModel
+			ready(base)
+		}
+		for adapter := range metrics.ActiveModels {
+			ready(model(adapter))
+			if metrics.BaseModel != "" && !slices.Contains(parents[adapter], metrics.BaseModel) {
+				parents[adapter] = append(parents[adapter], metrics.BaseModel)
+			}
+		}
+		for adapter := range metrics.WaitingModels {
+			model(adapter)
+		}
+	}
+	for adapter, bases := range parents {
+		if len(bases) == 1 && adapter != bases[0] {
+			models[adapter].Root = bases[0]
+			models[adapter].Parent = bases[0]
+		}
+	}
+	for _, objective := range s.datastore.ObjectiveGetAll() {
+		if _, ok := models[objective.Name]; ok {
+			continue
+		}
+		m := model(objective.Name)
+		m.Created = objective.CreationTimestamp.Unix()
+		// An objective is served by any pod of the pool.
+		if m.ReadyReplicas != nil {
+			*m.ReadyReplicas = len(pods)
+		}
+	}
+
+	list := &ModelList{Object: "list", Data: make([]Model, 0, len(models))}
+	for _, m := range models {
+		list.Data = append(list.Data, *m)
+	}
+	slices.SortFunc(list.Data, func(a, b Model) int { return strings.Compare(a.ID, b.ID) })
+	return list, nil
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"encoding/json"
+	"testing"
+
+	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	envoyTypePb "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	"sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+)
+
+func TestListModels(t *testing.T) {
+	created := metav1.Unix(1000, 0)
+	ds := &fakeDatastore{
+		pool: &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", CreationTimestamp: created}},
+		objectives: []*v1alpha2.InferenceObjective{
+			{ObjectMeta: metav1.ObjectMeta{Name: "base", CreationTimestamp: metav1.Unix(2000, 0)}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "chatbot", CreationTimestamp: metav1.Unix(3000, 0)}},
+		},
+		pods: []backendmetrics.PodMetrics{
+			&backendmetrics.FakePodMetrics{
+				Pod: &backend.Pod{NamespacedName: types.NamespacedName{Name: "pod1"}},
+				Metrics: &backendmetrics.MetricsState{
+					BaseModel:     "base",
+					ActiveModels:  map[string]int{"lora1": 0, "lora2": 0},
+					WaitingModels: map[string]int{"lora3": 0},
+				},
+			},
+			&backendmetrics.FakePodMetrics{
+				Pod: &backend.Pod{NamespacedName: types.NamespacedName{Name: "pod2"}},
+				Metrics: &backendmetrics.MetricsState{
+					BaseModel:    "base",
+					ActiveModels: map[string]int{"lora1": 0},
+				},
+			},
+			&backendmetrics.FakePodMetrics{
+				Pod:     &backend.Pod{NamespacedName: types.NamespacedName{Name: "pod3"}},
+				Metrics: &backendmetrics.MetricsState{ActiveModels: map[string]int{"lora1": 0}},
+			},
+		},
+	}
+	replicas := func(n int) *int { return &n }
+
+	tests := []struct {
+		name    string
+		options ModelsOptions
+		want    *ModelList
+	}{
+		{
+			name: "models",
+			want: &ModelList{Object: "list", Data: []Model{
+				{ID: "base", Object: "model", Created: 1000, OwnedBy: "pool", Root: "base"},
+				{ID: "chatbot", Object: "model", Created: 3000, OwnedBy: "pool"},
+				{ID: "lora1", Object: "model", Created: 1000, OwnedBy: "pool", Root: "base", Parent: "base"},
+				{ID: "lora2", Object: "model", Created: 1000, OwnedBy: "pool", Root: "base", Parent: "base"},
+				{ID: "lora3", Object: "model", Created: 1000, OwnedBy: "pool"},
+			}},
+		},
+		{
+			name:    "models with readiness",
+			options: ModelsOptions{Readiness: true},
+			want: &ModelList{Object: "list", Data: []Model{
+				{ID: "base", Object: "model", Created: 1000, OwnedBy: "pool", Root: "base", ReadyReplicas: replicas(2)},
+				{ID: "chatbot", Object: "model", Created: 3000, OwnedBy: "pool", ReadyReplicas: replicas(3)},
+				{ID: "lora1", Object: "model", Created: 1000, OwnedBy: "pool", Root: "base", Parent: "base", ReadyReplicas: replicas(3)},
+				{ID: "lora2", Object: "model", Created: 1000, OwnedBy: "pool", Root: "base", Parent: "base", ReadyReplicas: replicas(1)},
+				{ID: "lora3", Object: "model", Created: 1000, OwnedBy: "pool", ReadyReplicas: replicas(0)},
+			}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := NewStreamingServer(ds, &testDirector{}).WithModels(&test.options)
+			got, err := server.listModels()
+			if err != nil {
+				t.Fatalf("listModels returned unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("Unexpected models (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestHandleModelsRequest(t *testing.T) {
+	ds := &fakeDatastore{
+		pool: &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool"}},
+		pods: []backendmetrics.PodMetrics{
+			&backendmetrics.FakePodMetrics{
+				Pod:     &backend.Pod{NamespacedName: types.NamespacedName{Name: "pod1"}},
+				Metrics: &backendmetrics.MetricsState{BaseModel: "base"},
+			},
+		},
+	}
+	server := NewStreamingServer(ds, &testDirector{}).WithModels(&ModelsOptions{})
+	reqCtx := &RequestContext{Request: &Request{Headers: map[string]string{}}}
+	req := &extProcPb.ProcessingRequest_RequestHeaders{RequestHeaders: &extProcPb.HttpHeaders{
+		Headers: &configPb.HeaderMap{Headers: []*configPb.HeaderValue{
+			{Key: ":method", RawValue: []byte("GET")},
+			{Key: ":path", RawValue: []byte("/v1/models?limit=10")},
+		}},
+		EndOfStream: true,
+	}}
+
+	if err := server.HandleRequestHeaders(reqCtx, req); err != nil {
+		t.Fatalf("HandleRequestHeaders returned unexpected error: %v", err)
+	}
+	resp := reqCtx.reqHeaderResp.GetImmediateResponse()
+	if resp == nil {
+		t.Fatalf("Expected an immediate response, got %v", reqCtx.reqHeaderResp)
+	}
+	if resp.Status.Code != envoyTypePb.StatusCode_OK {
+		t.Errorf("Unexpected status %v", resp.Status.Code)
+	}
+	got := &ModelList{}
+	if err := json.Unmarshal(resp.Body, got); err != nil {
+		t.Fatalf("Failed to decode the response body %s: %v", resp.Body, err)
+	}
+	want := &ModelList{Object: "list", Data: []Model{{ID: "base", Object: "model", Created: metav1.Time{}.Unix(), OwnedBy: "pool", Root: "base"}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected models (-want +got): %s", diff)
+	}
+}
@@ -17,14 +17,19 @@ limitations under the License.
 package handlers
 
 import (
+	"context"
+	"net"
 	"strconv"
+	"strings"
 	"time"
 
 	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
 	"google.golang.org/protobuf/types/known/structpb"
 
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/common"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metadata"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics"
 	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
 )
 
@@ -33,6 +38,14 @@ func (s *StreamingServer) HandleRequestHeaders(reqCtx *RequestContext, req *extP
 
 	// an EoS in the request headers means this request has no body or trailers.
 	if req.RequestHeaders.EndOfStream {
+		if s.models != nil && isModelsRequest(req) {
+			resp, err := s.generateModelsResponse()
+			if err != nil {
+				return err
+			}
+			reqCtx.reqHeaderResp = resp
+			return nil
+		}
 		// We will route this request to a random pod as this is assumed to just be a GET
 		// More context: https://github.com/kubernetes-sigs/gateway-api-inference-extension/pull/526
 		// The above PR will address endpoint admission, but currently any request without a body will be
@@ -45,7 +58,11 @@ func (s *StreamingServer) HandleRequestHeaders(reqCtx *RequestContext, req *extP
 		if err != nil {
 			return err
 		}
-		reqCtx.TargetEndpoint = pod.Address + ":" + strconv.Itoa(int(pool.Spec.TargetPortNumber))
+		port := pod.Port
+		if ports := common.TargetPorts(&pool.Spec); port == 0 && len(ports) > 0 {
+			port = ports[0]
+		}
+		reqCtx.TargetEndpoint = net.JoinHostPort(pod.Address, strconv.Itoa(int(port)))
 		reqCtx.RequestSize = 0
 		reqCtx.reqHeaderResp = s.generateRequestHeaderResponse(reqCtx)
 		return nil
@@ -79,7 +96,61 @@ func (s *StreamingServer) HandleRequestHeaders(reqCtx *RequestContext, req *extP
 	return nil
 }
 
-func (s *StreamingServer) generateRequestBodyResponses(requestBodyBytes []byte) []*extProcPb.ProcessingResponse {
+// handleRequestHeadersOnly schedules the request on its headers, when the request body isn't sent to
+// the EPP. The model name is read from the header set by Body-Based Routing.
+func (s *StreamingServer) handleRequestHeadersOnly(ctx context.Context, reqCtx *RequestContext) error {
+	model := reqCtx.Request.Headers[metadata.ModelNameKey]
+	if model == "" {
+		return errutil.Error{Code: errutil.BadRequest, Msg: metadata.ModelNameKey + " header not found in request"}
+	}
+	reqCtx.Request.HeadersOnly = true
+	reqCtx.Request.Body["model"] = model
+
+	reqCtx, err := s.director.HandleRequest(ctx, reqCtx)
+	if err != nil {
+		return err
+	}
+	reqCtx.reqHeaderResp = s.generateRequestHeaderResponse(reqCtx)
+	metrics.RecordRequestCounter(reqCtx.IncomingModelName, reqCtx.TargetModelName)
+	return nil
+}
+
+// bufferedRequestHeaderResponse lets the request headers through unchanged. In the buffered modes,
+// Envoy waits for it before sending the request body, and the headers are mutated in the response
+// to the body instead.
+func bufferedRequestHeaderResponse() *extProcPb.ProcessingResponse {
+	return &extProcPb.ProcessingResponse{
+		Response: &extProcPb.ProcessingResponse_RequestHeaders{
+			RequestHeaders: &extProcPb.HeadersResponse{},
+		},
+	}
+}
+
+func (s *StreamingServer) generateRequestBodyResponses(reqCtx *RequestContext, requestBodyBytes []byte) []*extProcPb.ProcessingResponse {
+	if s.buffersRequestBody() {
+		// The request headers are held by Envoy until the body is processed, so the header mutations and
+		// the destination are sent along with the body.
+		return []*extProcPb.ProcessingResponse{
+			{
+				Response: &extProcPb.ProcessingResponse_RequestBody{
+					RequestBody: &extProcPb.BodyResponse{
+						Response: &extProcPb.CommonResponse{
+							ClearRouteCache: true,
+							HeaderMutation: &extProcPb.HeaderMutation{
+								SetHeaders:    s.generateHeaders(reqCtx),
+								RemoveHeaders: reqCtx.Request.RemovedHeaders,
+							},
+							BodyMutation: &extProcPb.BodyMutation{
+								Mutation: &extProcPb.BodyMutation_Body{Body: requestBodyBytes},
+							},
+						},
+					},
+				},
+				DynamicMetadata: s.generateMetadata(reqCtx.TargetEndpoint),
+			},
+		}
+	}
+
 	commonResponses := buildCommonResponses(requestBodyBytes, bodyByteLimit, true)
 	responses := []*extProcPb.ProcessingResponse{}
 	for _, commonResp := range commonResponses {
@@ -105,7 +176,8 @@ func (s *StreamingServer) generateRequestHeaderResponse(reqCtx *RequestContext)
 				Response: &extProcPb.CommonResponse{
 					ClearRouteCache: true,
 					HeaderMutation: &extProcPb.HeaderMutation{
-						SetHeaders: s.generateHeaders(reqCtx),
+						SetHeaders:    s.generateHeaders(reqCtx),
+						RemoveHeaders: reqCtx.Request.RemovedHeaders,
 					},
 				},
 			},
@@ -137,6 +209,10 @@ func (s *StreamingServer) generateHeaders(reqCtx *RequestContext) []*configPb.He
 
 	// include all headers
 	for key, value := range reqCtx.Request.Headers {
+		if reqCtx.RequestSize > 0 && strings.EqualFold(key, "Content-Length") {
+			// The received content length doesn't match a mutated body.
+			continue
+		}
 		headers = append(headers, &configPb.HeaderValueOption{
 			Header: &configPb.HeaderValue{
 				Key:      key,
@@ -17,10 +17,13 @@ limitations under the License.
 package handlers
 
 import (
+	"strings"
 	"testing"
 
 	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	"github.com/google/go-cmp/cmp"
+
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metadata"
 )
 
@@ -66,3 +69,39 @@ func TestHandleRequestHeaders(t *testing.T) {
 		t.Errorf("expected fairness ID header to be removed from request headers, but it was not")
 	}
 }
+
+func TestGenerateRequestHeaderResponse(t *testing.T) {
+	server := &StreamingServer{}
+	reqCtx := &RequestContext{
+		TargetEndpoint: "10.0.0.1:8000",
+		RequestSize:    128,
+		Request: &Request{
+			Headers: map[string]string{
+				"content-length": "42",
+				"x-served-by":    "pod1",
+			},
+			RemovedHeaders: []string{"x-api-key"},
+		},
+	}
+
+	resp := server.generateRequestHeaderResponse(reqCtx)
+	mutation := resp.GetRequestHeaders().GetResponse().GetHeaderMutation()
+	got := map[string]string{}
+	for _, header := range mutation.GetSetHeaders() {
+		if _, ok := got[strings.ToLower(header.Header.Key)]; ok {
+			t.Errorf("header %q is set twice", header.Header.Key)
+		}
+		got[strings.ToLower(header.Header.Key)] = string(header.Header.RawValue)
+	}
+	want := map[string]string{
+		metadata.DestinationEndpointKey: "10.0.0.1:8000",
+		"content-length":                "128",
+		"x-served-by":                   "pod1",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected set headers, diff(-want, +got): %v", diff)
+	}
+	if diff := cmp.Diff([]string{"x-api-key"}, mutation.GetRemoveHeaders()); diff != "" {
+		t.Errorf("Unexpected removed headers, diff(-want, +got): %v", diff)
+	}
+}
@@ -19,7 +19,9 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"slices"
 	"strings"
+	"time"
 
 	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
@@ -60,7 +62,8 @@ func (s *StreamingServer) HandleResponseBody(ctx context.Context, reqCtx *Reques
 	// will add the processing for streaming case.
 	reqCtx.ResponseComplete = true
 
-	reqCtx.respBodyResp = generateResponseBodyResponses(responseBytes, true)
+	responseBytes = s.director.HandleResponseBodyChunk(ctx, reqCtx, responseBytes, true)
+	reqCtx.respBodyResp = s.generateResponseBodyResponses(responseBytes, true)
 	return reqCtx, nil
 }
 
@@ -83,18 +86,54 @@ func (s *StreamingServer) HandleResponseHeaders(ctx context.Context, reqCtx *Req
 		}
 	}
 
+	s.consumeLoadReport(ctx, reqCtx)
+
 	reqCtx, err := s.director.HandleResponse(ctx, reqCtx)
 
 	return reqCtx, err
 }
 
+// consumeLoadReport updates the metrics of the target pod from the load report attached to the
+// response headers, if any. The load report headers are removed from the response.
+func (s *StreamingServer) consumeLoadReport(ctx context.Context, reqCtx *RequestContext) {
+	if s.loadReports == nil {
+		return
+	}
+	logger := log.FromContext(ctx)
+	defer func() {
+		for _, header := range s.loadReports.Headers() {
+			delete(reqCtx.Response.Headers, header)
+		}
+	}()
+
+	if reqCtx.TargetPod == nil {
+		return
+	}
+	pm := s.datastore.PodGet(reqCtx.TargetPod.NamespacedName)
+	if pm == nil {
+		return
+	}
+	updated, err := s.loadReports.Parse(reqCtx.Response.Headers, pm.GetMetrics())
+	if err != nil {
+		logger.V(logutil.DEBUG).Info("Ignoring invalid load report", "pod", reqCtx.TargetPod.NamespacedName, "error", err)
+		return
+	}
+	if updated == nil {
+		return
+	}
+	updated.UpdateTime = time.Now()
+	pm.UpdateMetrics(updated)
+	logger.V(logutil.TRACE).Info("Updated metrics from load report", "pod", reqCtx.TargetPod.NamespacedName, "metrics", updated)
+}
+
 func (s *StreamingServer) generateResponseHeaderResponse(reqCtx *RequestContext) *extProcPb.ProcessingResponse {
 	return &extProcPb.ProcessingResponse{
 		Response: &extProcPb.ProcessingResponse_ResponseHeaders{
 			ResponseHeaders: &extProcPb.HeadersResponse{
 				Response: &extProcPb.CommonResponse{
 					HeaderMutation: &extProcPb.HeaderMutation{
-						SetHeaders: s.generateResponseHeaders(reqCtx),
+						SetHeaders:    s.generateResponseHeaders(reqCtx),
+						RemoveHeaders: s.removedResponseHeaders(reqCtx),
 					},
 				},
 			},
@@ -102,7 +141,24 @@ func (s *StreamingServer) generateResponseHeaderResponse(reqCtx *RequestContext)
 	}
 }
 
-func generateResponseBodyResponses(responseBodyBytes []byte, setEoS bool) []*extProcPb.ProcessingResponse {
+func (s *StreamingServer) generateResponseBodyResponses(responseBodyBytes []byte, setEoS bool) []*extProcPb.ProcessingResponse {
+	if !s.streamsBodies() {
+		// Outside of the full duplex mode, each body message is answered with its replacement.
+		return []*extProcPb.ProcessingResponse{
+			{
+				Response: &extProcPb.ProcessingResponse_ResponseBody{
+					ResponseBody: &extProcPb.BodyResponse{
+						Response: &extProcPb.CommonResponse{
+							BodyMutation: &extProcPb.BodyMutation{
+								Mutation: &extProcPb.BodyMutation_Body{Body: responseBodyBytes},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
 	commonResponses := buildCommonResponses(responseBodyBytes, bodyByteLimit, setEoS)
 	responses := []*extProcPb.ProcessingResponse{}
 	for _, commonResp := range commonResponses {
@@ -118,17 +174,32 @@ func generateResponseBodyResponses(responseBodyBytes []byte, setEoS bool) []*ext
 	return responses
 }
 
-func (s *StreamingServer) generateResponseHeaders(reqCtx *RequestContext) []*configPb.HeaderValueOption {
-	// can likely refactor these two bespoke headers to be updated in PostDispatch, to centralize logic.
-	headers := []*configPb.HeaderValueOption{
+// unchangedResponseBodyResponses lets a chunk of the response body through unchanged, outside of the
+// full duplex mode.
+func unchangedResponseBodyResponses() []*extProcPb.ProcessingResponse {
+	return []*extProcPb.ProcessingResponse{
 		{
-			Header: &configPb.HeaderValue{
-				// This is for debugging purpose only.
-				Key:      "x-went-into-resp-headers",
-				RawValue: []byte("true"),
+			Response: &extProcPb.ProcessingResponse_ResponseBody{
+				ResponseBody: &extProcPb.BodyResponse{},
 			},
 		},
 	}
+}
+
+// removedResponseHeaders returns the response headers consumed by the EPP or removed by the response
+// plugins, that must not reach the client.
+func (s *StreamingServer) removedResponseHeaders(reqCtx *RequestContext) []string {
+	removed := reqCtx.Response.RemovedHeaders
+	if s.loadReports != nil {
+		removed = append(slices.Clone(removed), s.loadReports.Headers()...)
+	}
+	return removed
+}
+
+// generateResponseHeaders returns the response headers, as set by the model server and edited by the
+// response header plugins.
+func (s *StreamingServer) generateResponseHeaders(reqCtx *RequestContext) []*configPb.HeaderValueOption {
+	headers := []*configPb.HeaderValueOption{}
 
 	// include all headers
 	for key, value := range reqCtx.Response.Headers {
@@ -22,7 +22,12 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
 
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	"sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 )
 
@@ -82,7 +87,7 @@ func TestHandleResponseBody(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			server := &StreamingServer{}
+			server := &StreamingServer{director: &testDirector{}}
 			reqCtx := test.reqCtx
 			if reqCtx == nil {
 				reqCtx = &RequestContext{}
@@ -142,7 +147,7 @@ func TestHandleStreamedResponseBody(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			server := &StreamingServer{}
+			server := &StreamingServer{director: &testDirector{}}
 			reqCtx := test.reqCtx
 			if reqCtx == nil {
 				reqCtx = &RequestContext{}
@@ -155,3 +160,108 @@ func TestHandleStreamedResponseBody(t *testing.T) {
 		})
 	}
 }
+
+type fakeDatastore struct {
+	pool       *v1.InferencePool
+	objectives []*v1alpha2.InferenceObjective
+	pods       []backendmetrics.PodMetrics
+}
+
+func (ds *fakeDatastore) PoolGet() (*v1.InferencePool, error) {
+	return ds.pool, nil
+}
+
+func (ds *fakeDatastore) ObjectiveGetAll() []*v1alpha2.InferenceObjective {
+	return ds.objectives
+}
+
+func (ds *fakeDatastore) PodList(predicate func(backendmetrics.PodMetrics) bool) []backendmetrics.PodMetrics {
+	res := []backendmetrics.PodMetrics{}
+	for _, pm := range ds.pods {
+		if predicate(pm) {
+			res = append(res, pm)
+		}
+	}
+	return res
+}
+
+func (ds *fakeDatastore) PodGet(namespacedName types.NamespacedName) backendmetrics.PodMetrics {
+	for _, pm := range ds.pods {
+		if pm.GetPod().NamespacedName == namespacedName {
+			return pm
+		}
+	}
+	return nil
+}
+
+func TestConsumeLoadReport(t *testing.T) {
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+	podName := types.NamespacedName{Name: "pod1", Namespace: "default"}
+
+	tests := []struct {
+		name        string
+		headers     map[string]string
+		wantQueue   int
+		wantUpdated bool
+	}{
+		{
+			name: "valid load report",
+			headers: map[string]string{
+				"content-type":          "application/json",
+				"endpoint-load-metrics": "TEXT named_metrics.num_requests_waiting=5",
+			},
+			wantQueue:   5,
+			wantUpdated: true,
+		},
+		{
+			name: "invalid load report",
+			headers: map[string]string{
+				"content-type":          "application/json",
+				"endpoint-load-metrics": "TEXT named_metrics.num_requests_waiting=five",
+			},
+			wantQueue: 1,
+		},
+		{
+			name:      "no load report",
+			headers:   map[string]string{"content-type": "application/json"},
+			wantQueue: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pm := &backendmetrics.FakePodMetrics{
+				Pod:     &backend.Pod{NamespacedName: podName},
+				Metrics: &backendmetrics.MetricsState{WaitingQueueSize: 1},
+			}
+			parser, err := backendmetrics.NewLoadReportParser(backendmetrics.LoadReportConfig{
+				Format:                    backendmetrics.LoadReportFormatORCA,
+				TotalQueuedRequestsMetric: "num_requests_waiting",
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error creating the load report parser: %v", err)
+			}
+			server := NewStreamingServer(&fakeDatastore{pods: []backendmetrics.PodMetrics{pm}}, nil).WithLoadReportParser(parser)
+			reqCtx := &RequestContext{
+				TargetPod: &backend.Pod{NamespacedName: podName},
+				Response:  &Response{Headers: test.headers},
+			}
+
+			server.consumeLoadReport(ctx, reqCtx)
+
+			if pm.Metrics.WaitingQueueSize != test.wantQueue {
+				t.Errorf("Unexpected waiting queue size, want %d, got %d", test.wantQueue, pm.Metrics.WaitingQueueSize)
+			}
+			if updated := !pm.Metrics.UpdateTime.IsZero(); updated != test.wantUpdated {
+				t.Errorf("Unexpected metrics update, want %v, got %v", test.wantUpdated, updated)
+			}
+			if diff := cmp.Diff(map[string]string{"content-type": "application/json"}, reqCtx.Response.Headers); diff != "" {
+				t.Errorf("Unexpected response headers, diff(-want, +got): %v", diff)
+			}
+			removed := server.generateResponseHeaderResponse(reqCtx).GetResponseHeaders().GetResponse().GetHeaderMutation().GetRemoveHeaders()
+			if diff := cmp.Diff([]string{"endpoint-load-metrics", "endpoint-load-metrics-bin"}, removed); diff != "" {
+				t.Errorf("Unexpected removed headers, diff(-want, +got): %v", diff)
+			}
+		})
+	}
+}
@@ -19,24 +19,35 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
+	"math"
+	"strconv"
 	"strings"
 	"time"
 
+	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
 	envoyTypePb "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	"sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/common/validation"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/ratelimit"
 	schedulingtypes "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
 	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 	requtil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/request"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/tracing"
 )
 
 const (
@@ -48,24 +59,111 @@ func NewStreamingServer(datastore Datastore, director Director) *StreamingServer
 	return &StreamingServer{
 		director:  director,
 		datastore: datastore,
+		mode:      FullDuplexStreamed,
 	}
 }
 
 type Director interface {
 	HandleRequest(ctx context.Context, reqCtx *RequestContext) (*RequestContext, error)
 	HandleResponse(ctx context.Context, reqCtx *RequestContext) (*RequestContext, error)
+	// HandleResponseBodyChunk returns the chunk of the response body to send to the client.
+	// Non-streaming responses are passed in a single chunk.
+	HandleResponseBodyChunk(ctx context.Context, reqCtx *RequestContext, body []byte, endOfStream bool) []byte
+	// HandleStreamEnd is called once the stream of a request forwarded to a model server is closed.
+	// aborted is true if the stream was closed before the end of the response, e.g. on an upstream
+	// timeout, a stream reset or a client disconnect, which the gateway doesn't tell apart.
+	HandleStreamEnd(ctx context.Context, reqCtx *RequestContext, aborted bool)
 	GetRandomPod() *backend.Pod
 }
 
 type Datastore interface {
 	PoolGet() (*v1.InferencePool, error)
+	ObjectiveGetAll() []*v1alpha2.InferenceObjective
+	PodList(predicate func(backendmetrics.PodMetrics) bool) []backendmetrics.PodMetrics
+	PodGet(namespacedName types.NamespacedName) backendmetrics.PodMetrics
+}
+
+// ProcessingMode is the ext-proc processing mode the gateway is configured with. It determines when
+// Envoy waits for the EPP responses and how the bodies are mutated.
+type ProcessingMode string
+
+const (
+	// FullDuplexStreamed is the default mode, where the request and response bodies are streamed to the
+	// EPP and the request headers are held until the request body is processed.
+	FullDuplexStreamed ProcessingMode = "FULL_DUPLEX_STREAMED"
+	// Buffered is the mode where the whole request body is sent in a single message, once the response
+	// to the request headers is received.
+	Buffered ProcessingMode = "BUFFERED"
+	// BufferedPartial is the same as Buffered, but Envoy sends the body buffered so far when it exceeds
+	// the buffer limit. Such truncated bodies are rejected.
+	BufferedPartial ProcessingMode = "BUFFERED_PARTIAL"
+	// HeadersOnly is the mode where the request body isn't sent to the EPP. The model name is read from
+	// the header set by Body-Based Routing, and the request is scheduled on its headers.
+	HeadersOnly ProcessingMode = "HEADERS_ONLY"
+)
+
+// ProcessingModes returns the supported processing modes.
+func ProcessingModes() []ProcessingMode {
+	return []ProcessingMode{FullDuplexStreamed, Buffered, BufferedPartial, HeadersOnly}
 }
 
 // Server implements the Envoy external processing server.
 // https://www.envoyproxy.io/docs/envoy/latest/api-v3/service/ext_proc/v3/external_processor.proto
 type StreamingServer struct {
-	datastore Datastore
-	director  Director
+	datastore   Datastore
+	director    Director
+	loadReports *backendmetrics.LoadReportParser
+	validator   *validation.Validator
+	mode        ProcessingMode
+	models      *ModelsOptions
+	drainer     *Drainer
+}
+
+// WithLoadReportParser enables the consumption of the in-band load reports attached by model
+// servers to their response headers. A nil parser disables it.
+func (s *StreamingServer) WithLoadReportParser(parser *backendmetrics.LoadReportParser) *StreamingServer {
+	s.loadReports = parser
+	return s
+}
+
+// WithRequestValidator enables the validation of request bodies before scheduling. Invalid
+// requests are rejected with a 400 listing the violations. A nil validator disables it.
+func (s *StreamingServer) WithRequestValidator(validator *validation.Validator) *StreamingServer {
+	s.validator = validator
+	return s
+}
+
+// WithProcessingMode sets the ext-proc processing mode configured on the gateway. The default is
+// FULL_DUPLEX_STREAMED.
+func (s *StreamingServer) WithProcessingMode(mode ProcessingMode) *StreamingServer {
+	s.mode = mode
+	return s
+}
+
+// buffersRequestBody returns true if the whole request body is sent in a single message, after the
+// response to the request headers.
+func (s *StreamingServer) buffersRequestBody() bool {
+	return s.mode == Buffered || s.mode == BufferedPartial
+}
+
+// streamsBodies returns true if the bodies are streamed in full duplex, with responses chunked
+// independently of the received messages.
+func (s *StreamingServer) streamsBodies() bool {
+	return s.mode == FullDuplexStreamed || s.mode == ""
+}
+
+// WithModels enables answering /v1/models requests directly from the EPP, with the models served
+// across the pool. A nil options disables it, and the requests are routed to a random pod.
+func (s *StreamingServer) WithModels(options *ModelsOptions) *StreamingServer {
+	s.models = options
+	return s
+}
+
+// WithDrainer tracks the streams with the given drainer, and refuses new streams once it started
+// draining. A nil drainer disables it.
+func (s *StreamingServer) WithDrainer(drainer *Drainer) *StreamingServer {
+	s.drainer = drainer
+	return s
 }
 
 // RequestContext stores context information during the life time of an HTTP request.
@@ -81,15 +179,21 @@ type RequestContext struct {
 	ObjectiveKey              string
 	RequestReceivedTimestamp  time.Time
 	ResponseCompleteTimestamp time.Time
-	RequestSize               int
-	Usage                     Usage
-	ResponseSize              int
-	ResponseComplete          bool
-	ResponseStatusCode        string
-	RequestRunning            bool
-	Request                   *Request
+	// FirstResponseChunkTimestamp is the reception time of the first chunk of the response body.
+	FirstResponseChunkTimestamp time.Time
+	QueueTime                   time.Duration
+	SchedulingLatency           time.Duration
+	RequestSize                 int
+	Usage                       Usage
+	ResponseSize                int
+	ResponseComplete            bool
+	ResponseStatusCode          string
+	RequestRunning              bool
+	Request                     *Request
 
 	SchedulingRequest *schedulingtypes.LLMRequest
+	// RateLimitReservation is the admission of the request by the rate limit of its objective, if any.
+	RateLimitReservation *ratelimit.Reservation
 
 	RequestState         StreamRequestState
 	modelServerStreaming bool
@@ -109,9 +213,16 @@ type Request struct {
 	Headers  map[string]string
 	Body     map[string]any
 	Metadata map[string]any
+	// HeadersOnly is true if the request body isn't sent to the EPP. The body then only holds the model
+	// name read from the headers, and is not forwarded.
+	HeadersOnly bool
+	// RemovedHeaders are the request headers removed before the request is sent to the model server.
+	RemovedHeaders []string
 }
 type Response struct {
 	Headers map[string]string
+	// RemovedHeaders are the response headers removed before the response is sent to the client.
+	RemovedHeaders []string
 }
 type StreamRequestState int
 
@@ -132,6 +243,14 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 	loggerTrace := logger.V(logutil.TRACE)
 	loggerTrace.Info("Processing")
 
+	if s.drainer != nil {
+		if !s.drainer.acquire() {
+			// UNAVAILABLE is retriable, so the gateway can retry the stream on another replica.
+			return status.Error(codes.Unavailable, "the endpoint picker is shutting down")
+		}
+		defer s.drainer.release()
+	}
+
 	// Create request context to share states during life time of an HTTP request.
 	// See https://github.com/envoyproxy/envoy/issues/17540.
 	reqCtx := &RequestContext{
@@ -149,6 +268,14 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 	var body []byte
 	var responseBody map[string]any
 
+	// The span of the request is started once its headers, which carry the trace context of the
+	// gateway, are received. The span of the response body covers all its chunks.
+	var requestSpan, responseBodySpan trace.Span
+	responseBodyCtx := ctx
+	// The response is aborted if the stream is closed before the response headers, or in the middle
+	// of its body.
+	var responseBodyStarted, responseBodyEnded bool
+
 	// Create error handling var as each request should only report once for
 	// error metrics. This doesn't cover the error "Cannot receive stream request" because
 	// such errors might happen even though response is processed.
@@ -163,6 +290,20 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 			metrics.DecRunningRequests(reqCtx.IncomingModelName)
 		}
 	}(err, reqCtx)
+	defer func() {
+		if err == nil && reqCtx.TargetPod != nil {
+			aborted := (reqCtx.RequestState < ResponseRecieved && !responseBodyStarted) || (responseBodyStarted && !responseBodyEnded)
+			s.director.HandleStreamEnd(ctx, reqCtx, aborted)
+		}
+	}()
+	defer func() {
+		if responseBodySpan != nil {
+			responseBodySpan.End()
+		}
+		if requestSpan != nil {
+			endRequestSpan(requestSpan, reqCtx, err)
+		}
+	}()
 
 	for {
 		select {
@@ -192,21 +333,44 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 				ctx = log.IntoContext(ctx, logger)
 			}
 			err = s.HandleRequestHeaders(reqCtx, v)
+			ctx = tracing.Extract(ctx, reqCtx.Request.Headers)
+			ctx, requestSpan = tracing.Tracer().Start(ctx, "StreamingServer.Process",
+				trace.WithSpanKind(trace.SpanKindServer), trace.WithTimestamp(reqCtx.RequestReceivedTimestamp))
+			// The model server continues the trace from the span of the request.
+			tracing.Inject(ctx, reqCtx.Request.Headers)
+			if err == nil && reqCtx.reqHeaderResp == nil {
+				switch {
+				case s.buffersRequestBody():
+					reqCtx.reqHeaderResp = bufferedRequestHeaderResponse()
+				case s.mode == HeadersOnly:
+					err = s.handleRequestHeadersOnly(ctx, reqCtx)
+				}
+			}
 		case *extProcPb.ProcessingRequest_RequestBody:
 			loggerTrace.Info("Incoming body chunk", "EoS", v.RequestBody.EndOfStream)
+			if reqCtx.Request.HeadersOnly {
+				// The request was already scheduled on its headers, let the body through unchanged.
+				reqCtx.reqBodyResp = []*extProcPb.ProcessingResponse{
+					{Response: &extProcPb.ProcessingResponse_RequestBody{RequestBody: &extProcPb.BodyResponse{}}},
+				}
+				break
+			}
+			if s.buffersRequestBody() && !v.RequestBody.EndOfStream {
+				// Envoy only sends a partial body when it exceeds its buffer limit.
+				err = errutil.Error{Code: errutil.BadRequest, Msg: "request body exceeds the buffer limit of the gateway"}
+				break
+			}
 			// In the stream case, we can receive multiple request bodies.
 			body = append(body, v.RequestBody.Body...)
+			// Reject oversized bodies without buffering them entirely.
+			if err = s.validateRequestSize(len(body)); err != nil {
+				break
+			}
 
 			// Message is buffered, we can read and decode.
 			if v.RequestBody.EndOfStream {
 				loggerTrace.Info("decoding")
-				err = json.Unmarshal(body, &reqCtx.Request.Body)
-				if err != nil {
-					if logger.V(logutil.DEBUG).Enabled() {
-						err = errutil.Error{Code: errutil.BadRequest, Msg: "Error unmarshaling request body: " + string(body)}
-					} else {
-						err = errutil.Error{Code: errutil.BadRequest, Msg: "Error unmarshaling request body"}
-					}
+				if err = s.decodeRequestBody(ctx, reqCtx, body); err != nil {
 					break
 				}
 
@@ -226,8 +390,10 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 					break
 				}
 				reqCtx.RequestSize = len(requestBodyBytes)
-				reqCtx.reqHeaderResp = s.generateRequestHeaderResponse(reqCtx)
-				reqCtx.reqBodyResp = s.generateRequestBodyResponses(requestBodyBytes)
+				if !s.buffersRequestBody() {
+					reqCtx.reqHeaderResp = s.generateRequestHeaderResponse(reqCtx)
+				}
+				reqCtx.reqBodyResp = s.generateRequestBodyResponses(reqCtx, requestBodyBytes)
 
 				metrics.RecordRequestCounter(reqCtx.IncomingModelName, reqCtx.TargetModelName)
 				metrics.RecordRequestSizes(reqCtx.IncomingModelName, reqCtx.TargetModelName, reqCtx.RequestSize)
@@ -249,7 +415,9 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 			reqCtx.RequestState = ResponseRecieved
 
 			var responseErr error
-			reqCtx, responseErr = s.HandleResponseHeaders(ctx, reqCtx, v)
+			responseCtx, responseSpan := tracing.Tracer().Start(ctx, "StreamingServer.HandleResponseHeaders")
+			reqCtx, responseErr = s.HandleResponseHeaders(responseCtx, reqCtx, v)
+			tracing.EndSpan(responseSpan, responseErr)
 			if responseErr != nil {
 				if logger.V(logutil.DEBUG).Enabled() {
 					logger.V(logutil.DEBUG).Error(responseErr, "Failed to process response headers", "request", req)
@@ -260,11 +428,21 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 			reqCtx.respHeaderResp = s.generateResponseHeaderResponse(reqCtx)
 
 		case *extProcPb.ProcessingRequest_ResponseBody:
+			if reqCtx.RequestState < ResponseRecieved {
+				// The response headers are not sent to the EPP, the body can be answered right away.
+				reqCtx.RequestState = HeaderResponseResponseComplete
+			}
+			responseBodyStarted = true
+			responseBodyEnded = v.ResponseBody.EndOfStream
+			if responseBodySpan == nil {
+				responseBodyCtx, responseBodySpan = tracing.Tracer().Start(ctx, "StreamingServer.HandleResponseBody",
+					trace.WithAttributes(tracing.ResponseStreamingKey.Bool(reqCtx.modelServerStreaming)))
+			}
 			if reqCtx.modelServerStreaming {
 				// Currently we punt on response parsing if the modelServer is streaming, and we just passthrough.
 
 				responseText := string(v.ResponseBody.Body)
-				s.HandleResponseBodyModelStreaming(ctx, reqCtx, responseText)
+				s.HandleResponseBodyModelStreaming(responseBodyCtx, reqCtx, responseText)
 				if v.ResponseBody.EndOfStream {
 					loggerTrace.Info("stream completed")
 
@@ -273,9 +451,16 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 					metrics.RecordResponseSizes(reqCtx.IncomingModelName, reqCtx.TargetModelName, reqCtx.ResponseSize)
 				}
 
-				reqCtx.respBodyResp = generateResponseBodyResponses(v.ResponseBody.Body, v.ResponseBody.EndOfStream)
+				responseBody := s.director.HandleResponseBodyChunk(responseBodyCtx, reqCtx, v.ResponseBody.Body, v.ResponseBody.EndOfStream)
+				reqCtx.respBodyResp = s.generateResponseBodyResponses(responseBody, v.ResponseBody.EndOfStream)
 			} else {
 				body = append(body, v.ResponseBody.Body...)
+				// Outside of the full duplex mode, Envoy waits for a response to each message of a body streamed
+				// in several ones. They are let through unchanged, and the body is only read once complete.
+				partial := !s.streamsBodies() && len(body) > len(v.ResponseBody.Body)
+				if !s.streamsBodies() && !v.ResponseBody.EndOfStream {
+					reqCtx.respBodyResp = unchangedResponseBodyResponses()
+				}
 
 				// Message is buffered, we can read and decode.
 				if v.ResponseBody.EndOfStream {
@@ -291,11 +476,18 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 						} else {
 							logger.V(logutil.DEFAULT).Error(responseErr, "Error unmarshalling request body", "body", string(body))
 						}
-						reqCtx.respBodyResp = generateResponseBodyResponses(body, true)
+						if partial {
+							reqCtx.respBodyResp = unchangedResponseBodyResponses()
+						} else {
+							reqCtx.respBodyResp = s.generateResponseBodyResponses(body, true)
+						}
 						break
 					}
 
-					reqCtx, responseErr = s.HandleResponseBody(ctx, reqCtx, responseBody)
+					reqCtx, responseErr = s.HandleResponseBody(responseBodyCtx, reqCtx, responseBody)
+					if partial {
+						reqCtx.respBodyResp = unchangedResponseBodyResponses()
+					}
 					if responseErr != nil {
 						if logger.V(logutil.DEBUG).Enabled() {
 							logger.V(logutil.DEBUG).Error(responseErr, "Failed to process response body", "request", req)
@@ -311,6 +503,10 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 					}
 				}
 			}
+			if v.ResponseBody.EndOfStream {
+				responseBodySpan.End()
+				responseBodySpan = nil
+			}
 		case *extProcPb.ProcessingRequest_ResponseTrailers:
 			// This is currently unused.
 		}
@@ -339,6 +535,39 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 	}
 }
 
+// decodeRequestBody decodes the complete request body, and validates it if validation is enabled.
+func (s *StreamingServer) decodeRequestBody(ctx context.Context, reqCtx *RequestContext, body []byte) (err error) {
+	_, span := tracing.Tracer().Start(ctx, "StreamingServer.decodeRequestBody")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	if err := json.Unmarshal(body, &reqCtx.Request.Body); err != nil {
+		if log.FromContext(ctx).V(logutil.DEBUG).Enabled() {
+			return errutil.Error{Code: errutil.BadRequest, Msg: "Error unmarshaling request body: " + string(body)}
+		}
+		return errutil.Error{Code: errutil.BadRequest, Msg: "Error unmarshaling request body"}
+	}
+	return s.validateRequestBody(reqCtx.Request.Headers[requtil.PathHeaderKey], reqCtx.Request.Body)
+}
+
+// endRequestSpan sets the attributes of the request resolved by the Director and the status code
+// of the response on the span of the request, and ends it.
+func endRequestSpan(span trace.Span, reqCtx *RequestContext, err error) {
+	span.SetAttributes(
+		tracing.IncomingModelKey.String(reqCtx.IncomingModelName),
+		tracing.TargetModelKey.String(reqCtx.TargetModelName),
+		tracing.ObjectiveKey.String(reqCtx.ObjectiveKey),
+		tracing.EndpointKey.String(reqCtx.TargetEndpoint),
+	)
+	statusValue, ok := reqCtx.Response.Headers[":status"]
+	if !ok {
+		statusValue = reqCtx.Response.Headers["status"]
+	}
+	if statusCode, convErr := strconv.Atoi(statusValue); convErr == nil {
+		span.SetAttributes(tracing.ResponseStatusKey.Int(statusCode))
+	}
+	tracing.EndSpan(span, err)
+}
+
 // updateStateAndSendIfNeeded checks state and can send mutiple responses in a single pass, but only if ordered properly.
 // Order of requests matter in FULL_DUPLEX_STREAMING. For both request and response, the order of response sent back MUST be: Header->Body->Trailer, with trailer being optional.
 func (r *RequestContext) updateStateAndSendIfNeeded(srv extProcPb.ExternalProcessor_ProcessServer, logger logr.Logger) error {
@@ -351,6 +580,10 @@ func (r *RequestContext) updateStateAndSendIfNeeded(srv extProcPb.ExternalProces
 			return status.Errorf(codes.Unknown, "failed to send response back to Envoy: %v", err)
 		}
 		r.RequestState = HeaderRequestResponseComplete
+		if r.Request.HeadersOnly {
+			metrics.IncRunningRequests(r.IncomingModelName)
+			r.RequestRunning = true
+		}
 	}
 	if r.RequestState == HeaderRequestResponseComplete && r.reqBodyResp != nil && len(r.reqBodyResp) > 0 {
 		loggerTrace.Info("Sending request body response(s)")
@@ -361,8 +594,10 @@ func (r *RequestContext) updateStateAndSendIfNeeded(srv extProcPb.ExternalProces
 			}
 		}
 		r.RequestState = BodyRequestResponsesComplete
-		metrics.IncRunningRequests(r.IncomingModelName)
-		r.RequestRunning = true
+		if !r.RequestRunning {
+			metrics.IncRunningRequests(r.IncomingModelName)
+			r.RequestRunning = true
+		}
 		// Dump the response so a new stream message can begin
 		r.reqBodyResp = nil
 	}
@@ -387,7 +622,7 @@ func (r *RequestContext) updateStateAndSendIfNeeded(srv extProcPb.ExternalProces
 			}
 
 			body := response.Response.(*extProcPb.ProcessingResponse_ResponseBody)
-			if body.ResponseBody.Response.GetBodyMutation().GetStreamedResponse().GetEndOfStream() {
+			if body.ResponseBody.Response.GetBodyMutation().GetStreamedResponse().GetEndOfStream() || r.ResponseComplete {
 				r.RequestState = BodyResponseResponsesComplete
 			}
 		}
@@ -403,6 +638,38 @@ func (r *RequestContext) updateStateAndSendIfNeeded(srv extProcPb.ExternalProces
 	return nil
 }
 
+// validateRequestSize rejects request bodies larger than the configured limit, if any.
+func (s *StreamingServer) validateRequestSize(size int) error {
+	if s.validator == nil {
+		return nil
+	}
+	if err := s.validator.ValidateSize(size); err != nil {
+		return errutil.Error{Code: errutil.BadRequest, Msg: err.Error()}
+	}
+	return nil
+}
+
+// validationEndpoints maps the request kinds to the schemas validating them. The other kinds
+// are only checked against the limits.
+var validationEndpoints = map[schedulingtypes.RequestKind]validation.Endpoint{
+	schedulingtypes.RequestKindChatCompletions: validation.ChatCompletions,
+	schedulingtypes.RequestKindCompletions:     validation.Completions,
+	schedulingtypes.RequestKindEmbeddings:      validation.Embeddings,
+}
+
+// validateRequestBody rejects request bodies not matching their OpenAI schema or exceeding the
+// configured limits, if validation is enabled. The schema is selected by the request kind.
+func (s *StreamingServer) validateRequestBody(path string, body map[string]any) error {
+	if s.validator == nil {
+		return nil
+	}
+	endpoint := validationEndpoints[requtil.ExtractRequestKind(path, body)]
+	if err := s.validator.ValidateEndpoint(endpoint, body); err != nil {
+		return errutil.Error{Code: errutil.BadRequest, Msg: err.Error()}
+	}
+	return nil
+}
+
 func buildErrResponse(err error) (*extProcPb.ProcessingResponse, error) {
 	var resp *extProcPb.ProcessingResponse
 
@@ -419,6 +686,18 @@ func buildErrResponse(err error) (*extProcPb.ProcessingResponse, error) {
 				},
 			},
 		}
+	// This code is returned when the request exceeds the rate limit of its InferenceObjective.
+	case errutil.RateLimited:
+		resp = &extProcPb.ProcessingResponse{
+			Response: &extProcPb.ProcessingResponse_ImmediateResponse{
+				ImmediateResponse: &extProcPb.ImmediateResponse{
+					Status: &envoyTypePb.HttpStatus{
+						Code: envoyTypePb.StatusCode_TooManyRequests,
+					},
+					Headers: retryAfterHeader(err),
+				},
+			},
+		}
 	// This code can be returned by when EPP processes the request and run into server-side errors.
 	case errutil.Internal:
 		resp = &extProcPb.ProcessingResponse{
@@ -473,6 +752,21 @@ func buildErrResponse(err error) (*extProcPb.ProcessingResponse, error) {
 	return resp, nil
 }
 
+// retryAfterHeader returns the Retry-After header of a RateLimited error, in whole seconds rounded
+// up, or nil if the error has no retry delay.
+func retryAfterHeader(err error) *extProcPb.HeaderMutation {
+	var gatewayErr errutil.Error
+	if !errors.As(err, &gatewayErr) || gatewayErr.RetryAfter <= 0 {
+		return nil
+	}
+	seconds := int64(math.Ceil(gatewayErr.RetryAfter.Seconds()))
+	return &extProcPb.HeaderMutation{
+		SetHeaders: []*configPb.HeaderValueOption{
+			{Header: &configPb.HeaderValue{Key: "retry-after", RawValue: []byte(strconv.FormatInt(seconds, 10))}},
+		},
+	}
+}
+
 func buildCommonResponses(bodyBytes []byte, byteLimit int, setEos bool) []*extProcPb.CommonResponse {
 	responses := []*extProcPb.CommonResponse{}
 	startingIndex := 0
@@ -17,8 +17,28 @@ limitations under the License.
 package handlers
 
 import (
+	"context"
 	"crypto/rand"
+	"io"
 	"testing"
+	"time"
+
+	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	envoyTypePb "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/common/validation"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metadata"
+	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+	testutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/testing"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/tracing"
 )
 
 func TestBuildCommonResponses(t *testing.T) {
@@ -83,3 +103,355 @@ func generateBytes(count int) []byte {
 	_, _ = rand.Read(arr)
 	return arr
 }
+
+func TestValidateRequest(t *testing.T) {
+	validator, err := validation.NewValidator(validation.Limits{MaxBodyBytes: 100, MaxTokens: 512})
+	if err != nil {
+		t.Fatalf("NewValidator returned unexpected error: %v", err)
+	}
+	server := NewStreamingServer(nil, nil).WithRequestValidator(validator)
+
+	tests := []struct {
+		name     string
+		size     int
+		path     string
+		body     map[string]any
+		wantBody string
+	}{
+		{
+			name: "valid",
+			size: 100,
+			body: map[string]any{"model": "m", "prompt": "hi", "max_tokens": float64(512)},
+		},
+		{
+			name:     "oversized body",
+			size:     101,
+			wantBody: "inference gateway: BadRequest - invalid request: request body size exceeds the limit of 100 bytes",
+		},
+		{
+			name:     "invalid body",
+			size:     50,
+			body:     map[string]any{"model": "m", "messages": []any{map[string]any{"role": "robot"}}, "max_tokens": float64(1024)},
+			wantBody: "inference gateway: BadRequest - invalid request: max_tokens: must be less than or equal to 512; messages[0].role: should be one of [system developer user assistant tool function]",
+		},
+		{
+			name:     "schema selected by path",
+			size:     50,
+			path:     "/v1/embeddings",
+			body:     map[string]any{"model": "m", "prompt": "hi"},
+			wantBody: "inference gateway: BadRequest - invalid request: input: is required",
+		},
+		{
+			name: "responses request only checked against limits",
+			size: 50,
+			path: "/v1/responses",
+			body: map[string]any{"model": "m", "input": []any{map[string]any{"role": "robot", "content": "hi"}}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := server.validateRequestSize(test.size)
+			if err == nil {
+				err = server.validateRequestBody(test.path, test.body)
+			}
+			if test.wantBody == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Expected an error, got nil")
+			}
+			resp, err := buildErrResponse(err)
+			if err != nil {
+				t.Fatalf("buildErrResponse returned unexpected error: %v", err)
+			}
+			want := &extProcPb.ProcessingResponse{
+				Response: &extProcPb.ProcessingResponse_ImmediateResponse{
+					ImmediateResponse: &extProcPb.ImmediateResponse{
+						Status: &envoyTypePb.HttpStatus{Code: envoyTypePb.StatusCode_BadRequest},
+						Body:   []byte(test.wantBody),
+					},
+				},
+			}
+			if diff := cmp.Diff(want, resp, protocmp.Transform()); diff != "" {
+				t.Errorf("Unexpected response, diff(-want, +got): %v", diff)
+			}
+		})
+	}
+
+	// Validation is disabled without a validator.
+	if err := NewStreamingServer(nil, nil).validateRequestSize(1 << 30); err != nil {
+		t.Errorf("Expected no error without a validator, got %v", err)
+	}
+}
+
+func TestBuildErrResponseRateLimited(t *testing.T) {
+	resp, err := buildErrResponse(errutil.Error{Code: errutil.RateLimited, Msg: "requests rate limit of ns/chat exceeded", RetryAfter: 1500 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("buildErrResponse returned unexpected error: %v", err)
+	}
+	want := &extProcPb.ProcessingResponse{
+		Response: &extProcPb.ProcessingResponse_ImmediateResponse{
+			ImmediateResponse: &extProcPb.ImmediateResponse{
+				Status: &envoyTypePb.HttpStatus{Code: envoyTypePb.StatusCode_TooManyRequests},
+				Body:   []byte("inference gateway: RateLimited - requests rate limit of ns/chat exceeded"),
+				Headers: &extProcPb.HeaderMutation{
+					SetHeaders: []*configPb.HeaderValueOption{{
+						Header: &configPb.HeaderValue{Key: "retry-after", RawValue: []byte("2")},
+					}},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, resp, protocmp.Transform()); diff != "" {
+		t.Errorf("Unexpected response, diff(-want, +got): %v", diff)
+	}
+}
+
+func TestProcessingModes(t *testing.T) {
+	requestHeaders := func(headers map[string]string) *extProcPb.ProcessingRequest {
+		return &extProcPb.ProcessingRequest{Request: &extProcPb.ProcessingRequest_RequestHeaders{RequestHeaders: buildHeaders(headers)}}
+	}
+	requestBody := func(body string, endOfStream bool) *extProcPb.ProcessingRequest {
+		return &extProcPb.ProcessingRequest{Request: &extProcPb.ProcessingRequest_RequestBody{
+			RequestBody: &extProcPb.HttpBody{Body: []byte(body), EndOfStream: endOfStream},
+		}}
+	}
+	responseHeaders := func(headers map[string]string) *extProcPb.ProcessingRequest {
+		return &extProcPb.ProcessingRequest{Request: &extProcPb.ProcessingRequest_ResponseHeaders{ResponseHeaders: buildHeaders(headers)}}
+	}
+	responseBody := func(body string, endOfStream bool) *extProcPb.ProcessingRequest {
+		return &extProcPb.ProcessingRequest{Request: &extProcPb.ProcessingRequest_ResponseBody{
+			ResponseBody: &extProcPb.HttpBody{Body: []byte(body), EndOfStream: endOfStream},
+		}}
+	}
+	immediateResponse := func(err error) *extProcPb.ProcessingResponse {
+		return &extProcPb.ProcessingResponse{Response: &extProcPb.ProcessingResponse_ImmediateResponse{
+			ImmediateResponse: &extProcPb.ImmediateResponse{
+				Status: &envoyTypePb.HttpStatus{Code: envoyTypePb.StatusCode_BadRequest},
+				Body:   []byte(err.Error()),
+			},
+		}}
+	}
+	unchangedResponseBody := &extProcPb.ProcessingResponse{Response: &extProcPb.ProcessingResponse_ResponseBody{ResponseBody: &extProcPb.BodyResponse{}}}
+	destination := (&StreamingServer{}).generateMetadata(testEndpoint)
+
+	tests := []struct {
+		name          string
+		mode          ProcessingMode
+		requests      []*extProcPb.ProcessingRequest
+		wantResponses []*extProcPb.ProcessingResponse
+	}{
+		{
+			name: "buffered",
+			mode: Buffered,
+			requests: []*extProcPb.ProcessingRequest{
+				requestHeaders(map[string]string{":path": "/v1/completions", "content-length": "38"}),
+				requestBody(`{"model":"food-review","prompt":"hi"}`, true),
+				responseHeaders(map[string]string{":status": "200"}),
+				responseBody(`{"id":"1"}`, true),
+			},
+			wantResponses: []*extProcPb.ProcessingResponse{
+				{Response: &extProcPb.ProcessingResponse_RequestHeaders{RequestHeaders: &extProcPb.HeadersResponse{}}},
+				{
+					Response: &extProcPb.ProcessingResponse_RequestBody{RequestBody: &extProcPb.BodyResponse{
+						Response: &extProcPb.CommonResponse{
+							ClearRouteCache: true,
+							HeaderMutation: &extProcPb.HeaderMutation{SetHeaders: []*configPb.HeaderValueOption{
+								{Header: &configPb.HeaderValue{Key: metadata.DestinationEndpointKey, RawValue: []byte(testEndpoint)}},
+								{Header: &configPb.HeaderValue{Key: "Content-Length", RawValue: []byte("28")}},
+								{Header: &configPb.HeaderValue{Key: ":path", RawValue: []byte("/v1/completions")}},
+							}},
+							BodyMutation: &extProcPb.BodyMutation{Mutation: &extProcPb.BodyMutation_Body{Body: []byte(`{"model":"v1","prompt":"hi"}`)}},
+						},
+					}},
+					DynamicMetadata: destination,
+				},
+				{Response: &extProcPb.ProcessingResponse_ResponseHeaders{ResponseHeaders: &extProcPb.HeadersResponse{
+					Response: &extProcPb.CommonResponse{HeaderMutation: &extProcPb.HeaderMutation{SetHeaders: []*configPb.HeaderValueOption{
+						{Header: &configPb.HeaderValue{Key: ":status", RawValue: []byte("200")}},
+					}}},
+				}}},
+				{Response: &extProcPb.ProcessingResponse_ResponseBody{ResponseBody: &extProcPb.BodyResponse{
+					Response: &extProcPb.CommonResponse{BodyMutation: &extProcPb.BodyMutation{Mutation: &extProcPb.BodyMutation_Body{Body: []byte(`{"id":"1"}`)}}},
+				}}},
+			},
+		},
+		{
+			name: "buffered partial body exceeding the buffer limit",
+			mode: BufferedPartial,
+			requests: []*extProcPb.ProcessingRequest{
+				requestHeaders(map[string]string{":path": "/v1/completions"}),
+				requestBody(`{"model":"food-review","prompt":"h`, false),
+			},
+			wantResponses: []*extProcPb.ProcessingResponse{
+				{Response: &extProcPb.ProcessingResponse_RequestHeaders{RequestHeaders: &extProcPb.HeadersResponse{}}},
+				immediateResponse(errutil.Error{Code: errutil.BadRequest, Msg: "request body exceeds the buffer limit of the gateway"}),
+			},
+		},
+		{
+			name: "headers only, with skipped response headers",
+			mode: HeadersOnly,
+			requests: []*extProcPb.ProcessingRequest{
+				requestHeaders(map[string]string{metadata.ModelNameKey: "food-review"}),
+				requestBody(`{"model":"food-review","prompt":"hi"}`, true),
+				responseBody(`{"id":`, false),
+				responseBody(`"1"}`, true),
+			},
+			wantResponses: []*extProcPb.ProcessingResponse{
+				{
+					Response: &extProcPb.ProcessingResponse_RequestHeaders{RequestHeaders: &extProcPb.HeadersResponse{
+						Response: &extProcPb.CommonResponse{
+							ClearRouteCache: true,
+							HeaderMutation: &extProcPb.HeaderMutation{SetHeaders: []*configPb.HeaderValueOption{
+								{Header: &configPb.HeaderValue{Key: metadata.DestinationEndpointKey, RawValue: []byte(testEndpoint)}},
+								{Header: &configPb.HeaderValue{Key: metadata.ModelNameKey, RawValue: []byte("food-review")}},
+							}},
+						},
+					}},
+					DynamicMetadata: destination,
+				},
+				{Response: &extProcPb.ProcessingResponse_RequestBody{RequestBody: &extProcPb.BodyResponse{}}},
+				unchangedResponseBody,
+				unchangedResponseBody,
+			},
+		},
+		{
+			name: "headers only without model name header",
+			mode: HeadersOnly,
+			requests: []*extProcPb.ProcessingRequest{
+				requestHeaders(map[string]string{":path": "/v1/completions"}),
+			},
+			wantResponses: []*extProcPb.ProcessingResponse{
+				immediateResponse(errutil.Error{Code: errutil.BadRequest, Msg: metadata.ModelNameKey + " header not found in request"}),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := logutil.NewTestLoggerIntoContext(context.Background())
+			director := &testDirector{}
+			server := NewStreamingServer(&fakeDatastore{}, director).WithProcessingMode(test.mode)
+			stream := &fakeProcessServer{ctx: ctx, requests: test.requests}
+
+			if err := server.Process(stream); err != nil {
+				t.Fatalf("Process returned unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.wantResponses, stream.responses, protocmp.Transform(),
+				protocmp.SortRepeated(func(a, b *configPb.HeaderValueOption) bool { return a.Header.Key < b.Header.Key })); diff != "" {
+				t.Errorf("Unexpected responses (-want +got): %s", diff)
+			}
+			if test.mode == HeadersOnly && director.request != nil && !director.request.HeadersOnly {
+				t.Error("Request scheduled with a body in the headers-only mode")
+			}
+		})
+	}
+}
+
+func TestProcessTracing(t *testing.T) {
+	recorder, restore := testutil.NewTestSpanRecorder()
+	defer restore()
+
+	const traceID = "0af7651916cd43dd8448eb211c80319c"
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+	director := &testDirector{}
+	server := NewStreamingServer(&fakeDatastore{}, director).WithProcessingMode(Buffered)
+	stream := &fakeProcessServer{ctx: ctx, requests: []*extProcPb.ProcessingRequest{
+		{Request: &extProcPb.ProcessingRequest_RequestHeaders{RequestHeaders: buildHeaders(map[string]string{
+			":path":       "/v1/completions",
+			"traceparent": "00-" + traceID + "-b7ad6b7169203331-01",
+		})}},
+		{Request: &extProcPb.ProcessingRequest_RequestBody{RequestBody: &extProcPb.HttpBody{
+			Body: []byte(`{"model":"food-review","prompt":"hi"}`), EndOfStream: true,
+		}}},
+		{Request: &extProcPb.ProcessingRequest_ResponseHeaders{ResponseHeaders: buildHeaders(map[string]string{":status": "200"})}},
+		{Request: &extProcPb.ProcessingRequest_ResponseBody{ResponseBody: &extProcPb.HttpBody{Body: []byte(`{"id":"1"}`), EndOfStream: true}}},
+	}}
+
+	require.NoError(t, server.Process(stream))
+
+	spans := map[string]trace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	require.Contains(t, spans, "StreamingServer.Process")
+	request := spans["StreamingServer.Process"]
+	assert.Equal(t, traceID, request.SpanContext().TraceID().String())
+	assert.Equal(t, "b7ad6b7169203331", request.Parent().SpanID().String())
+	assert.Contains(t, request.Attributes(), tracing.EndpointKey.String(testEndpoint))
+	assert.Contains(t, request.Attributes(), tracing.ResponseStatusKey.Int(200))
+	for _, name := range []string{"StreamingServer.decodeRequestBody", "StreamingServer.HandleResponseHeaders", "StreamingServer.HandleResponseBody"} {
+		require.Contains(t, spans, name)
+		assert.Equal(t, request.SpanContext().SpanID(), spans[name].Parent().SpanID(), "parent of %s", name)
+	}
+
+	// The model server continues the trace from the span of the request.
+	wantTraceparent := "00-" + traceID + "-" + request.SpanContext().SpanID().String() + "-01"
+	assert.Equal(t, wantTraceparent, director.request.Headers["traceparent"])
+}
+
+func buildHeaders(headers map[string]string) *extProcPb.HttpHeaders {
+	values := []*configPb.HeaderValue{}
+	for key, value := range headers {
+		values = append(values, &configPb.HeaderValue{Key: key, RawValue: []byte(value)})
+	}
+	return &extProcPb.HttpHeaders{Headers: &configPb.HeaderMap{Headers: values}}
+}
+
+// fakeProcessServer plays the given requests and records the responses.
+type fakeProcessServer struct {
+	extProcPb.ExternalProcessor_ProcessServer
+	ctx       context.Context
+	requests  []*extProcPb.ProcessingRequest
+	responses []*extProcPb.ProcessingResponse
+}
+
+func (s *fakeProcessServer) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeProcessServer) Recv() (*extProcPb.ProcessingRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+	return req, nil
+}
+
+func (s *fakeProcessServer) Send(resp *extProcPb.ProcessingResponse) error {
+	s.responses = append(s.responses, resp)
+	return nil
+}
+
+const testEndpoint = "1.2.3.4:5678"
+
+// testDirector routes every request to the test endpoint, and passes the response through unchanged.
+type testDirector struct {
+	request *Request
+}
+
+func (d *testDirector) HandleRequest(_ context.Context, reqCtx *RequestContext) (*RequestContext, error) {
+	d.request = reqCtx.Request
+	reqCtx.Request.Body["model"] = "v1"
+	reqCtx.TargetEndpoint = testEndpoint
+	return reqCtx, nil
+}
+
+func (d *testDirector) HandleResponse(_ context.Context, reqCtx *RequestContext) (*RequestContext, error) {
+	return reqCtx, nil
+}
+
+func (d *testDirector) HandleResponseBodyChunk(_ context.Context, _ *RequestContext, body []byte, _ bool) []byte {
+	return body
+}
+
+func (d *testDirector) HandleStreamEnd(_ context.Context, _ *RequestContext, _ bool) {
+}
+
+func (d *testDirector) GetRandomPod() *backend.Pod {
+	return nil
+}
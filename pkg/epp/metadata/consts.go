@@ -32,4 +32,7 @@ const (
 	ObjectiveKey = "x-gateway-inference-objective"
 	// ModelNameRewriteKey is the header key used to specify the model name to be used when the request is forwarded to the model server.
 	ModelNameRewriteKey = "x-gateway-model-name-rewrite"
+	// ModelNameKey is the header key set by Body-Based Routing with the model name of the request. It is read in
+	// the headers-only processing mode, where the request body isn't sent to the EPP.
+	ModelNameKey = "x-gateway-model-name"
 )
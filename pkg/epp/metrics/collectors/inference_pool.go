@@ -17,6 +17,8 @@ limitations under the License.
 package collectors
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	compbasemetrics "k8s.io/component-base/metrics"
 
@@ -34,6 +36,16 @@ var (
 			"model_server_pod",
 		}, nil,
 	)
+
+	descInferencePoolPerPodMetricsStaleness = prometheus.NewDesc(
+		"inference_pool_per_pod_metrics_staleness_seconds",
+		metricsutil.HelpMsgWithStability("The time in seconds since the model server metrics of each underlying pod were last refreshed.", compbasemetrics.ALPHA),
+		[]string{
+			"name",
+			"model_server_namespace",
+			"model_server_pod",
+		}, nil,
+	)
 )
 
 type inferencePoolMetricsCollector struct {
@@ -54,6 +66,7 @@ func NewInferencePoolMetricsCollector(ds datastore.Datastore) prometheus.Collect
 // DescribeWithStability implements the prometheus.Collector interface.
 func (c *inferencePoolMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- descInferencePoolPerPodQueueSize
+	ch <- descInferencePoolPerPodMetricsStaleness
 }
 
 // CollectWithStability implements the prometheus.Collector interface.
@@ -69,12 +82,24 @@ func (c *inferencePoolMetricsCollector) Collect(ch chan<- prometheus.Metric) {
 	}
 
 	for _, pod := range podMetrics {
+		metrics := pod.GetMetrics()
 		ch <- prometheus.MustNewConstMetric(
 			descInferencePoolPerPodQueueSize,
 			prometheus.GaugeValue,
-			float64(pod.GetMetrics().WaitingQueueSize),
+			float64(metrics.WaitingQueueSize),
 			pool.Name,
 			pod.GetPod().NamespacedName.Name,
 		)
+		// Pods that were never successfully scraped have no meaningful staleness.
+		if !metrics.UpdateTime.IsZero() {
+			ch <- prometheus.MustNewConstMetric(
+				descInferencePoolPerPodMetricsStaleness,
+				prometheus.GaugeValue,
+				time.Since(metrics.UpdateTime).Seconds(),
+				pool.Name,
+				pod.GetPod().NamespacedName.Namespace,
+				pod.GetPod().NamespacedName.Name,
+			)
+		}
 	}
 }
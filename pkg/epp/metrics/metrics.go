@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
 	compbasemetrics "k8s.io/component-base/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
@@ -226,6 +227,84 @@ var (
 		[]string{},
 	)
 
+	// Model Server Scrape Metrics
+	modelServerScrapeLatencies = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: InferenceExtension,
+			Name:      "model_server_scrape_duration_seconds",
+			Help:      metricsutil.HelpMsgWithStability("Model server metrics scrape latency distribution in seconds for each pod.", compbasemetrics.ALPHA),
+			Buckets: []float64{
+				0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10,
+			},
+		},
+		[]string{"model_server_namespace", "model_server_pod"},
+	)
+
+	modelServerScrapeErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: InferenceExtension,
+			Name:      "model_server_scrape_errors_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of model server metrics scrape errors broken out for each pod and reason.", compbasemetrics.ALPHA),
+		},
+		[]string{"model_server_namespace", "model_server_pod", "reason"},
+	)
+
+	modelServerScrapesSkipped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: InferenceExtension,
+			Name:      "model_server_scrapes_skipped_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of model server metrics scrapes skipped broken out for each pod and reason.", compbasemetrics.ALPHA),
+		},
+		[]string{"model_server_namespace", "model_server_pod", "reason"},
+	)
+
+	// Outlier Detection Metrics
+	outlierDetectionEjected = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: InferenceExtension,
+			Name:      "outlier_detection_ejected",
+			Help:      metricsutil.HelpMsgWithStability("Whether each pod is currently ejected from scheduling by outlier detection (1=ejected, 0=not ejected).", compbasemetrics.ALPHA),
+		},
+		[]string{"model_server_namespace", "model_server_pod"},
+	)
+
+	outlierDetectionEjections = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: InferenceExtension,
+			Name:      "outlier_detection_ejections_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of pod ejections by outlier detection broken out for each pod and reason.", compbasemetrics.ALPHA),
+		},
+		[]string{"model_server_namespace", "model_server_pod", "reason"},
+	)
+
+	outlierDetectionEjectionsOverflow = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: InferenceExtension,
+			Name:      "outlier_detection_ejections_overflow_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of pod ejections by outlier detection that were not enforced because the maximum ejection percentage was reached.", compbasemetrics.ALPHA),
+		},
+		[]string{},
+	)
+
+	// Config Metrics
+	configVersion = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: InferenceExtension,
+			Name:      "config_version",
+			Help:      metricsutil.HelpMsgWithStability("Version of the EndpointPickerConfig in use, starting at 1 and incremented by each reload, with the hash of its content.", compbasemetrics.ALPHA),
+		},
+		[]string{"hash"},
+	)
+
+	configReloads = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: InferenceExtension,
+			Name:      "config_reloads_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of reloads of the EndpointPickerConfig broken out by result.", compbasemetrics.ALPHA),
+		},
+		[]string{"result"},
+	)
+
 	// Info Metrics
 	InferenceExtensionInfo = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -260,6 +339,14 @@ func Register(customCollectors ...prometheus.Collector) {
 		metrics.Registry.MustRegister(PrefixCacheSize)
 		metrics.Registry.MustRegister(PrefixCacheHitRatio)
 		metrics.Registry.MustRegister(PrefixCacheHitLength)
+		metrics.Registry.MustRegister(modelServerScrapeLatencies)
+		metrics.Registry.MustRegister(modelServerScrapeErrors)
+		metrics.Registry.MustRegister(modelServerScrapesSkipped)
+		metrics.Registry.MustRegister(outlierDetectionEjected)
+		metrics.Registry.MustRegister(outlierDetectionEjections)
+		metrics.Registry.MustRegister(outlierDetectionEjectionsOverflow)
+		metrics.Registry.MustRegister(configVersion)
+		metrics.Registry.MustRegister(configReloads)
 		for _, collector := range customCollectors {
 			metrics.Registry.MustRegister(collector)
 		}
@@ -286,6 +373,14 @@ func Reset() {
 	PrefixCacheSize.Reset()
 	PrefixCacheHitRatio.Reset()
 	PrefixCacheHitLength.Reset()
+	modelServerScrapeLatencies.Reset()
+	modelServerScrapeErrors.Reset()
+	modelServerScrapesSkipped.Reset()
+	outlierDetectionEjected.Reset()
+	outlierDetectionEjections.Reset()
+	outlierDetectionEjectionsOverflow.Reset()
+	configVersion.Reset()
+	configReloads.Reset()
 }
 
 // RecordRequstCounter records the number of requests.
@@ -411,6 +506,68 @@ func RecordPrefixCacheMatch(matchedLength, totalLength int) {
 	}
 }
 
+// RecordModelServerScrapeLatency records the latency of a model server metrics scrape.
+func RecordModelServerScrapeLatency(pod types.NamespacedName, duration time.Duration) {
+	modelServerScrapeLatencies.WithLabelValues(pod.Namespace, pod.Name).Observe(duration.Seconds())
+}
+
+// RecordModelServerScrapeError records a failed or partially failed model server metrics scrape.
+func RecordModelServerScrapeError(pod types.NamespacedName, reason string) {
+	modelServerScrapeErrors.WithLabelValues(pod.Namespace, pod.Name, reason).Inc()
+}
+
+// RecordModelServerScrapeSkipped records a model server metrics scrape that was skipped.
+func RecordModelServerScrapeSkipped(pod types.NamespacedName, reason string) {
+	modelServerScrapesSkipped.WithLabelValues(pod.Namespace, pod.Name, reason).Inc()
+}
+
+// DeleteModelServerScrapeMetrics removes the scrape metrics series of a pod that is no longer scraped.
+func DeleteModelServerScrapeMetrics(pod types.NamespacedName) {
+	labels := prometheus.Labels{"model_server_namespace": pod.Namespace, "model_server_pod": pod.Name}
+	modelServerScrapeLatencies.DeletePartialMatch(labels)
+	modelServerScrapeErrors.DeletePartialMatch(labels)
+	modelServerScrapesSkipped.DeletePartialMatch(labels)
+}
+
+// RecordOutlierEjection records the ejection of a pod by outlier detection.
+func RecordOutlierEjection(pod types.NamespacedName, reason string) {
+	outlierDetectionEjections.WithLabelValues(pod.Namespace, pod.Name, reason).Inc()
+	outlierDetectionEjected.WithLabelValues(pod.Namespace, pod.Name).Set(1)
+}
+
+// RecordOutlierUnejection records the return of an ejected pod to scheduling.
+func RecordOutlierUnejection(pod types.NamespacedName) {
+	outlierDetectionEjected.WithLabelValues(pod.Namespace, pod.Name).Set(0)
+}
+
+// RecordOutlierEjectionOverflow records an ejection that was not enforced because of the maximum ejection percentage.
+func RecordOutlierEjectionOverflow() {
+	outlierDetectionEjectionsOverflow.WithLabelValues().Inc()
+}
+
+// DeleteOutlierDetectionMetrics removes the outlier detection metrics series of a pod that is no longer in the pool.
+func DeleteOutlierDetectionMetrics(pod types.NamespacedName) {
+	labels := prometheus.Labels{"model_server_namespace": pod.Namespace, "model_server_pod": pod.Name}
+	outlierDetectionEjected.DeletePartialMatch(labels)
+	outlierDetectionEjections.DeletePartialMatch(labels)
+}
+
+// RecordConfigVersion records the version and the hash of the EndpointPickerConfig in use.
+func RecordConfigVersion(version int, hash string) {
+	configVersion.Reset()
+	configVersion.WithLabelValues(hash).Set(float64(version))
+}
+
+// RecordConfigReload records a reload of the EndpointPickerConfig, which either succeeded or was
+// rejected.
+func RecordConfigReload(success bool) {
+	result := "success"
+	if !success {
+		result = "failure"
+	}
+	configReloads.WithLabelValues(result).Inc()
+}
+
 func RecordInferenceExtensionInfo(commitSha, buildRef string) {
 	InferenceExtensionInfo.WithLabelValues(commitSha, buildRef).Set(1)
 }
@@ -19,6 +19,7 @@ package metrics
 import (
 	"context"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -768,3 +769,26 @@ func TestPrefixCacheMetrics(t *testing.T) {
 		}
 	})
 }
+
+func TestConfigMetrics(t *testing.T) {
+	Register()
+	Reset()
+	RecordConfigVersion(1, "first")
+	RecordConfigReload(false)
+	RecordConfigReload(true)
+	RecordConfigVersion(2, "second")
+
+	want := `
+# HELP inference_extension_config_reloads_total [ALPHA] Counter of reloads of the EndpointPickerConfig broken out by result.
+# TYPE inference_extension_config_reloads_total counter
+inference_extension_config_reloads_total{result="failure"} 1
+inference_extension_config_reloads_total{result="success"} 1
+# HELP inference_extension_config_version [ALPHA] Version of the EndpointPickerConfig in use, starting at 1 and incremented by each reload, with the hash of its content.
+# TYPE inference_extension_config_version gauge
+inference_extension_config_version{hash="second"} 2
+`
+	if err := testutil.GatherAndCompare(metrics.Registry, strings.NewReader(want),
+		InferenceExtension+"_config_version", InferenceExtension+"_config_reloads_total"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectives
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	"sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/common"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// pendingConditionType is the condition type of the default condition set on the objectives
+// created before the Accepted condition was managed.
+const pendingConditionType = "Ready"
+
+// StatusUpdater periodically writes the status of the InferenceObjectives of the pool namespace.
+//
+// The objectives referencing the pool of the EPP get Accepted and ResolvedRefs conditions, their
+// last request time, and their latency attainment when they set latency targets. The objectives
+// referencing a pool which doesn't exist get False conditions. The objectives referencing another
+// existing pool are left to the EPP of that pool.
+type StatusUpdater struct {
+	client client.Client
+	// reader reads the pools from the API server, since the cache only holds the pool of the EPP.
+	reader   client.Reader
+	pool     common.GKNN
+	tracker  *Tracker
+	interval time.Duration
+}
+
+// NewStatusUpdater returns a StatusUpdater writing the status of the objectives of the pool
+// namespace at the given interval.
+func NewStatusUpdater(client client.Client, reader client.Reader, pool common.GKNN, tracker *Tracker, interval time.Duration) *StatusUpdater {
+	return &StatusUpdater{
+		client:   client,
+		reader:   reader,
+		pool:     pool,
+		tracker:  tracker,
+		interval: interval,
+	}
+}
+
+// Start implements the controller-runtime manager.Runnable interface.
+func (u *StatusUpdater) Start(ctx context.Context) error {
+	ticker := time.NewTicker(u.interval)
+	defer ticker.Stop()
+	for {
+		u.sync(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// sync writes the status of the objectives of the pool namespace.
+func (u *StatusUpdater) sync(ctx context.Context) {
+	logger := log.FromContext(ctx)
+	list := &v1alpha2.InferenceObjectiveList{}
+	if err := u.client.List(ctx, list, client.InNamespace(u.pool.Namespace)); err != nil {
+		logger.V(logutil.DEFAULT).Error(err, "Failed to list the InferenceObjectives")
+		return
+	}
+	now := time.Now()
+	names := []string{}
+	for i := range list.Items {
+		objective := &list.Items[i]
+		if !objective.DeletionTimestamp.IsZero() {
+			continue
+		}
+		served := u.referencesPool(objective)
+		if served {
+			names = append(names, objective.Name)
+		}
+		found, err := u.poolExists(ctx, objective.Spec.PoolRef)
+		if err != nil {
+			logger.V(logutil.DEFAULT).Error(err, "Failed to get the pool of the InferenceObjective", "objective", objective.Name)
+			continue
+		}
+		updated := objective.DeepCopy()
+		switch {
+		case !found:
+			setPoolNotFound(updated)
+		case served:
+			u.setServed(updated, now)
+		default:
+			// The pool is handled by a different EPP.
+			continue
+		}
+		if equality.Semantic.DeepEqual(objective.Status, updated.Status) {
+			continue
+		}
+		if err := u.client.Status().Patch(ctx, updated, client.MergeFrom(objective)); err != nil {
+			logger.V(logutil.DEFAULT).Error(err, "Failed to update the status of the InferenceObjective", "objective", objective.Name)
+		}
+	}
+	u.tracker.Retain(names)
+}
+
+// referencesPool returns true if the objective references the pool of the EPP.
+func (u *StatusUpdater) referencesPool(objective *v1alpha2.InferenceObjective) bool {
+	ref := objective.Spec.PoolRef
+	return string(ref.Name) == u.pool.Name && string(ref.Group) == u.pool.Group
+}
+
+// poolExists returns true if the pool referenced by an objective exists.
+func (u *StatusUpdater) poolExists(ctx context.Context, ref v1alpha2.PoolObjectReference) (bool, error) {
+	var pool client.Object
+	switch string(ref.Group) {
+	case v1.GroupName:
+		pool = &v1.InferencePool{}
+	case v1alpha2.GroupName:
+		pool = &v1alpha2.InferencePool{}
+	default:
+		return false, nil
+	}
+	err := u.reader.Get(ctx, types.NamespacedName{Namespace: u.pool.Namespace, Name: string(ref.Name)}, pool)
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// setServed sets the status of an objective served by the EPP.
+func (u *StatusUpdater) setServed(objective *v1alpha2.InferenceObjective, now time.Time) {
+	setConditions(objective, metav1.ConditionTrue, v1alpha2.ObjectiveReasonAccepted, "Accepted by the Endpoint Picker",
+		metav1.ConditionTrue, v1alpha2.ObjectiveReasonResolvedRefs, fmt.Sprintf("InferencePool %s resolved", u.pool.Name))
+
+	if last, ok := u.tracker.LastRequestTime(objective.Name); ok {
+		// The time is serialized with a second precision.
+		lastRequestTime := metav1.NewTime(last.Truncate(time.Second))
+		objective.Status.LastRequestTime = &lastRequestTime
+	}
+
+	target := LatencyObjective(objective)
+	if target == nil {
+		objective.Status.Latency = nil
+	} else if attainment := u.tracker.Attainment(objective.Name, target, now); attainment != nil {
+		objective.Status.Latency = attainment
+	}
+	// Otherwise keep the last attainment until new requests are served.
+}
+
+// setPoolNotFound sets the status of an objective referencing a pool which doesn't exist.
+func setPoolNotFound(objective *v1alpha2.InferenceObjective) {
+	message := fmt.Sprintf("InferencePool %s not found", objective.Spec.PoolRef.Name)
+	setConditions(objective, metav1.ConditionFalse, v1alpha2.ObjectiveReasonPoolNotFound, message,
+		metav1.ConditionFalse, v1alpha2.ObjectiveReasonPoolNotFound, message)
+}
+
+// setConditions sets the Accepted and ResolvedRefs conditions of an objective, and removes the
+// pending default condition.
+func setConditions(objective *v1alpha2.InferenceObjective,
+	accepted metav1.ConditionStatus, acceptedReason v1alpha2.InferenceObjectiveConditionReason, acceptedMessage string,
+	resolved metav1.ConditionStatus, resolvedReason v1alpha2.InferenceObjectiveConditionReason, resolvedMessage string) {
+	meta.RemoveStatusCondition(&objective.Status.Conditions, pendingConditionType)
+	meta.SetStatusCondition(&objective.Status.Conditions, metav1.Condition{
+		Type:               string(v1alpha2.ObjectiveConditionAccepted),
+		Status:             accepted,
+		Reason:             string(acceptedReason),
+		Message:            acceptedMessage,
+		ObservedGeneration: objective.Generation,
+	})
+	meta.SetStatusCondition(&objective.Status.Conditions, metav1.Condition{
+		Type:               string(v1alpha2.ObjectiveConditionResolvedRefs),
+		Status:             resolved,
+		Reason:             string(resolvedReason),
+		Message:            resolvedMessage,
+		ObservedGeneration: objective.Generation,
+	})
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectives
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	"sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/common"
+	utiltest "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/testing"
+)
+
+func TestStatusUpdaterSync(t *testing.T) {
+	pool := common.GKNN{
+		NamespacedName: types.NamespacedName{Namespace: "ns", Name: "pool"},
+		GroupKind:      schema.GroupKind{Group: v1.GroupName, Kind: "InferencePool"},
+	}
+	objective := func(name, poolName string) *utiltest.InferenceObjectiveWrapper {
+		return utiltest.MakeInferenceObjective(name).Namespace("ns").PoolName(poolName).PoolGroup(v1.GroupName)
+	}
+	withTargets := objective("chat", "pool").Latency(time.Second, 50*time.Millisecond, 90).ObjRef()
+	idle := objective("idle", "pool").Latency(time.Second, 50*time.Millisecond, 90).ObjRef()
+	idle.Status.Conditions = []metav1.Condition{{Type: pendingConditionType, Status: metav1.ConditionUnknown, Reason: string(v1alpha2.ObjectiveReasonPending)}}
+	withoutTargets := objective("batch", "pool").ObjRef()
+	withoutTargets.Status.Latency = &v1alpha2.LatencyAttainment{Met: true, SampleCount: 3}
+	missingPool := objective("missing", "other-pool").ObjRef()
+	otherPool := objective("other", "pool2").ObjRef()
+
+	scheme := runtime.NewScheme()
+	_ = v1alpha2.Install(scheme)
+	_ = v1.Install(scheme)
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(withTargets, idle, withoutTargets, missingPool, otherPool,
+			utiltest.MakeInferencePool("pool").Namespace("ns").ObjRef(),
+			utiltest.MakeInferencePool("pool2").Namespace("ns").ObjRef()).
+		WithStatusSubresource(&v1alpha2.InferenceObjective{}).
+		Build()
+
+	tracker := NewTracker(DefaultWindowSize)
+	tracker.RecordRequest("chat")
+	tracker.RecordLatency("chat", 200*time.Millisecond, 10*time.Millisecond)
+	tracker.RecordRequest("removed")
+	tracker.RecordLatency("removed", 200*time.Millisecond, 10*time.Millisecond)
+	NewStatusUpdater(fakeClient, fakeClient, pool, tracker, time.Second).sync(context.Background())
+
+	get := func(name string) *v1alpha2.InferenceObjective {
+		objective := &v1alpha2.InferenceObjective{}
+		if err := fakeClient.Get(context.Background(), client.ObjectKey(types.NamespacedName{Namespace: "ns", Name: name}), objective); err != nil {
+			t.Fatalf("Failed to get the InferenceObjective %s: %v", name, err)
+		}
+		return objective
+	}
+	assertConditions := func(objective *v1alpha2.InferenceObjective, status metav1.ConditionStatus, reason v1alpha2.InferenceObjectiveConditionReason) {
+		t.Helper()
+		for _, conditionType := range []v1alpha2.InferenceObjectiveConditionType{v1alpha2.ObjectiveConditionAccepted, v1alpha2.ObjectiveConditionResolvedRefs} {
+			condition := meta.FindStatusCondition(objective.Status.Conditions, string(conditionType))
+			if condition == nil || condition.Status != status {
+				t.Errorf("Unexpected %s condition of %s: %+v", conditionType, objective.Name, condition)
+			}
+		}
+		if condition := meta.FindStatusCondition(objective.Status.Conditions, string(v1alpha2.ObjectiveConditionAccepted)); condition != nil && condition.Reason != string(reason) {
+			t.Errorf("Unexpected Accepted reason of %s: %s", objective.Name, condition.Reason)
+		}
+	}
+
+	chat := get("chat")
+	assertConditions(chat, metav1.ConditionTrue, v1alpha2.ObjectiveReasonAccepted)
+	if latency := chat.Status.Latency; latency == nil || !latency.Met || latency.SampleCount != 1 {
+		t.Errorf("Unexpected attainment of the objective with latency targets: %+v", latency)
+	}
+	if chat.Status.LastRequestTime == nil {
+		t.Error("Expected the last request time to be set")
+	}
+
+	idle = get("idle")
+	assertConditions(idle, metav1.ConditionTrue, v1alpha2.ObjectiveReasonAccepted)
+	if meta.FindStatusCondition(idle.Status.Conditions, pendingConditionType) != nil {
+		t.Error("Expected the pending condition to be removed")
+	}
+	if idle.Status.Latency != nil || idle.Status.LastRequestTime != nil {
+		t.Errorf("Expected no traffic for the objective without requests, got %+v", idle.Status)
+	}
+
+	if latency := get("batch").Status.Latency; latency != nil {
+		t.Errorf("Expected the attainment to be cleared for the objective without latency targets, got %+v", latency)
+	}
+	assertConditions(get("missing"), metav1.ConditionFalse, v1alpha2.ObjectiveReasonPoolNotFound)
+	if conditions := get("other").Status.Conditions; len(conditions) != 0 {
+		t.Errorf("Expected the objective of another pool to be left untouched, got %+v", conditions)
+	}
+	if _, ok := tracker.LastRequestTime("removed"); ok {
+		t.Error("Expected the removed objective to be forgotten")
+	}
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package objectives tracks the traffic of the InferenceObjectives and manages their status.
+//
+// The Tracker records the last request time of each objective, and the time to first token and
+// the time per output token of its recent requests. The StatusUpdater periodically writes the
+// conditions of the objectives, their last request time and their latency attainment to their
+// status.
+package objectives
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
+	schedulingtypes "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+)
+
+const (
+	// DefaultPercentile is the percentile of the latency objectives which don't set one.
+	DefaultPercentile = 90
+	// DefaultWindowSize is the default number of recent requests the attainment is computed from.
+	DefaultWindowSize = 1000
+)
+
+// LatencyObjective returns the latency targets of the given InferenceObjective, or nil if it has
+// none.
+func LatencyObjective(objective *v1alpha2.InferenceObjective) *schedulingtypes.LatencyObjective {
+	if objective == nil || objective.Spec.Latency == nil {
+		return nil
+	}
+	latency := objective.Spec.Latency
+	target := &schedulingtypes.LatencyObjective{Percentile: DefaultPercentile}
+	if latency.TargetTTFT != nil {
+		target.TTFT = latency.TargetTTFT.Duration
+	}
+	if latency.TargetTPOT != nil {
+		target.TPOT = latency.TargetTPOT.Duration
+	}
+	if latency.Percentile != nil {
+		target.Percentile = int(*latency.Percentile)
+	}
+	return target
+}
+
+// Tracker records the traffic of each objective.
+type Tracker struct {
+	mu   sync.Mutex
+	size int
+	// key: objective name, value: latency samples of its recent requests
+	windows map[string]*window
+	// key: objective name, value: time of its last request
+	lastRequests map[string]time.Time
+}
+
+// NewTracker returns a Tracker computing the attainment from the given number of recent requests
+// of each objective.
+func NewTracker(size int) *Tracker {
+	return &Tracker{
+		size:         size,
+		windows:      map[string]*window{},
+		lastRequests: map[string]time.Time{},
+	}
+}
+
+// window holds the recent samples of an objective.
+type window struct {
+	ttft samples
+	tpot samples
+}
+
+// samples is a ring buffer of latencies.
+type samples struct {
+	values []time.Duration
+	next   int
+}
+
+func (s *samples) add(value time.Duration, size int) {
+	if len(s.values) < size {
+		s.values = append(s.values, value)
+		return
+	}
+	s.values[s.next] = value
+	s.next = (s.next + 1) % size
+}
+
+// attainment returns the latency at the percentile, and the percentage of samples within the
+// target.
+func (s *samples) attainment(target time.Duration, percentile int) (time.Duration, int32) {
+	sorted := slices.Clone(s.values)
+	slices.Sort(sorted)
+	index := (len(sorted)*percentile+99)/100 - 1
+	index = max(0, min(index, len(sorted)-1))
+	met := 0
+	for _, value := range sorted {
+		if value <= target {
+			met++
+		}
+	}
+	return sorted[index], int32(met * 100 / len(sorted))
+}
+
+// RecordRequest records the reception of a request of the objective.
+func (t *Tracker) RecordRequest(objective string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lastRequests[objective] = time.Now()
+}
+
+// LastRequestTime returns the time of the last request of the objective, if any.
+func (t *Tracker) LastRequestTime(objective string) (time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	last, ok := t.lastRequests[objective]
+	return last, ok
+}
+
+// RecordLatency records the latency of a request of the objective. A zero tpot means that the time
+// per output token wasn't observed.
+func (t *Tracker) RecordLatency(objective string, ttft, tpot time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	w, ok := t.windows[objective]
+	if !ok {
+		w = &window{}
+		t.windows[objective] = w
+	}
+	w.ttft.add(ttft, t.size)
+	if tpot > 0 {
+		w.tpot.add(tpot, t.size)
+	}
+}
+
+// Attainment compares the recent requests of the objective to its targets. It returns nil if no
+// request was recorded.
+func (t *Tracker) Attainment(objective string, target *schedulingtypes.LatencyObjective, now time.Time) *v1alpha2.LatencyAttainment {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	w, ok := t.windows[objective]
+	if !ok || len(w.ttft.values) == 0 {
+		return nil
+	}
+	attainment := &v1alpha2.LatencyAttainment{
+		Met:            true,
+		SampleCount:    int32(len(w.ttft.values)),
+		LastUpdateTime: metav1.NewTime(now),
+	}
+	if target.TTFT > 0 {
+		observed, percent := w.ttft.attainment(target.TTFT, target.Percentile)
+		attainment.ObservedTTFT = &metav1.Duration{Duration: observed}
+		attainment.TTFTAttainment = &percent
+		attainment.Met = attainment.Met && observed <= target.TTFT
+	}
+	if target.TPOT > 0 {
+		if len(w.tpot.values) == 0 {
+			// The target can't be evaluated without streamed responses.
+			attainment.Met = false
+		} else {
+			observed, percent := w.tpot.attainment(target.TPOT, target.Percentile)
+			attainment.ObservedTPOT = &metav1.Duration{Duration: observed}
+			attainment.TPOTAttainment = &percent
+			attainment.Met = attainment.Met && observed <= target.TPOT
+		}
+	}
+	return attainment
+}
+
+// Retain forgets the objectives which are not in the given list.
+func (t *Tracker) Retain(objectives []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for objective := range t.windows {
+		if !slices.Contains(objectives, objective) {
+			delete(t.windows, objective)
+		}
+	}
+	for objective := range t.lastRequests {
+		if !slices.Contains(objectives, objective) {
+			delete(t.lastRequests, objective)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectives
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
+	schedulingtypes "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	utiltest "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/testing"
+)
+
+func TestLatencyObjective(t *testing.T) {
+	tests := []struct {
+		name      string
+		objective *v1alpha2.InferenceObjective
+		want      *schedulingtypes.LatencyObjective
+	}{
+		{
+			name:      "no objective",
+			objective: nil,
+		},
+		{
+			name:      "no latency targets",
+			objective: utiltest.MakeInferenceObjective("chat").ObjRef(),
+		},
+		{
+			name:      "latency targets",
+			objective: utiltest.MakeInferenceObjective("chat").Latency(time.Second, 50*time.Millisecond, 99).ObjRef(),
+			want:      &schedulingtypes.LatencyObjective{TTFT: time.Second, TPOT: 50 * time.Millisecond, Percentile: 99},
+		},
+		{
+			name: "default percentile",
+			objective: &v1alpha2.InferenceObjective{Spec: v1alpha2.InferenceObjectiveSpec{
+				Latency: &v1alpha2.LatencyObjective{TargetTTFT: &metav1.Duration{Duration: time.Second}},
+			}},
+			want: &schedulingtypes.LatencyObjective{TTFT: time.Second, Percentile: DefaultPercentile},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if diff := cmp.Diff(test.want, LatencyObjective(test.objective)); diff != "" {
+				t.Errorf("Unexpected latency objective (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestTrackerAttainment(t *testing.T) {
+	now := time.Now()
+	duration := func(d time.Duration) *metav1.Duration { return &metav1.Duration{Duration: d} }
+
+	tests := []struct {
+		name    string
+		records [][2]time.Duration
+		target  schedulingtypes.LatencyObjective
+		want    *v1alpha2.LatencyAttainment
+	}{
+		{
+			name:   "no request",
+			target: schedulingtypes.LatencyObjective{TTFT: time.Second, Percentile: 90},
+		},
+		{
+			name: "targets met",
+			records: [][2]time.Duration{
+				{100 * time.Millisecond, 10 * time.Millisecond},
+				{200 * time.Millisecond, 20 * time.Millisecond},
+				{300 * time.Millisecond, 0},
+				{2 * time.Second, 0},
+			},
+			target: schedulingtypes.LatencyObjective{TTFT: 500 * time.Millisecond, TPOT: 20 * time.Millisecond, Percentile: 75},
+			want: &v1alpha2.LatencyAttainment{
+				ObservedTTFT:   duration(300 * time.Millisecond),
+				ObservedTPOT:   duration(20 * time.Millisecond),
+				TTFTAttainment: ptr.To[int32](75),
+				TPOTAttainment: ptr.To[int32](100),
+				Met:            true,
+				SampleCount:    4,
+				LastUpdateTime: metav1.NewTime(now),
+			},
+		},
+		{
+			name: "ttft target missed",
+			records: [][2]time.Duration{
+				{100 * time.Millisecond, 0},
+				{2 * time.Second, 0},
+			},
+			target: schedulingtypes.LatencyObjective{TTFT: 500 * time.Millisecond, Percentile: 90},
+			want: &v1alpha2.LatencyAttainment{
+				ObservedTTFT:   duration(2 * time.Second),
+				TTFTAttainment: ptr.To[int32](50),
+				SampleCount:    2,
+				LastUpdateTime: metav1.NewTime(now),
+			},
+		},
+		{
+			name:    "tpot not observed",
+			records: [][2]time.Duration{{100 * time.Millisecond, 0}},
+			target:  schedulingtypes.LatencyObjective{TPOT: 20 * time.Millisecond, Percentile: 90},
+			want: &v1alpha2.LatencyAttainment{
+				SampleCount:    1,
+				LastUpdateTime: metav1.NewTime(now),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tracker := NewTracker(DefaultWindowSize)
+			for _, record := range test.records {
+				tracker.RecordLatency("chat", record[0], record[1])
+			}
+			if diff := cmp.Diff(test.want, tracker.Attainment("chat", &test.target, now)); diff != "" {
+				t.Errorf("Unexpected attainment (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestTrackerWindow(t *testing.T) {
+	tracker := NewTracker(2)
+	tracker.RecordLatency("chat", 5*time.Second, 0)
+	tracker.RecordLatency("chat", 100*time.Millisecond, 0)
+	tracker.RecordLatency("chat", 200*time.Millisecond, 0)
+	tracker.RecordLatency("code", time.Second, 0)
+
+	attainment := tracker.Attainment("chat", &schedulingtypes.LatencyObjective{TTFT: time.Second, Percentile: 100}, time.Now())
+	if attainment == nil || attainment.SampleCount != 2 || !attainment.Met {
+		t.Errorf("Expected the oldest request to leave the window, got %+v", attainment)
+	}
+
+	tracker.Retain([]string{"chat"})
+	if tracker.Attainment("code", &schedulingtypes.LatencyObjective{TTFT: time.Second, Percentile: 90}, time.Now()) != nil {
+		t.Error("Expected the removed objective to be forgotten")
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outlierdetection
+
+import (
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	envutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/env"
+)
+
+// Default configuration values
+const (
+	// DefaultEnabled leaves outlier detection off unless OD_ENABLED is set, so that pods are
+	// never ejected from existing deployments without opting in.
+	DefaultEnabled = false
+	// DefaultConsecutiveErrors is the default number of consecutive error responses
+	// after which a pod is ejected.
+	DefaultConsecutiveErrors = 5
+	// DefaultConsecutiveScrapeFailures is the default number of consecutive failed
+	// metrics scrapes after which a pod is ejected.
+	DefaultConsecutiveScrapeFailures = 5
+	// DefaultErrorRateThreshold is the default ratio (0.0 to 1.0) of error responses
+	// within an interval above which a pod is ejected.
+	DefaultErrorRateThreshold = 0.5
+	// DefaultErrorRateMinRequests is the default minimum number of responses within an
+	// interval for the error rate of a pod to be evaluated.
+	DefaultErrorRateMinRequests = 10
+	// DefaultInterval is the default duration of the error rate window.
+	DefaultInterval = 10 * time.Second
+	// DefaultBaseEjectionTime is the default duration of the first ejection of a pod.
+	DefaultBaseEjectionTime = 30 * time.Second
+	// DefaultMaxEjectionTime is the default maximum duration of an ejection.
+	DefaultMaxEjectionTime = 5 * time.Minute
+	// DefaultMaxEjectionPercent is the default maximum percentage of the pods in the
+	// pool that can be ejected at the same time.
+	DefaultMaxEjectionPercent = 50
+)
+
+// Environment variable names for outlier detection configuration
+const (
+	EnvOdEnabled                   = "OD_ENABLED"
+	EnvOdConsecutiveErrors         = "OD_CONSECUTIVE_ERRORS"
+	EnvOdConsecutiveScrapeFailures = "OD_CONSECUTIVE_SCRAPE_FAILURES"
+	EnvOdErrorRateThreshold        = "OD_ERROR_RATE_THRESHOLD"
+	EnvOdErrorRateMinRequests      = "OD_ERROR_RATE_MIN_REQUESTS"
+	EnvOdInterval                  = "OD_INTERVAL"
+	EnvOdBaseEjectionTime          = "OD_BASE_EJECTION_TIME"
+	EnvOdMaxEjectionTime           = "OD_MAX_EJECTION_TIME"
+	EnvOdMaxEjectionPercent        = "OD_MAX_EJECTION_PERCENT"
+)
+
+// Config holds the configuration for outlier detection.
+type Config struct {
+	// Enabled turns outlier detection on. When disabled, no pod is ever ejected.
+	Enabled bool
+	// ConsecutiveErrors is the number of consecutive error (5xx) responses after which
+	// a pod is ejected. Zero disables this check.
+	ConsecutiveErrors int
+	// ConsecutiveScrapeFailures is the number of consecutive failed metrics scrapes after
+	// which a pod is ejected. Zero disables this check.
+	ConsecutiveScrapeFailures int
+	// ErrorRateThreshold is the ratio (0.0 to 1.0) of error responses within an interval
+	// at or above which a pod is ejected. Zero disables this check.
+	ErrorRateThreshold float64
+	// ErrorRateMinRequests is the minimum number of responses within an interval for the
+	// error rate of a pod to be evaluated.
+	ErrorRateMinRequests int
+	// Interval is the duration of the error rate window, and the period at which
+	// ejections are re-evaluated.
+	Interval time.Duration
+	// BaseEjectionTime is the duration of the first ejection of a pod. The duration
+	// doubles on every subsequent ejection, up to MaxEjectionTime.
+	BaseEjectionTime time.Duration
+	// MaxEjectionTime is the maximum duration of an ejection.
+	MaxEjectionTime time.Duration
+	// MaxEjectionPercent is the maximum percentage (0 to 100) of the pods in the pool
+	// that can be ejected at the same time.
+	MaxEjectionPercent int
+}
+
+// LoadConfigFromEnv loads outlier detection Config from environment variables.
+func LoadConfigFromEnv() *Config {
+	// Use a default logger for initial configuration loading.
+	logger := log.Log.WithName("outlier-detection-config")
+
+	cfg := &Config{}
+
+	cfg.Enabled = envutil.GetEnvBool(EnvOdEnabled, DefaultEnabled, logger)
+
+	cfg.ConsecutiveErrors = envutil.GetEnvInt(EnvOdConsecutiveErrors, DefaultConsecutiveErrors, logger)
+	if cfg.ConsecutiveErrors < 0 {
+		cfg.ConsecutiveErrors = DefaultConsecutiveErrors
+	}
+
+	cfg.ConsecutiveScrapeFailures = envutil.GetEnvInt(EnvOdConsecutiveScrapeFailures, DefaultConsecutiveScrapeFailures, logger)
+	if cfg.ConsecutiveScrapeFailures < 0 {
+		cfg.ConsecutiveScrapeFailures = DefaultConsecutiveScrapeFailures
+	}
+
+	cfg.ErrorRateThreshold = envutil.GetEnvFloat(EnvOdErrorRateThreshold, DefaultErrorRateThreshold, logger)
+	if cfg.ErrorRateThreshold < 0 || cfg.ErrorRateThreshold > 1 {
+		cfg.ErrorRateThreshold = DefaultErrorRateThreshold
+	}
+
+	cfg.ErrorRateMinRequests = envutil.GetEnvInt(EnvOdErrorRateMinRequests, DefaultErrorRateMinRequests, logger)
+	if cfg.ErrorRateMinRequests <= 0 {
+		cfg.ErrorRateMinRequests = DefaultErrorRateMinRequests
+	}
+
+	cfg.Interval = envutil.GetEnvDuration(EnvOdInterval, DefaultInterval, logger)
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultInterval
+	}
+
+	cfg.BaseEjectionTime = envutil.GetEnvDuration(EnvOdBaseEjectionTime, DefaultBaseEjectionTime, logger)
+	if cfg.BaseEjectionTime <= 0 {
+		cfg.BaseEjectionTime = DefaultBaseEjectionTime
+	}
+
+	cfg.MaxEjectionTime = envutil.GetEnvDuration(EnvOdMaxEjectionTime, DefaultMaxEjectionTime, logger)
+	if cfg.MaxEjectionTime < cfg.BaseEjectionTime {
+		cfg.MaxEjectionTime = max(DefaultMaxEjectionTime, cfg.BaseEjectionTime)
+	}
+
+	cfg.MaxEjectionPercent = envutil.GetEnvInt(EnvOdMaxEjectionPercent, DefaultMaxEjectionPercent, logger)
+	if cfg.MaxEjectionPercent < 0 || cfg.MaxEjectionPercent > 100 {
+		cfg.MaxEjectionPercent = DefaultMaxEjectionPercent
+	}
+
+	logger.Info("Outlier detection configuration loaded from env", "config", fmt.Sprintf("%+v", cfg))
+	return cfg
+}
@@ -0,0 +1,343 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package outlierdetection implements passive outlier detection of the model
+// server pods.
+//
+// The Detector observes the outcome of the requests served by each pod, as well
+// as the outcome of its metrics scrapes, and temporarily ejects misbehaving pods
+// from scheduling. A pod is ejected when it reaches a number of consecutive
+// errors, or when its error rate within an interval exceeds a threshold. The
+// ejection time grows exponentially with the number of times a pod has recently
+// been ejected, and the number of pods ejected at the same time is bounded by a
+// percentage of the pool.
+package outlierdetection
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datalayer"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	// loggerName is the name to use for loggers created by this package.
+	loggerName = "OutlierDetection"
+
+	// EjectionStateKey is the endpoint attribute key holding the EjectionState of a pod.
+	EjectionStateKey = "outlier-detection/ejection-state"
+
+	// Ejection reasons.
+	ReasonConsecutiveErrors         = "consecutive_errors"
+	ReasonErrorRate                 = "error_rate"
+	ReasonConsecutiveScrapeFailures = "consecutive_scrape_failures"
+)
+
+// EjectionState is the outlier detection state of a pod, stored as an attribute of its
+// endpoint in the datastore.
+type EjectionState struct {
+	// Ejected is true while the pod is ejected from scheduling.
+	Ejected bool
+	// EjectedUntil is the time at which the current ejection ends.
+	EjectedUntil time.Time
+	// Reason is the reason of the current or last ejection.
+	Reason string
+	// EjectionCount is the number of recent ejections, driving the ejection time.
+	EjectionCount int
+}
+
+// Clone implements datalayer.Cloneable.
+func (s *EjectionState) Clone() datalayer.Cloneable {
+	if s == nil {
+		return nil
+	}
+	clone := *s
+	return &clone
+}
+
+// Datastore provides an interface to access the pods of the pool.
+type Datastore interface {
+	PodList(predicate func(backendmetrics.PodMetrics) bool) []backendmetrics.PodMetrics
+}
+
+// podState holds the outlier detection counters of a single pod.
+type podState struct {
+	consecutiveErrors         int
+	consecutiveScrapeFailures int
+	windowRequests            int
+	windowErrors              int
+	ejectionCount             int
+	ejectedUntil              time.Time
+	reason                    string
+}
+
+func (s *podState) ejected(now time.Time) bool {
+	return now.Before(s.ejectedUntil)
+}
+
+// Detector tracks the errors of each pod and decides which pods are ejected.
+type Detector struct {
+	config    *Config
+	datastore Datastore
+	logger    logr.Logger
+
+	mu   sync.Mutex
+	pods map[types.NamespacedName]*podState
+	now  func() time.Time
+}
+
+// NewDetector creates a new outlier Detector.
+// The datastore is used to determine the size of the pool and to publish the
+// ejection state of the pods.
+func NewDetector(config *Config, datastore Datastore, logger logr.Logger) *Detector {
+	logger = logger.WithName(loggerName)
+	logger.V(logutil.DEFAULT).Info("Creating new outlier detector", "config", config)
+
+	return &Detector{
+		config:    config,
+		datastore: datastore,
+		logger:    logger,
+		pods:      make(map[types.NamespacedName]*podState),
+		now:       time.Now,
+	}
+}
+
+// RecordResponse records the status code of a response served by the given pod.
+// Responses with a 5xx status code are counted as errors.
+func (d *Detector) RecordResponse(pod types.NamespacedName, statusCode int) {
+	if !d.config.Enabled {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	state := d.stateLocked(pod)
+	state.windowRequests++
+	if statusCode < 500 {
+		state.consecutiveErrors = 0
+		return
+	}
+	d.recordErrorLocked(pod, state)
+}
+
+// RecordAbortedResponse records a response of the given pod that was aborted before its end, e.g.
+// on an upstream timeout or a stream reset, as an error. If the status code of the response was
+// already recorded as a success, the response is counted as an error instead, rather than twice.
+func (d *Detector) RecordAbortedResponse(pod types.NamespacedName, statusRecorded bool) {
+	if !d.config.Enabled {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	state := d.stateLocked(pod)
+	if !statusRecorded {
+		state.windowRequests++
+	}
+	d.recordErrorLocked(pod, state)
+}
+
+// recordErrorLocked counts an error response of the pod, and ejects it once it reaches the
+// number of consecutive errors. Must be called with the mutex held.
+func (d *Detector) recordErrorLocked(pod types.NamespacedName, state *podState) {
+	state.windowErrors = min(state.windowErrors+1, state.windowRequests)
+	state.consecutiveErrors++
+	if d.config.ConsecutiveErrors > 0 && state.consecutiveErrors >= d.config.ConsecutiveErrors {
+		d.ejectLocked(pod, state, ReasonConsecutiveErrors)
+	}
+}
+
+// ObserveScrape records the outcome of a metrics scrape of the given pod. It implements
+// datalayer.ScrapeObserver.
+func (d *Detector) ObserveScrape(pod types.NamespacedName, err error) {
+	if !d.config.Enabled {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	state := d.stateLocked(pod)
+	if err == nil {
+		state.consecutiveScrapeFailures = 0
+		return
+	}
+	state.consecutiveScrapeFailures++
+	if d.config.ConsecutiveScrapeFailures > 0 && state.consecutiveScrapeFailures >= d.config.ConsecutiveScrapeFailures {
+		d.ejectLocked(pod, state, ReasonConsecutiveScrapeFailures)
+	}
+}
+
+// IsEjected returns true if the given pod is currently ejected from scheduling.
+func (d *Detector) IsEjected(pod types.NamespacedName) bool {
+	if !d.config.Enabled {
+		return false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	state, ok := d.pods[pod]
+	return ok && state.ejected(d.now())
+}
+
+// Start runs the periodic evaluation of the pods until the context is canceled. It
+// implements the controller-runtime manager.Runnable interface.
+func (d *Detector) Start(ctx context.Context) error {
+	if !d.config.Enabled {
+		return nil
+	}
+	ticker := time.NewTicker(d.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			d.evaluate(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection implements the controller-runtime manager.LeaderElectionRunnable
+// interface. Every replica tracks the pods it routes to.
+func (d *Detector) NeedLeaderElection() bool {
+	return false
+}
+
+// evaluate ends expired ejections, ejects pods whose error rate over the last interval
+// is too high, and starts a new interval.
+func (d *Detector) evaluate(ctx context.Context) {
+	logger := log.FromContext(ctx).WithName(loggerName)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	known := make(map[types.NamespacedName]bool)
+	for _, pm := range d.datastore.PodList(backendmetrics.AllPodsPredicate) {
+		known[pm.GetPod().NamespacedName] = true
+	}
+
+	now := d.now()
+	for pod, state := range d.pods {
+		if !known[pod] {
+			delete(d.pods, pod)
+			metrics.DeleteOutlierDetectionMetrics(pod)
+			continue
+		}
+
+		returned := false
+		if !state.ejectedUntil.IsZero() && !state.ejected(now) {
+			logger.V(logutil.DEFAULT).Info("Returning pod to scheduling", "pod", pod, "ejectionCount", state.ejectionCount)
+			state.ejectedUntil = time.Time{}
+			metrics.RecordOutlierUnejection(pod)
+			d.publishLocked(pod, state)
+			returned = true
+		}
+
+		switch {
+		case state.ejected(now) || returned:
+		case d.config.ErrorRateThreshold > 0 && state.windowRequests >= d.config.ErrorRateMinRequests &&
+			float64(state.windowErrors)/float64(state.windowRequests) >= d.config.ErrorRateThreshold:
+			d.ejectLocked(pod, state, ReasonErrorRate)
+		case state.windowErrors == 0 && state.ejectionCount > 0:
+			// A full healthy interval in scheduling decays the ejection multiplier.
+			state.ejectionCount--
+		}
+
+		state.windowRequests = 0
+		state.windowErrors = 0
+	}
+}
+
+func (d *Detector) stateLocked(pod types.NamespacedName) *podState {
+	state, ok := d.pods[pod]
+	if !ok {
+		state = &podState{}
+		d.pods[pod] = state
+	}
+	return state
+}
+
+// ejectLocked ejects the pod unless it is already ejected or the maximum ejection
+// percentage is reached. Must be called with the mutex held.
+func (d *Detector) ejectLocked(pod types.NamespacedName, state *podState, reason string) {
+	now := d.now()
+	if state.ejected(now) {
+		return
+	}
+
+	total := len(d.datastore.PodList(backendmetrics.AllPodsPredicate))
+	ejected := 0
+	for _, s := range d.pods {
+		if s.ejected(now) {
+			ejected++
+		}
+	}
+	if (ejected+1)*100 > total*d.config.MaxEjectionPercent {
+		d.logger.V(logutil.VERBOSE).Info("Not ejecting pod, maximum ejection percentage reached",
+			"pod", pod, "reason", reason, "ejectedPods", ejected, "totalPods", total)
+		metrics.RecordOutlierEjectionOverflow()
+		return
+	}
+
+	state.ejectionCount++
+	duration := ejectionTime(d.config.BaseEjectionTime, d.config.MaxEjectionTime, state.ejectionCount)
+	state.ejectedUntil = now.Add(duration)
+	state.reason = reason
+	state.consecutiveErrors = 0
+	state.consecutiveScrapeFailures = 0
+	state.windowRequests = 0
+	state.windowErrors = 0
+
+	d.logger.V(logutil.DEFAULT).Info("Ejecting pod from scheduling", "pod", pod, "reason", reason,
+		"duration", duration, "ejectionCount", state.ejectionCount)
+	metrics.RecordOutlierEjection(pod, reason)
+	d.publishLocked(pod, state)
+}
+
+// publishLocked stores the ejection state of the pod as an attribute of its endpoint.
+func (d *Detector) publishLocked(pod types.NamespacedName, state *podState) {
+	endpoints := d.datastore.PodList(func(pm backendmetrics.PodMetrics) bool {
+		return pm.GetPod().NamespacedName == pod
+	})
+	for _, ep := range endpoints {
+		ep.Put(EjectionStateKey, &EjectionState{
+			Ejected:       state.ejected(d.now()),
+			EjectedUntil:  state.ejectedUntil,
+			Reason:        state.reason,
+			EjectionCount: state.ejectionCount,
+		})
+	}
+}
+
+// ejectionTime returns the duration of an ejection: the base ejection time doubled for every
+// previous ejection, capped at maxEjection.
+func ejectionTime(base, maxEjection time.Duration, ejectionCount int) time.Duration {
+	duration := base
+	for i := 1; i < ejectionCount; i++ {
+		duration *= 2
+		if duration >= maxEjection || duration <= 0 {
+			return maxEjection
+		}
+	}
+	return min(duration, maxEjection)
+}
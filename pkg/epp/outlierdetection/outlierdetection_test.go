@@ -0,0 +1,319 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outlierdetection
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datalayer"
+)
+
+type mockDatastore struct {
+	pods []*backendmetrics.FakePodMetrics
+}
+
+func (fds *mockDatastore) PodList(predicate func(backendmetrics.PodMetrics) bool) []backendmetrics.PodMetrics {
+	res := []backendmetrics.PodMetrics{}
+	for _, pod := range fds.pods {
+		if predicate(pod) {
+			res = append(res, pod)
+		}
+	}
+	return res
+}
+
+func newMockDatastore(names ...string) *mockDatastore {
+	ds := &mockDatastore{}
+	for _, name := range names {
+		ds.pods = append(ds.pods, &backendmetrics.FakePodMetrics{
+			Pod:     &backend.Pod{NamespacedName: types.NamespacedName{Name: name, Namespace: "ns1"}},
+			Metrics: backendmetrics.NewMetricsState(),
+		})
+	}
+	return ds
+}
+
+func podName(name string) types.NamespacedName {
+	return types.NamespacedName{Name: name, Namespace: "ns1"}
+}
+
+func testConfig() *Config {
+	return &Config{
+		Enabled:                   true,
+		ConsecutiveErrors:         3,
+		ConsecutiveScrapeFailures: 3,
+		ErrorRateThreshold:        0.5,
+		ErrorRateMinRequests:      4,
+		Interval:                  time.Second,
+		BaseEjectionTime:          10 * time.Second,
+		MaxEjectionTime:           time.Minute,
+		MaxEjectionPercent:        50,
+	}
+}
+
+// newTestDetector returns a detector with a controllable clock.
+func newTestDetector(config *Config, ds Datastore) (*Detector, *time.Time) {
+	d := NewDetector(config, ds, logr.Discard())
+	now := time.Now()
+	d.now = func() time.Time { return now }
+	return d, &now
+}
+
+func ejectionStateOf(t *testing.T, ds *mockDatastore, name string) *EjectionState {
+	for _, pod := range ds.pods {
+		if pod.GetPod().NamespacedName.Name == name {
+			value, ok := pod.Get(EjectionStateKey)
+			require.True(t, ok, "expected the ejection state to be published")
+			return value.(*EjectionState)
+		}
+	}
+	t.Fatalf("pod %s not found", name)
+	return nil
+}
+
+func TestConsecutiveErrors(t *testing.T) {
+	ds := newMockDatastore("pod1", "pod2")
+	d, _ := newTestDetector(testConfig(), ds)
+
+	d.RecordResponse(podName("pod1"), 500)
+	d.RecordResponse(podName("pod1"), 503)
+	d.RecordResponse(podName("pod1"), 200) // resets the consecutive errors
+	d.RecordResponse(podName("pod1"), 500)
+	d.RecordResponse(podName("pod1"), 500)
+	assert.False(t, d.IsEjected(podName("pod1")))
+
+	d.RecordResponse(podName("pod1"), 504)
+	assert.True(t, d.IsEjected(podName("pod1")))
+	assert.False(t, d.IsEjected(podName("pod2")))
+
+	state := ejectionStateOf(t, ds, "pod1")
+	assert.True(t, state.Ejected)
+	assert.Equal(t, ReasonConsecutiveErrors, state.Reason)
+	assert.Equal(t, 1, state.EjectionCount)
+}
+
+func TestAbortedResponses(t *testing.T) {
+	ds := newMockDatastore("pod1", "pod2")
+	config := testConfig()
+	config.ConsecutiveErrors = 0
+	d, _ := newTestDetector(config, ds)
+
+	// The aborted responses whose status code was recorded as a success are counted as errors
+	// instead, 2 errors out of 4 requests.
+	d.RecordResponse(podName("pod1"), 200)
+	d.RecordAbortedResponse(podName("pod1"), true)
+	d.RecordResponse(podName("pod1"), 200)
+	d.RecordAbortedResponse(podName("pod1"), false)
+	d.RecordResponse(podName("pod1"), 200)
+	d.evaluate(context.Background())
+	assert.True(t, d.IsEjected(podName("pod1")))
+	assert.Equal(t, ReasonErrorRate, ejectionStateOf(t, ds, "pod1").Reason)
+
+	config = testConfig()
+	d, _ = newTestDetector(config, newMockDatastore("pod1", "pod2"))
+	for range config.ConsecutiveErrors {
+		d.RecordAbortedResponse(podName("pod1"), false)
+	}
+	assert.True(t, d.IsEjected(podName("pod1")))
+}
+
+func TestConsecutiveScrapeFailures(t *testing.T) {
+	ds := newMockDatastore("pod1", "pod2")
+	d, _ := newTestDetector(testConfig(), ds)
+
+	for range 2 {
+		d.ObserveScrape(podName("pod1"), errors.New("connection refused"))
+	}
+	d.ObserveScrape(podName("pod1"), nil)
+	for range 2 {
+		d.ObserveScrape(podName("pod1"), errors.New("connection refused"))
+	}
+	assert.False(t, d.IsEjected(podName("pod1")))
+
+	d.ObserveScrape(podName("pod1"), errors.New("connection refused"))
+	assert.True(t, d.IsEjected(podName("pod1")))
+	assert.Equal(t, ReasonConsecutiveScrapeFailures, ejectionStateOf(t, ds, "pod1").Reason)
+}
+
+func TestErrorRate(t *testing.T) {
+	ds := newMockDatastore("pod1", "pod2")
+	config := testConfig()
+	config.ConsecutiveErrors = 0
+	d, _ := newTestDetector(config, ds)
+
+	// Below the minimum number of requests.
+	d.RecordResponse(podName("pod1"), 500)
+	d.RecordResponse(podName("pod1"), 500)
+	d.evaluate(context.Background())
+	assert.False(t, d.IsEjected(podName("pod1")))
+
+	// The window was reset, below the error rate threshold.
+	for _, code := range []int{500, 200, 200, 200} {
+		d.RecordResponse(podName("pod1"), code)
+	}
+	d.evaluate(context.Background())
+	assert.False(t, d.IsEjected(podName("pod1")))
+
+	for _, code := range []int{500, 200, 500, 200} {
+		d.RecordResponse(podName("pod1"), code)
+	}
+	d.evaluate(context.Background())
+	assert.True(t, d.IsEjected(podName("pod1")))
+	assert.Equal(t, ReasonErrorRate, ejectionStateOf(t, ds, "pod1").Reason)
+}
+
+// failingSource is a data source whose collections always fail.
+type failingSource struct{}
+
+func (failingSource) Name() string                             { return "failing" }
+func (failingSource) AddExtractor(_ datalayer.Extractor) error { return nil }
+func (failingSource) Collect(context.Context, datalayer.Endpoint) error {
+	return errors.New("connection refused")
+}
+
+func TestConsecutiveScrapeFailuresFromDataLayer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ds := newMockDatastore("pod1", "pod2")
+	d, _ := newTestDetector(testConfig(), ds)
+	factory := datalayer.NewEndpointFactory([]datalayer.DataSource{failingSource{}}, time.Millisecond)
+	factory.SetScrapeObserver(d)
+
+	pod := datalayer.ToPodInfos(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "ns1"},
+		Status:     corev1.PodStatus{PodIP: "1.2.3.4"},
+	}, []int32{8000})[0]
+	ep := factory.NewEndpoint(ctx, pod, nil)
+	require.NotNil(t, ep)
+	defer factory.ReleaseEndpoint(ep)
+
+	assert.Eventually(t, func() bool {
+		return d.IsEjected(podName("pod1"))
+	}, 5*time.Second, time.Millisecond, "expected the failing collections to eject the pod")
+	assert.Equal(t, ReasonConsecutiveScrapeFailures, ejectionStateOf(t, ds, "pod1").Reason)
+}
+
+func TestMaxEjectionPercent(t *testing.T) {
+	ds := newMockDatastore("pod1", "pod2", "pod3", "pod4")
+	d, _ := newTestDetector(testConfig(), ds)
+
+	for _, name := range []string{"pod1", "pod2", "pod3"} {
+		for range 3 {
+			d.RecordResponse(podName(name), 500)
+		}
+	}
+	assert.True(t, d.IsEjected(podName("pod1")))
+	assert.True(t, d.IsEjected(podName("pod2")))
+	assert.False(t, d.IsEjected(podName("pod3")), "at most 50% of the pods can be ejected")
+
+	// A single pod can't be ejected with a 50% limit.
+	single := newMockDatastore("pod1")
+	d, _ = newTestDetector(testConfig(), single)
+	for range 3 {
+		d.RecordResponse(podName("pod1"), 500)
+	}
+	assert.False(t, d.IsEjected(podName("pod1")))
+}
+
+func TestEjectionExpiresAndBacksOff(t *testing.T) {
+	ds := newMockDatastore("pod1", "pod2")
+	d, now := newTestDetector(testConfig(), ds)
+
+	eject := func() {
+		for range 3 {
+			d.RecordResponse(podName("pod1"), 500)
+		}
+		require.True(t, d.IsEjected(podName("pod1")))
+	}
+
+	eject()
+	*now = now.Add(9 * time.Second)
+	assert.True(t, d.IsEjected(podName("pod1")))
+	*now = now.Add(time.Second)
+	assert.False(t, d.IsEjected(podName("pod1")), "expected the first ejection to last the base ejection time")
+	d.evaluate(context.Background())
+	assert.False(t, ejectionStateOf(t, ds, "pod1").Ejected)
+
+	eject()
+	*now = now.Add(19 * time.Second)
+	assert.True(t, d.IsEjected(podName("pod1")), "expected the second ejection to last twice the base ejection time")
+	*now = now.Add(time.Second)
+	assert.False(t, d.IsEjected(podName("pod1")))
+	assert.Equal(t, 2, ejectionStateOf(t, ds, "pod1").EjectionCount)
+
+	// Healthy intervals after the pod returned to scheduling decay the ejection multiplier.
+	d.evaluate(context.Background()) // returns the pod to scheduling
+	d.evaluate(context.Background())
+	d.evaluate(context.Background())
+	eject()
+	assert.Equal(t, 1, ejectionStateOf(t, ds, "pod1").EjectionCount)
+}
+
+func TestEjectionTime(t *testing.T) {
+	tests := []struct {
+		count int
+		want  time.Duration
+	}{
+		{count: 1, want: 10 * time.Second},
+		{count: 2, want: 20 * time.Second},
+		{count: 3, want: 40 * time.Second},
+		{count: 4, want: time.Minute},
+		{count: 100, want: time.Minute},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, ejectionTime(10*time.Second, time.Minute, test.count), "ejection count %d", test.count)
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	ds := newMockDatastore("pod1", "pod2")
+	config := testConfig()
+	config.Enabled = false
+	d, _ := newTestDetector(config, ds)
+
+	for range 10 {
+		d.RecordResponse(podName("pod1"), 500)
+		d.RecordAbortedResponse(podName("pod1"), false)
+		d.ObserveScrape(podName("pod1"), errors.New("connection refused"))
+	}
+	assert.False(t, d.IsEjected(podName("pod1")))
+}
+
+func TestRemovedPodsArePruned(t *testing.T) {
+	ds := newMockDatastore("pod1", "pod2")
+	d, _ := newTestDetector(testConfig(), ds)
+
+	d.RecordResponse(podName("pod1"), 500)
+	ds.pods = ds.pods[1:]
+	d.evaluate(context.Background())
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	assert.NotContains(t, d.pods, podName("pod1"))
+}
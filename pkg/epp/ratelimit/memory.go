@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// maxIdleBuckets is the number of buckets above which the full buckets are dropped, since they are
+// the same as new ones.
+const maxIdleBuckets = 10000
+
+// compile-time type assertion
+var _ Backend = &MemoryBackend{}
+
+// MemoryBackend stores the token buckets in memory. The limits are hence enforced by each EPP
+// replica separately.
+type MemoryBackend struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+// bucket is a token bucket holding one minute worth of its rate.
+type bucket struct {
+	balance float64
+	updated time.Time
+}
+
+// NewMemoryBackend returns a new MemoryBackend.
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{
+		buckets: map[string]*bucket{},
+		now:     time.Now,
+	}
+}
+
+// Take implements Backend.
+func (m *MemoryBackend) Take(_ context.Context, key string, perMinute int64, cost int64) (time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b := m.refill(key, perMinute)
+	if b.balance < 1 {
+		perSecond := float64(perMinute) / 60
+		return time.Duration((1 - b.balance) / perSecond * float64(time.Second)), nil
+	}
+	b.balance -= float64(cost)
+	return 0, nil
+}
+
+// Charge implements Backend.
+func (m *MemoryBackend) Charge(_ context.Context, key string, perMinute int64, cost int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b := m.refill(key, perMinute)
+	b.balance = min(b.balance-float64(cost), float64(perMinute))
+	return nil
+}
+
+// refill returns the bucket of the key, refilled since its last update.
+func (m *MemoryBackend) refill(key string, perMinute int64) *bucket {
+	now := m.now()
+	b, ok := m.buckets[key]
+	if !ok {
+		if len(m.buckets) >= maxIdleBuckets {
+			m.dropFullBuckets(now)
+		}
+		b = &bucket{balance: float64(perMinute), updated: now}
+		m.buckets[key] = b
+		return b
+	}
+	elapsed := now.Sub(b.updated)
+	b.balance = min(b.balance+elapsed.Minutes()*float64(perMinute), float64(perMinute))
+	b.updated = now
+	return b
+}
+
+// dropFullBuckets drops the buckets which were refilled for at least a minute, since they are
+// full whatever their rate.
+func (m *MemoryBackend) dropFullBuckets(now time.Time) {
+	for key, b := range m.buckets {
+		if b.balance >= 0 && now.Sub(b.updated) >= time.Minute {
+			delete(m.buckets, key)
+		}
+	}
+}
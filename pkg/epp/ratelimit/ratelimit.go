@@ -0,0 +1,188 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ratelimit enforces the request and token rate limits of the InferenceObjectives.
+//
+// The limits are enforced with token buckets holding one minute worth of the rate. A request is
+// admitted when both its request and token buckets have at least one token left. The prompt
+// tokens of the request are estimated and charged up front, and the completion tokens are charged
+// once the response reports its usage, along with the correction of the prompt estimate. The token
+// bucket may hence go into debt, delaying the next requests.
+//
+// The buckets are stored by a Backend. The default MemoryBackend keeps them in the memory of the
+// EPP replica, and other backends can share them across replicas.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
+	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// charsPerToken is the average number of characters per token, used to estimate the prompt tokens.
+const charsPerToken = 4
+
+// Backend stores the token buckets. Implementations must be safe for concurrent use.
+type Backend interface {
+	// Take refills the bucket at the given rate per minute, and removes cost tokens from it if it
+	// holds at least one token, possibly leaving it in debt. Otherwise, it returns the time until
+	// the bucket holds one token again.
+	Take(ctx context.Context, bucket string, perMinute int64, cost int64) (retryAfter time.Duration, err error)
+	// Charge refills the bucket at the given rate per minute and removes cost tokens from it,
+	// regardless of its balance. A negative cost refunds tokens, up to the capacity of the bucket.
+	Charge(ctx context.Context, bucket string, perMinute int64, cost int64) error
+}
+
+// Limit is the rate limit of a key.
+type Limit struct {
+	// RequestsPerMinute is the maximum number of requests per minute, zero means unlimited.
+	RequestsPerMinute int64
+	// TokensPerMinute is the maximum number of tokens per minute, zero means unlimited.
+	TokensPerMinute int64
+}
+
+// Reservation is the admission of a request, whose tokens are charged once complete.
+type Reservation struct {
+	key                   string
+	limit                 Limit
+	estimatedPromptTokens int64
+}
+
+// Key returns the rate limit key of an InferenceObjective request, and its limit. It returns false
+// if the objective has no rate limit.
+func Key(objective *v1alpha2.InferenceObjective, fairnessID string) (string, Limit, bool) {
+	if objective == nil || objective.Spec.RateLimit == nil {
+		return "", Limit{}, false
+	}
+	rateLimit := objective.Spec.RateLimit
+	limit := Limit{}
+	if rateLimit.RequestsPerMinute != nil {
+		limit.RequestsPerMinute = *rateLimit.RequestsPerMinute
+	}
+	if rateLimit.TokensPerMinute != nil {
+		limit.TokensPerMinute = *rateLimit.TokensPerMinute
+	}
+	key := objective.Namespace + "/" + objective.Name
+	if rateLimit.Key == v1alpha2.RateLimitKeyFairnessID {
+		key += "/" + fairnessID
+	}
+	return key, limit, true
+}
+
+// EstimatePromptTokens estimates the number of tokens of a prompt.
+func EstimatePromptTokens(prompt string) int64 {
+	return int64(math.Ceil(float64(len(prompt)) / charsPerToken))
+}
+
+// Limiter enforces rate limits with the token buckets of a Backend. Backend errors are logged and
+// fail open, so that an unavailable shared backend doesn't reject all the requests.
+type Limiter struct {
+	backend Backend
+}
+
+// NewLimiter returns a Limiter storing its buckets in the given backend.
+func NewLimiter(backend Backend) *Limiter {
+	return &Limiter{backend: backend}
+}
+
+// Admit admits a request of the key, and charges its estimated prompt tokens. It returns a
+// RateLimited error with the time after which the request can be retried if the limit is exceeded.
+func (l *Limiter) Admit(ctx context.Context, key string, limit Limit, estimatedPromptTokens int64) (*Reservation, error) {
+	logger := log.FromContext(ctx)
+	if limit.RequestsPerMinute > 0 {
+		retryAfter, err := l.backend.Take(ctx, requestsBucket(key), limit.RequestsPerMinute, 1)
+		if err != nil {
+			logger.V(logutil.DEFAULT).Error(err, "Failed to take from the requests bucket, admitting the request", "key", key)
+		} else if retryAfter > 0 {
+			return nil, rateLimitedError(key, "requests", retryAfter)
+		}
+	}
+	if limit.TokensPerMinute > 0 {
+		retryAfter, err := l.backend.Take(ctx, tokensBucket(key), limit.TokensPerMinute, estimatedPromptTokens)
+		if err != nil {
+			logger.V(logutil.DEFAULT).Error(err, "Failed to take from the tokens bucket, admitting the request", "key", key)
+		} else if retryAfter > 0 {
+			if limit.RequestsPerMinute > 0 {
+				// The request isn't admitted after all.
+				if err := l.backend.Charge(ctx, requestsBucket(key), limit.RequestsPerMinute, -1); err != nil {
+					logger.V(logutil.DEFAULT).Error(err, "Failed to refund the requests bucket", "key", key)
+				}
+			}
+			return nil, rateLimitedError(key, "tokens", retryAfter)
+		}
+	}
+	return &Reservation{key: key, limit: limit, estimatedPromptTokens: estimatedPromptTokens}, nil
+}
+
+// Complete charges the completion tokens of an admitted request, and corrects its prompt tokens
+// estimate with the actual prompt tokens, when known.
+func (l *Limiter) Complete(ctx context.Context, reservation *Reservation, promptTokens, completionTokens int64) {
+	if reservation == nil || reservation.limit.TokensPerMinute == 0 {
+		return
+	}
+	cost := completionTokens
+	if promptTokens > 0 {
+		cost += promptTokens - reservation.estimatedPromptTokens
+	}
+	if cost == 0 {
+		return
+	}
+	if err := l.backend.Charge(ctx, tokensBucket(reservation.key), reservation.limit.TokensPerMinute, cost); err != nil {
+		log.FromContext(ctx).V(logutil.DEFAULT).Error(err, "Failed to charge the tokens bucket", "key", reservation.key)
+	}
+}
+
+// Release refunds the request and the estimated prompt tokens of an admitted request that isn't
+// served, e.g. because it is dropped by admission control or fails to be scheduled.
+func (l *Limiter) Release(ctx context.Context, reservation *Reservation) {
+	if reservation == nil {
+		return
+	}
+	logger := log.FromContext(ctx)
+	if reservation.limit.RequestsPerMinute > 0 {
+		if err := l.backend.Charge(ctx, requestsBucket(reservation.key), reservation.limit.RequestsPerMinute, -1); err != nil {
+			logger.V(logutil.DEFAULT).Error(err, "Failed to refund the requests bucket", "key", reservation.key)
+		}
+	}
+	if reservation.limit.TokensPerMinute > 0 && reservation.estimatedPromptTokens > 0 {
+		if err := l.backend.Charge(ctx, tokensBucket(reservation.key), reservation.limit.TokensPerMinute, -reservation.estimatedPromptTokens); err != nil {
+			logger.V(logutil.DEFAULT).Error(err, "Failed to refund the tokens bucket", "key", reservation.key)
+		}
+	}
+}
+
+func requestsBucket(key string) string {
+	return "requests/" + key
+}
+
+func tokensBucket(key string) string {
+	return "tokens/" + key
+}
+
+func rateLimitedError(key, kind string, retryAfter time.Duration) error {
+	return errutil.Error{
+		Code:       errutil.RateLimited,
+		Msg:        fmt.Sprintf("%s rate limit of %s exceeded", kind, key),
+		RetryAfter: retryAfter,
+	}
+}
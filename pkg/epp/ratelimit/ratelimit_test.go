@@ -0,0 +1,214 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
+	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
+)
+
+// newTestBackend returns a MemoryBackend whose clock is advanced by the returned function.
+func newTestBackend() (*MemoryBackend, func(time.Duration)) {
+	now := time.Unix(0, 0)
+	backend := NewMemoryBackend()
+	backend.now = func() time.Time { return now }
+	return backend, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestKey(t *testing.T) {
+	objective := func(rateLimit *v1alpha2.RateLimit) *v1alpha2.InferenceObjective {
+		return &v1alpha2.InferenceObjective{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "chat"},
+			Spec:       v1alpha2.InferenceObjectiveSpec{RateLimit: rateLimit},
+		}
+	}
+	tests := []struct {
+		name      string
+		objective *v1alpha2.InferenceObjective
+		wantKey   string
+		wantLimit Limit
+		wantOK    bool
+	}{
+		{
+			name:      "no objective",
+			objective: nil,
+		},
+		{
+			name:      "no rate limit",
+			objective: objective(nil),
+		},
+		{
+			name:      "keyed by objective",
+			objective: objective(&v1alpha2.RateLimit{RequestsPerMinute: ptr.To[int64](10), Key: v1alpha2.RateLimitKeyObjective}),
+			wantKey:   "ns/chat",
+			wantLimit: Limit{RequestsPerMinute: 10},
+			wantOK:    true,
+		},
+		{
+			name:      "keyed by fairness ID",
+			objective: objective(&v1alpha2.RateLimit{TokensPerMinute: ptr.To[int64](1000), Key: v1alpha2.RateLimitKeyFairnessID}),
+			wantKey:   "ns/chat/tenant",
+			wantLimit: Limit{TokensPerMinute: 1000},
+			wantOK:    true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			key, limit, ok := Key(test.objective, "tenant")
+			assert.Equal(t, test.wantOK, ok)
+			assert.Equal(t, test.wantKey, key)
+			assert.Equal(t, test.wantLimit, limit)
+		})
+	}
+}
+
+func TestEstimatePromptTokens(t *testing.T) {
+	assert.Equal(t, int64(0), EstimatePromptTokens(""))
+	assert.Equal(t, int64(1), EstimatePromptTokens("hi"))
+	assert.Equal(t, int64(3), EstimatePromptTokens("hello, world"))
+}
+
+func TestLimiterRequests(t *testing.T) {
+	ctx := context.Background()
+	backend, advance := newTestBackend()
+	limiter := NewLimiter(backend)
+	limit := Limit{RequestsPerMinute: 2}
+
+	for range 2 {
+		_, err := limiter.Admit(ctx, "ns/chat", limit, 10)
+		require.NoError(t, err)
+	}
+	_, err := limiter.Admit(ctx, "ns/chat", limit, 10)
+	var rateLimited errutil.Error
+	require.True(t, errors.As(err, &rateLimited), "expected a rate limited error, got %v", err)
+	assert.Equal(t, errutil.RateLimited, rateLimited.Code)
+	assert.Equal(t, 30*time.Second, rateLimited.RetryAfter)
+
+	// Other keys have their own buckets.
+	_, err = limiter.Admit(ctx, "ns/batch", limit, 10)
+	require.NoError(t, err)
+
+	advance(30 * time.Second)
+	_, err = limiter.Admit(ctx, "ns/chat", limit, 10)
+	require.NoError(t, err)
+}
+
+func TestLimiterTokens(t *testing.T) {
+	ctx := context.Background()
+	backend, advance := newTestBackend()
+	limiter := NewLimiter(backend)
+	limit := Limit{RequestsPerMinute: 10, TokensPerMinute: 600}
+
+	// The first request is admitted with a full bucket, and its completion puts the bucket in debt.
+	reservation, err := limiter.Admit(ctx, "ns/chat", limit, 100)
+	require.NoError(t, err)
+	limiter.Complete(ctx, reservation, 150, 550)
+
+	_, err = limiter.Admit(ctx, "ns/chat", limit, 100)
+	var rateLimited errutil.Error
+	require.True(t, errors.As(err, &rateLimited), "expected a rate limited error, got %v", err)
+	// The bucket is at -100 tokens and refills 10 tokens per second.
+	assert.Equal(t, 10100*time.Millisecond, rateLimited.RetryAfter)
+
+	// The rejected request was refunded to the requests bucket.
+	retryAfter, err := backend.Take(ctx, requestsBucket("ns/chat"), limit.RequestsPerMinute, 0)
+	require.NoError(t, err)
+	assert.Zero(t, retryAfter)
+	assert.InDelta(t, 9, backend.buckets[requestsBucket("ns/chat")].balance, 0.001)
+
+	advance(11 * time.Second)
+	_, err = limiter.Admit(ctx, "ns/chat", limit, 100)
+	require.NoError(t, err)
+}
+
+func TestLimiterRelease(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := newTestBackend()
+	limiter := NewLimiter(backend)
+	limit := Limit{RequestsPerMinute: 1, TokensPerMinute: 100}
+
+	// The released requests are refunded, and don't prevent the next requests.
+	for range 3 {
+		reservation, err := limiter.Admit(ctx, "ns/chat", limit, 100)
+		require.NoError(t, err)
+		limiter.Release(ctx, reservation)
+	}
+	assert.InDelta(t, 1, backend.buckets[requestsBucket("ns/chat")].balance, 0.001)
+	assert.InDelta(t, 100, backend.buckets[tokensBucket("ns/chat")].balance, 0.001)
+
+	limiter.Release(ctx, nil)
+}
+
+type failingBackend struct{}
+
+func (failingBackend) Take(context.Context, string, int64, int64) (time.Duration, error) {
+	return 0, errors.New("unavailable")
+}
+
+func (failingBackend) Charge(context.Context, string, int64, int64) error {
+	return errors.New("unavailable")
+}
+
+func TestLimiterFailsOpen(t *testing.T) {
+	limiter := NewLimiter(failingBackend{})
+	reservation, err := limiter.Admit(context.Background(), "ns/chat", Limit{RequestsPerMinute: 1, TokensPerMinute: 1}, 100)
+	require.NoError(t, err)
+	limiter.Complete(context.Background(), reservation, 100, 100)
+	limiter.Release(context.Background(), reservation)
+}
+
+func TestMemoryBackendCharge(t *testing.T) {
+	ctx := context.Background()
+	backend, advance := newTestBackend()
+
+	require.NoError(t, backend.Charge(ctx, "b", 60, 100))
+	assert.InDelta(t, -40, backend.buckets["b"].balance, 0.001)
+
+	advance(10 * time.Second)
+	require.NoError(t, backend.Charge(ctx, "b", 60, 0))
+	assert.InDelta(t, -30, backend.buckets["b"].balance, 0.001)
+
+	// Refunds don't fill the bucket above its capacity.
+	require.NoError(t, backend.Charge(ctx, "b", 60, -1000))
+	assert.InDelta(t, 60, backend.buckets["b"].balance, 0.001)
+}
+
+func TestMemoryBackendDropsFullBuckets(t *testing.T) {
+	ctx := context.Background()
+	backend, advance := newTestBackend()
+	for i := range maxIdleBuckets {
+		_, err := backend.Take(ctx, string(rune(i)), 60, 1)
+		require.NoError(t, err)
+	}
+	require.NoError(t, backend.Charge(ctx, string(rune(0)), 60, 1000))
+
+	advance(time.Minute)
+	_, err := backend.Take(ctx, "new", 60, 1)
+	require.NoError(t, err)
+	// The bucket in debt isn't full yet, and is kept along with the new one.
+	assert.Len(t, backend.buckets, 2)
+}
@@ -20,26 +20,36 @@ package requestcontrol
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"net"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
 	"sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/common"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
 	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datalayer"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/handlers"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metadata"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/objectives"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/ratelimit"
 	schedulingtypes "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
 	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 	requtil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/request"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/tracing"
 )
 
 // Scheduler defines the interface required by the Director for scheduling.
@@ -52,38 +62,120 @@ type SaturationDetector interface {
 	IsSaturated(ctx context.Context) bool
 }
 
+// OutlierDetector tracks the responses served by each pod and determines which pods are
+// ejected from scheduling.
+type OutlierDetector interface {
+	RecordResponse(pod types.NamespacedName, statusCode int)
+	// RecordAbortedResponse records a response aborted before its end as an error. statusRecorded
+	// tells whether the status code of the response was already recorded as a success.
+	RecordAbortedResponse(pod types.NamespacedName, statusRecorded bool)
+	IsEjected(pod types.NamespacedName) bool
+}
+
+// ObjectiveTracker records the traffic of the InferenceObjectives.
+type ObjectiveTracker interface {
+	// RecordRequest records the reception of a request of the objective.
+	RecordRequest(objective string)
+	// RecordLatency records the latency of a request of an objective with latency targets. A zero
+	// tpot means that the time per output token wasn't observed.
+	RecordLatency(objective string, ttft, tpot time.Duration)
+}
+
+// RateLimiter enforces the rate limits of the InferenceObjectives.
+type RateLimiter interface {
+	// Admit admits a request of the key, and charges its estimated prompt tokens. It returns a
+	// RateLimited error if the limit is exceeded.
+	Admit(ctx context.Context, key string, limit ratelimit.Limit, estimatedPromptTokens int64) (*ratelimit.Reservation, error)
+	// Complete charges the tokens of an admitted request once its response is complete.
+	Complete(ctx context.Context, reservation *ratelimit.Reservation, promptTokens, completionTokens int64)
+	// Release refunds an admitted request that isn't served.
+	Release(ctx context.Context, reservation *ratelimit.Reservation)
+}
+
 // NewDirectorWithConfig creates a new Director instance with all dependencies.
 func NewDirectorWithConfig(datastore datastore.Datastore, scheduler Scheduler, saturationDetector SaturationDetector, config *Config) *Director {
-	return &Director{
-		datastore:           datastore,
-		scheduler:           scheduler,
-		saturationDetector:  saturationDetector,
-		preRequestPlugins:   config.preRequestPlugins,
-		postResponsePlugins: config.postResponsePlugins,
+	d := &Director{
+		datastore:          datastore,
+		saturationDetector: saturationDetector,
+		outlierDetector:    config.outlierDetector,
+		objectiveTracker:   config.objectiveTracker,
+		rateLimiter:        config.rateLimiter,
+		bypassCriticality:  config.bypassCriticality,
 	}
+	d.UpdatePlugins(scheduler, config)
+	return d
 }
 
 // Director orchestrates the request handling flow, including scheduling.
 type Director struct {
-	datastore           datastore.Datastore
-	scheduler           Scheduler
-	saturationDetector  SaturationDetector
-	preRequestPlugins   []PreRequest
-	postResponsePlugins []PostResponse
+	datastore          datastore.Datastore
+	saturationDetector SaturationDetector
+	// pipeline holds the scheduler and the plugins, which are replaced together when the
+	// configuration is reloaded.
+	pipeline         atomic.Pointer[pipeline]
+	outlierDetector  OutlierDetector
+	objectiveTracker ObjectiveTracker
+	rateLimiter      RateLimiter
+	// bypassCriticality is the criticality from which the requests bypass the saturation check.
+	bypassCriticality int
 	// we just need a pointer to an int variable since criticality is a pointer in InferenceObjective
 	// no need to set this in the constructor, since the value we want is the default int val
 	// and value types cannot be nil
 	defaultCriticality int
 }
 
+// pipeline is the scheduler and the request control plugins of the Director.
+type pipeline struct {
+	scheduler              Scheduler
+	requestMutators        []RequestMutator
+	preRequestPlugins      []PreRequest
+	postResponsePlugins    []PostResponse
+	responseHeaderMutators []ResponseHeaderMutator
+	responseBodyMutators   []ResponseBodyMutator
+}
+
+// UpdatePlugins atomically replaces the scheduler and the request control plugins of the Director
+// with the given scheduler and the plugins of the given config. The other dependencies set in the
+// config, e.g. the outlier detector, are ignored. The requests being processed keep the plugins
+// they started each phase with.
+func (d *Director) UpdatePlugins(scheduler Scheduler, config *Config) {
+	d.pipeline.Store(&pipeline{
+		scheduler:              scheduler,
+		requestMutators:        config.requestMutators,
+		preRequestPlugins:      config.preRequestPlugins,
+		postResponsePlugins:    config.postResponsePlugins,
+		responseHeaderMutators: config.responseHeaderMutators,
+		responseBodyMutators:   config.responseBodyMutators,
+	})
+}
+
 // HandleRequest orchestrates the request lifecycle:
 //  1. Parses request details.
-//  2. Calls admitRequest for admission control.
+//  2. Calls admitRequest for admission control, and enforces the rate limit of the objective.
 //  3. Calls Scheduler.Schedule if request is approved.
-//  4. Calls prepareRequest to populate RequestContext with result and call PreRequest plugins.
+//  4. Calls prepareRequest to populate RequestContext with result and call RequestMutator and PreRequest plugins.
 //
 // It always returns the requestContext even in the error case, as the request context is used in error handling.
 func (d *Director) HandleRequest(ctx context.Context, reqCtx *handlers.RequestContext) (*handlers.RequestContext, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "Director.HandleRequest")
+	reqCtx, err := d.handleRequest(ctx, reqCtx)
+	if err != nil && reqCtx.RateLimitReservation != nil {
+		// The request isn't served, it doesn't count against the rate limit.
+		d.rateLimiter.Release(ctx, reqCtx.RateLimitReservation)
+		reqCtx.RateLimitReservation = nil
+	}
+	span.SetAttributes(
+		tracing.IncomingModelKey.String(reqCtx.IncomingModelName),
+		tracing.TargetModelKey.String(reqCtx.TargetModelName),
+		tracing.ObjectiveKey.String(reqCtx.ObjectiveKey),
+		tracing.EndpointKey.String(reqCtx.TargetEndpoint),
+	)
+	tracing.EndSpan(span, err)
+	return reqCtx, err
+}
+
+// handleRequest implements HandleRequest within its span.
+func (d *Director) handleRequest(ctx context.Context, reqCtx *handlers.RequestContext) (*handlers.RequestContext, error) {
 	logger := log.FromContext(ctx)
 
 	// --- 1. Parse Request, Resolve Target Models, and Determine Parameters ---
@@ -100,9 +192,14 @@ func (d *Director) HandleRequest(ctx context.Context, reqCtx *handlers.RequestCo
 	}
 	reqCtx.Request.Body["model"] = reqCtx.TargetModelName
 
-	prompt, err := requtil.ExtractPromptFromRequestBody(requestBodyMap)
-	if err != nil {
-		return reqCtx, err
+	kind := requtil.ExtractRequestKind(reqCtx.Request.Headers[requtil.PathHeaderKey], requestBodyMap)
+	prompt := ""
+	if !reqCtx.Request.HeadersOnly {
+		// Without the body, the request is scheduled on its model name and headers only.
+		var err error
+		if prompt, err = requtil.ExtractPrompt(kind, requestBodyMap); err != nil {
+			return reqCtx, err
+		}
 	}
 	infObjective := d.datastore.ObjectiveGet(reqCtx.ObjectiveKey)
 	if infObjective == nil {
@@ -112,26 +209,33 @@ func (d *Director) HandleRequest(ctx context.Context, reqCtx *handlers.RequestCo
 				Criticality: &d.defaultCriticality,
 			},
 		}
-	} else if infObjective.Spec.Criticality == nil {
-		// Default to 0 if not specified.
-		infObjective.Spec.Criticality = &d.defaultCriticality
+	} else {
+		if infObjective.Spec.Criticality == nil {
+			// Default to 0 if not specified.
+			infObjective.Spec.Criticality = &d.defaultCriticality
+		}
+		if d.objectiveTracker != nil {
+			d.objectiveTracker.RecordRequest(reqCtx.ObjectiveKey)
+		}
 	}
 
 	// Prepare LLMRequest (needed for both saturation detection and Scheduler)
 	reqCtx.SchedulingRequest = &schedulingtypes.LLMRequest{
 		RequestId:   reqCtx.Request.Headers[requtil.RequestIdHeaderKey],
 		TargetModel: reqCtx.TargetModelName,
+		Kind:        kind,
 		Prompt:      prompt,
 		Headers:     reqCtx.Request.Headers,
+		Latency:     objectives.LatencyObjective(infObjective),
 	}
 
-	logger = logger.WithValues("objectiveKey", reqCtx.ObjectiveKey, "incomingModelName", reqCtx.IncomingModelName, "targetModelName", reqCtx.TargetModelName, "criticality", infObjective.Spec.Criticality)
+	logger = logger.WithValues("objectiveKey", reqCtx.ObjectiveKey, "incomingModelName", reqCtx.IncomingModelName, "targetModelName", reqCtx.TargetModelName, "kind", kind, "criticality", infObjective.Spec.Criticality)
 
 	ctx = log.IntoContext(ctx, logger)
 	logger.V(logutil.DEBUG).Info("LLM request assembled")
 
-	// --- 2. Admission Control check --
-	if err := d.admitRequest(ctx, *infObjective.Spec.Criticality, reqCtx.FairnessID); err != nil {
+	// --- 2. Admission Control and Rate Limit checks --
+	if err := d.admit(ctx, reqCtx, infObjective, prompt); err != nil {
 		return reqCtx, err
 	}
 
@@ -140,7 +244,12 @@ func (d *Director) HandleRequest(ctx context.Context, reqCtx *handlers.RequestCo
 	if len(candidatePods) == 0 {
 		return reqCtx, errutil.Error{Code: errutil.ServiceUnavailable, Msg: "failed to find candidate pods for serving the request"}
 	}
-	result, err := d.scheduler.Schedule(ctx, reqCtx.SchedulingRequest, candidatePods)
+	if !reqCtx.RequestReceivedTimestamp.IsZero() {
+		reqCtx.QueueTime = time.Since(reqCtx.RequestReceivedTimestamp)
+	}
+	schedulingStart := time.Now()
+	result, err := d.pipeline.Load().scheduler.Schedule(ctx, reqCtx.SchedulingRequest, candidatePods)
+	reqCtx.SchedulingLatency = time.Since(schedulingStart)
 	if err != nil {
 		return reqCtx, errutil.Error{Code: errutil.InferencePoolResourceExhausted, Msg: fmt.Errorf("failed to find target pod: %w", err).Error()}
 	}
@@ -156,6 +265,28 @@ func (d *Director) HandleRequest(ctx context.Context, reqCtx *handlers.RequestCo
 	return reqCtx, nil
 }
 
+// admit calls admitRequest for admission control, and then enforces the rate limit of the
+// objective of the request, if any, so that the requests dropped for saturation don't use up the
+// rate limit.
+func (d *Director) admit(ctx context.Context, reqCtx *handlers.RequestContext, infObjective *v1alpha2.InferenceObjective, prompt string) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "Director.admit", trace.WithAttributes(tracing.CriticalityKey.Int(*infObjective.Spec.Criticality)))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	if err := d.admitRequest(ctx, *infObjective.Spec.Criticality, reqCtx.FairnessID); err != nil {
+		return err
+	}
+	if d.rateLimiter != nil {
+		if key, limit, ok := ratelimit.Key(infObjective, reqCtx.FairnessID); ok {
+			reservation, err := d.rateLimiter.Admit(ctx, key, limit, ratelimit.EstimatePromptTokens(prompt))
+			if err != nil {
+				return err
+			}
+			reqCtx.RateLimitReservation = reservation
+		}
+	}
+	return nil
+}
+
 // admitRequest handles admission control to decide whether or not to accept the request
 // based on the request criticality and system saturation state.
 func (d *Director) admitRequest(ctx context.Context, requestCriticality int, fairnessID string) error {
@@ -165,9 +296,7 @@ func (d *Director) admitRequest(ctx context.Context, requestCriticality int, fai
 
 	// This will be removed in favor of a more robust implementation (Flow Control) in the very near future.
 	// For now we will keep similar behavior to the previous implementation.
-	// TODO: Make this a configurable value.
-	// Tracking issue https://github.com/kubernetes-sigs/gateway-api-inference-extension/issues/1347
-	if requestCriticality >= 2 {
+	if requestCriticality >= d.bypassCriticality {
 		logger.V(logutil.DEBUG).Info("Critical request bypassing saturation check.")
 		return nil
 	}
@@ -186,6 +315,7 @@ func (d *Director) admitRequest(ctx context.Context, requestCriticality int, fai
 // getCandidatePodsForScheduling gets the list of relevant endpoints for the scheduling cycle from the datastore.
 // according to EPP protocol, if "x-gateway-destination-endpoint-subset" is set on the request metadata and specifies
 // a subset of endpoints, only these endpoints will be considered as candidates for the scheduler.
+// Pods ejected by outlier detection are never candidates.
 // Snapshot pod metrics from the datastore to:
 // 1. Reduce concurrent access to the datastore.
 // 2. Ensure consistent data during the scheduling operation of a request between all scheduling cycles.
@@ -194,34 +324,45 @@ func (d *Director) getCandidatePodsForScheduling(ctx context.Context, requestMet
 
 	subsetMap, found := requestMetadata[metadata.SubsetFilterNamespace].(map[string]any)
 	if !found {
-		return d.toSchedulerPodMetrics(d.datastore.PodList(backendmetrics.AllPodsPredicate))
+		return d.toSchedulerPodMetrics(d.podList(backendmetrics.AllPodsPredicate))
 	}
 
 	// Check if endpoint key is present in the subset map and ensure there is at least one value
 	endpointSubsetList, found := subsetMap[metadata.SubsetFilterKey].([]any)
 	if !found {
-		return d.toSchedulerPodMetrics(d.datastore.PodList(backendmetrics.AllPodsPredicate))
+		return d.toSchedulerPodMetrics(d.podList(backendmetrics.AllPodsPredicate))
 	} else if len(endpointSubsetList) == 0 {
 		loggerTrace.Info("found empty subset filter in request metadata, filtering all pods")
 		return []schedulingtypes.Pod{}
 	}
 
-	// Create a map of endpoint addresses for easy lookup
+	// Create maps of the subset's addresses and endpoints for easy lookup. The endpoint is formatted
+	// as "<address>:<port>" (ex. "10.0.1.0:8080"), which selects the data parallel rank of a pod
+	// listening on the port, or as "<address>", which selects all the ranks of the pod.
+	addresses := make(map[string]bool)
 	endpoints := make(map[string]bool)
 	for _, endpoint := range endpointSubsetList {
-		// Extract address from endpoint
-		// The endpoint is formatted as "<address>:<port>" (ex. "10.0.1.0:8080")
-		epStr := strings.Split(endpoint.(string), ":")[0]
-		endpoints[epStr] = true
+		epStr := endpoint.(string)
+		if host, _, err := net.SplitHostPort(epStr); err == nil {
+			endpoints[epStr] = true
+			// The endpoints of the pods whose port isn't known are matched by address.
+			endpoints[host] = true
+		} else {
+			addresses[epStr] = true
+		}
 	}
 
 	podTotalCount := 0
-	podFitleredList := d.datastore.PodList(func(pm backendmetrics.PodMetrics) bool {
+	podFitleredList := d.podList(func(pm backendmetrics.PodMetrics) bool {
 		podTotalCount++
-		if _, found := endpoints[pm.GetPod().Address]; found {
+		pod := pm.GetPod()
+		if addresses[pod.Address] {
 			return true
 		}
-		return false
+		if pod.Port == 0 {
+			return endpoints[pod.Address]
+		}
+		return endpoints[net.JoinHostPort(pod.Address, strconv.Itoa(int(pod.Port)))]
 	})
 
 	loggerTrace.Info("filtered candidate pods by subset filtering", "podTotalCount", podTotalCount, "filteredCount", len(podFitleredList))
@@ -229,8 +370,18 @@ func (d *Director) getCandidatePodsForScheduling(ctx context.Context, requestMet
 	return d.toSchedulerPodMetrics(podFitleredList)
 }
 
-// prepareRequest populates the RequestContext and calls the registered PreRequest plugins
-// for allowing plugging customized logic based on the scheduling result.
+// podList lists the pods matching the given predicate that are not ejected by outlier detection.
+func (d *Director) podList(predicate func(backendmetrics.PodMetrics) bool) []backendmetrics.PodMetrics {
+	if d.outlierDetector == nil {
+		return d.datastore.PodList(predicate)
+	}
+	return d.datastore.PodList(func(pm backendmetrics.PodMetrics) bool {
+		return predicate(pm) && !d.outlierDetector.IsEjected(pm.GetPod().NamespacedName)
+	})
+}
+
+// prepareRequest populates the RequestContext and calls the registered RequestMutator and PreRequest
+// plugins for allowing plugging customized logic based on the scheduling result.
 func (d *Director) prepareRequest(ctx context.Context, reqCtx *handlers.RequestContext, result *schedulingtypes.SchedulingResult) (*handlers.RequestContext, error) {
 	logger := log.FromContext(ctx)
 	if result == nil || len(result.ProfileResults) == 0 {
@@ -242,12 +393,11 @@ func (d *Director) prepareRequest(ctx context.Context, reqCtx *handlers.RequestC
 		return reqCtx, err
 	}
 	targetPods := []*backend.Pod{}
-	targetPort := int(pool.Spec.TargetPortNumber)
 	targetEndpoints := []string{}
 
 	for _, pod := range result.ProfileResults[result.PrimaryProfileName].TargetPods {
 		curPod := pod.GetPod()
-		curEndpoint := net.JoinHostPort(curPod.Address, strconv.Itoa(targetPort))
+		curEndpoint := net.JoinHostPort(curPod.Address, strconv.Itoa(targetPort(curPod, pool)))
 		targetPods = append(targetPods, curPod)
 		targetEndpoints = append(targetEndpoints, curEndpoint)
 	}
@@ -258,33 +408,166 @@ func (d *Director) prepareRequest(ctx context.Context, reqCtx *handlers.RequestC
 	reqCtx.TargetPod = targetPods[0]
 	reqCtx.TargetEndpoint = multiEndpointString
 
-	d.runPreRequestPlugins(ctx, reqCtx.SchedulingRequest, result, targetPort)
+	if err := d.runRequestMutators(ctx, reqCtx); err != nil {
+		return reqCtx, err
+	}
+	d.runPreRequestPlugins(ctx, reqCtx.SchedulingRequest, result, targetPort(targetPods[0], pool))
 
 	return reqCtx, nil
 }
 
+// targetPort returns the port of the endpoint, which is the port of its data parallel rank, or the
+// pool's first target port if the endpoint's port isn't known.
+func targetPort(pod *backend.Pod, pool *v1.InferencePool) int {
+	if pod.Port != 0 {
+		return int(pod.Port)
+	}
+	if ports := common.TargetPorts(&pool.Spec); len(ports) > 0 {
+		return int(ports[0])
+	}
+	return 0
+}
+
 func (d *Director) toSchedulerPodMetrics(pods []backendmetrics.PodMetrics) []schedulingtypes.Pod {
 	pm := make([]schedulingtypes.Pod, len(pods))
 	for i, pod := range pods {
-		pm[i] = &schedulingtypes.PodMetrics{Pod: pod.GetPod().Clone(), MetricsState: pod.GetMetrics().Clone()}
+		pm[i] = &schedulingtypes.PodMetrics{Pod: pod.GetPod().Clone(), MetricsState: pod.GetMetrics().Clone(), AttributeMap: cloneAttributes(pod)}
 	}
 
 	return pm
 }
 
+// cloneAttributes snapshots the extended attributes of an endpoint.
+func cloneAttributes(pod backendmetrics.PodMetrics) *datalayer.Attributes {
+	attributes := datalayer.NewAttributes()
+	for _, key := range pod.Keys() {
+		if value, ok := pod.Get(key); ok { // Get returns a clone of the stored value
+			attributes.Put(key, value)
+		}
+	}
+	return attributes
+}
+
 func (d *Director) HandleResponse(ctx context.Context, reqCtx *handlers.RequestContext) (*handlers.RequestContext, error) {
 	response := &Response{
 		RequestId: reqCtx.Request.Headers[requtil.RequestIdHeaderKey],
 		Headers:   reqCtx.Response.Headers,
 	}
 
+	if d.outlierDetector != nil && reqCtx.TargetPod != nil {
+		if statusCode, ok := responseStatusCode(reqCtx.Response.Headers); ok {
+			d.outlierDetector.RecordResponse(reqCtx.TargetPod.NamespacedName, statusCode)
+		}
+	}
+
 	// TODO: to extend fallback functionality, handle cases where target pod is unavailable
 	// https://github.com/kubernetes-sigs/gateway-api-inference-extension/issues/1224
 	d.runPostResponsePlugins(ctx, reqCtx.SchedulingRequest, response, reqCtx.TargetPod)
+	d.runResponseHeaderMutators(ctx, reqCtx)
 
 	return reqCtx, nil
 }
 
+// HandleStreamEnd charges the tokens of an aborted response with the usage seen so far, and records
+// it as an error of the target pod with the outlier detector. A response with an error status code
+// was already recorded as an error by HandleResponse.
+func (d *Director) HandleStreamEnd(ctx context.Context, reqCtx *handlers.RequestContext, aborted bool) {
+	d.completeRateLimit(ctx, reqCtx)
+	if !aborted || d.outlierDetector == nil || reqCtx.TargetPod == nil {
+		return
+	}
+	statusCode, statusRecorded := responseStatusCode(reqCtx.Response.Headers)
+	if statusRecorded && statusCode >= 500 {
+		return
+	}
+	d.outlierDetector.RecordAbortedResponse(reqCtx.TargetPod.NamespacedName, statusRecorded)
+}
+
+// HandleResponseBodyChunk runs the ResponseBodyMutator plugins on a chunk of the response body and
+// returns the chunk to send to the client.
+func (d *Director) HandleResponseBodyChunk(ctx context.Context, reqCtx *handlers.RequestContext, body []byte, endOfStream bool) []byte {
+	d.trackLatency(reqCtx, endOfStream)
+	if endOfStream {
+		d.completeRateLimit(ctx, reqCtx)
+	}
+	mutators := d.pipeline.Load().responseBodyMutators
+	if len(mutators) == 0 {
+		return body
+	}
+	loggerDebug := log.FromContext(ctx).V(logutil.DEBUG)
+	chunk := &ResponseBodyChunk{
+		Body:        body,
+		IsStreaming: isStreamingResponse(reqCtx.Response.Headers),
+		EndOfStream: endOfStream,
+	}
+	for _, plugin := range mutators {
+		loggerDebug.Info("Running response body mutator plugin", "plugin", plugin.TypedName())
+		pluginCtx, span := tracing.StartPluginSpan(ctx, ResponseBodyMutatorExtensionPoint, plugin.TypedName().Type, plugin.TypedName().Name)
+		before := time.Now()
+		plugin.MutateResponseBody(pluginCtx, reqCtx.SchedulingRequest, chunk, reqCtx.TargetPod)
+		span.End()
+		metrics.RecordPluginProcessingLatency(ResponseBodyMutatorExtensionPoint, plugin.TypedName().Type, plugin.TypedName().Name, time.Since(before))
+		loggerDebug.Info("Completed running response body mutator plugin successfully", "plugin", plugin.TypedName())
+	}
+	return chunk.Body
+}
+
+// completeRateLimit charges the tokens of the request to its rate limit once the response is
+// complete or aborted, with the usage seen so far. The reservation is completed only once.
+func (d *Director) completeRateLimit(ctx context.Context, reqCtx *handlers.RequestContext) {
+	if d.rateLimiter == nil || reqCtx.RateLimitReservation == nil {
+		return
+	}
+	d.rateLimiter.Complete(ctx, reqCtx.RateLimitReservation, int64(reqCtx.Usage.PromptTokens), int64(reqCtx.Usage.CompletionTokens))
+	reqCtx.RateLimitReservation = nil
+}
+
+// trackLatency records the latency of the successful requests of the objectives with latency
+// targets once their response is complete. The time per output token is only observed on streamed
+// responses reporting their token usage.
+func (d *Director) trackLatency(reqCtx *handlers.RequestContext, endOfStream bool) {
+	if d.objectiveTracker == nil || reqCtx.SchedulingRequest == nil || reqCtx.SchedulingRequest.Latency == nil {
+		return
+	}
+	now := time.Now()
+	if reqCtx.FirstResponseChunkTimestamp.IsZero() {
+		reqCtx.FirstResponseChunkTimestamp = now
+	}
+	if !endOfStream || reqCtx.RequestReceivedTimestamp.IsZero() {
+		return
+	}
+	if statusCode, ok := responseStatusCode(reqCtx.Response.Headers); ok && (statusCode < 200 || statusCode >= 300) {
+		return
+	}
+	ttft := reqCtx.FirstResponseChunkTimestamp.Sub(reqCtx.RequestReceivedTimestamp)
+	var tpot time.Duration
+	if isStreamingResponse(reqCtx.Response.Headers) && reqCtx.Usage.CompletionTokens > 1 {
+		tpot = now.Sub(reqCtx.FirstResponseChunkTimestamp) / time.Duration(reqCtx.Usage.CompletionTokens-1)
+	}
+	d.objectiveTracker.RecordLatency(reqCtx.ObjectiveKey, ttft, tpot)
+}
+
+// isStreamingResponse returns true if the response is a stream of server-sent events.
+func isStreamingResponse(headers map[string]string) bool {
+	return strings.Contains(headers["content-type"], "text/event-stream")
+}
+
+// responseStatusCode returns the HTTP status code of the response from its headers.
+func responseStatusCode(headers map[string]string) (int, bool) {
+	value, ok := headers[":status"]
+	if !ok {
+		value, ok = headers["status"]
+	}
+	if !ok {
+		return 0, false
+	}
+	statusCode, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false
+	}
+	return statusCode, true
+}
+
 func (d *Director) GetRandomPod() *backend.Pod {
 	pods := d.datastore.PodList(backendmetrics.AllPodsPredicate)
 	if len(pods) == 0 {
@@ -295,24 +578,93 @@ func (d *Director) GetRandomPod() *backend.Pod {
 	return pod.GetPod()
 }
 
+// runRequestMutators runs the RequestMutator plugins on the request body and headers, which are
+// edited in place. Errors which are not errutil.Error fail the request with an internal error.
+func (d *Director) runRequestMutators(ctx context.Context, reqCtx *handlers.RequestContext) error {
+	mutators := d.pipeline.Load().requestMutators
+	if len(mutators) == 0 {
+		return nil
+	}
+	if reqCtx.Request.HeadersOnly {
+		// The body isn't forwarded by the EPP, the mutations of the placeholder body would be lost.
+		log.FromContext(ctx).V(logutil.VERBOSE).Info("Skipping the request mutator plugins, the request body isn't processed in the HEADERS_ONLY mode",
+			"plugins", len(mutators))
+		return nil
+	}
+	loggerDebug := log.FromContext(ctx).V(logutil.DEBUG)
+	mutation := NewRequestMutation(reqCtx.Request.Body, reqCtx.Request.Headers)
+	for _, plugin := range mutators {
+		loggerDebug.Info("Running request mutator plugin", "plugin", plugin.TypedName())
+		pluginCtx, span := tracing.StartPluginSpan(ctx, RequestMutatorExtensionPoint, plugin.TypedName().Type, plugin.TypedName().Name)
+		before := time.Now()
+		err := plugin.MutateRequest(pluginCtx, reqCtx.SchedulingRequest, mutation, reqCtx.TargetPod)
+		tracing.EndSpan(span, err)
+		metrics.RecordPluginProcessingLatency(RequestMutatorExtensionPoint, plugin.TypedName().Type, plugin.TypedName().Name, time.Since(before))
+		if err != nil {
+			var gatewayErr errutil.Error
+			if errors.As(err, &gatewayErr) {
+				return gatewayErr
+			}
+			return errutil.Error{Code: errutil.Internal, Msg: fmt.Sprintf("request mutator %s failed: %v", plugin.TypedName(), err)}
+		}
+		loggerDebug.Info("Completed running request mutator plugin successfully", "plugin", plugin.TypedName())
+	}
+	// The mutators may have replaced the body map.
+	reqCtx.Request.Body = mutation.Body
+	reqCtx.Request.RemovedHeaders = append(reqCtx.Request.RemovedHeaders, mutation.RemovedHeaders()...)
+	return nil
+}
+
 func (d *Director) runPreRequestPlugins(ctx context.Context, request *schedulingtypes.LLMRequest,
 	schedulingResult *schedulingtypes.SchedulingResult, targetPort int) {
 	loggerDebug := log.FromContext(ctx).V(logutil.DEBUG)
-	for _, plugin := range d.preRequestPlugins {
+	for _, plugin := range d.pipeline.Load().preRequestPlugins {
 		loggerDebug.Info("Running pre-request plugin", "plugin", plugin.TypedName())
+		pluginCtx, span := tracing.StartPluginSpan(ctx, PreRequestExtensionPoint, plugin.TypedName().Type, plugin.TypedName().Name)
 		before := time.Now()
-		plugin.PreRequest(ctx, request, schedulingResult, targetPort)
+		plugin.PreRequest(pluginCtx, request, schedulingResult, targetPort)
+		span.End()
 		metrics.RecordPluginProcessingLatency(PreRequestExtensionPoint, plugin.TypedName().Type, plugin.TypedName().Name, time.Since(before))
 		loggerDebug.Info("Completed running pre-request plugin successfully", "plugin", plugin.TypedName())
 	}
 }
 
+// runResponseHeaderMutators runs the ResponseHeaderMutator plugins on the response headers, which
+// are edited in place. The Content-Length header is removed if the response body may be mutated.
+func (d *Director) runResponseHeaderMutators(ctx context.Context, reqCtx *handlers.RequestContext) {
+	p := d.pipeline.Load()
+	if len(p.responseHeaderMutators) == 0 && len(p.responseBodyMutators) == 0 {
+		return
+	}
+	loggerDebug := log.FromContext(ctx).V(logutil.DEBUG)
+	mutation := NewResponseMutation(reqCtx.Response.Headers)
+	mutation.QueueTime = reqCtx.QueueTime
+	mutation.SchedulingLatency = reqCtx.SchedulingLatency
+	for _, plugin := range p.responseHeaderMutators {
+		loggerDebug.Info("Running response header mutator plugin", "plugin", plugin.TypedName())
+		pluginCtx, span := tracing.StartPluginSpan(ctx, ResponseHeaderMutatorExtensionPoint, plugin.TypedName().Type, plugin.TypedName().Name)
+		before := time.Now()
+		plugin.MutateResponseHeaders(pluginCtx, reqCtx.SchedulingRequest, mutation, reqCtx.TargetPod)
+		span.End()
+		metrics.RecordPluginProcessingLatency(ResponseHeaderMutatorExtensionPoint, plugin.TypedName().Type, plugin.TypedName().Name, time.Since(before))
+		loggerDebug.Info("Completed running response header mutator plugin successfully", "plugin", plugin.TypedName())
+	}
+	reqCtx.Response.RemovedHeaders = append(reqCtx.Response.RemovedHeaders, mutation.RemovedHeaders()...)
+	if len(p.responseBodyMutators) > 0 {
+		// The length of the mutated body is unknown when the headers are sent.
+		delete(reqCtx.Response.Headers, contentLengthHeader)
+		reqCtx.Response.RemovedHeaders = append(reqCtx.Response.RemovedHeaders, contentLengthHeader)
+	}
+}
+
 func (d *Director) runPostResponsePlugins(ctx context.Context, request *schedulingtypes.LLMRequest, response *Response, targetPod *backend.Pod) {
 	loggerDebug := log.FromContext(ctx).V(logutil.DEBUG)
-	for _, plugin := range d.postResponsePlugins {
+	for _, plugin := range d.pipeline.Load().postResponsePlugins {
 		loggerDebug.Info("Running post-response plugin", "plugin", plugin.TypedName())
+		pluginCtx, span := tracing.StartPluginSpan(ctx, PostResponseExtensionPoint, plugin.TypedName().Type, plugin.TypedName().Name)
 		before := time.Now()
-		plugin.PostResponse(ctx, request, response, targetPod)
+		plugin.PostResponse(pluginCtx, request, response, targetPod)
+		span.End()
 		metrics.RecordPluginProcessingLatency(PostResponseExtensionPoint, plugin.TypedName().Type, plugin.TypedName().Name, time.Since(before))
 		loggerDebug.Info("Completed running post-response plugin successfully", "plugin", plugin.TypedName())
 	}
@@ -20,12 +20,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -34,17 +38,21 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	"sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
 	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datalayer"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/handlers"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metadata"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/ratelimit"
 	schedulingtypes "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
 	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 	requtil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/request"
 	testutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/testing"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/tracing"
 )
 
 // --- Mocks ---
@@ -169,6 +177,7 @@ func TestDirector_HandleRequest(t *testing.T) {
 	tests := []struct {
 		name                   string
 		reqBodyMap             map[string]any
+		path                   string
 		mockSaturationDetector *mockSaturationDetector
 		inferenceObjectiveName string
 		schedulerMockSetup     func(m *mockScheduler)
@@ -176,6 +185,7 @@ func TestDirector_HandleRequest(t *testing.T) {
 		wantReqCtx             *handlers.RequestContext // Fields to check in the returned RequestContext
 		wantMutatedBodyModel   string                   // Expected model in reqCtx.Request.Body after PostDispatch
 		targetModelName        string                   // Expected model name after target model resolution
+		wantKind               schedulingtypes.RequestKind
 	}{
 		{
 			name: "successful completions request (critical, saturation ignored)",
@@ -258,6 +268,58 @@ func TestDirector_HandleRequest(t *testing.T) {
 			inferenceObjectiveName: objectiveName,
 			targetModelName:        model,
 		},
+		{
+			name: "successful embeddings request",
+			reqBodyMap: map[string]any{
+				"model": model,
+				"input": []any{"first text", "second text"},
+			},
+			path:                   "/v1/embeddings",
+			mockSaturationDetector: &mockSaturationDetector{isSaturated: false},
+			schedulerMockSetup: func(m *mockScheduler) {
+				m.scheduleResults = defaultSuccessfulScheduleResults
+			},
+			wantReqCtx: &handlers.RequestContext{
+				ObjectiveKey:    objectiveName,
+				TargetModelName: model,
+				TargetPod: &backend.Pod{
+					NamespacedName: types.NamespacedName{Namespace: "default", Name: "pod1"},
+					Address:        "192.168.1.100",
+				},
+				TargetEndpoint: "192.168.1.100:8000,192.168.2.100:8000,192.168.4.100:8000",
+			},
+			wantMutatedBodyModel:   model,
+			inferenceObjectiveName: objectiveName,
+			targetModelName:        model,
+			wantKind:               schedulingtypes.RequestKindEmbeddings,
+		},
+		{
+			name: "successful responses request",
+			reqBodyMap: map[string]any{
+				"model":        model,
+				"instructions": "be critical",
+				"input":        "review this dish",
+			},
+			path:                   "/v1/responses",
+			mockSaturationDetector: &mockSaturationDetector{isSaturated: false},
+			schedulerMockSetup: func(m *mockScheduler) {
+				m.scheduleResults = defaultSuccessfulScheduleResults
+			},
+			wantMutatedBodyModel:   model,
+			inferenceObjectiveName: objectiveName,
+			targetModelName:        model,
+			wantKind:               schedulingtypes.RequestKindResponses,
+		},
+		{
+			name: "rerank request without query, expect err",
+			reqBodyMap: map[string]any{
+				"model":     model,
+				"documents": []any{"a", "b"},
+			},
+			path:                   "/v1/rerank",
+			wantErrCode:            errutil.BadRequest,
+			inferenceObjectiveName: objectiveName,
+		},
 		{
 			name: "successful completions request (sheddable, not saturated)",
 			reqBodyMap: map[string]any{
@@ -398,6 +460,7 @@ func TestDirector_HandleRequest(t *testing.T) {
 					Body: make(map[string]any),
 					Headers: map[string]string{
 						requtil.RequestIdHeaderKey: "test-req-id-" + test.name, // Ensure a default request ID
+						requtil.PathHeaderKey:      test.path,
 					},
 				},
 				ObjectiveKey:    test.inferenceObjectiveName,
@@ -429,6 +492,10 @@ func TestDirector_HandleRequest(t *testing.T) {
 				assert.Equal(t, test.wantReqCtx.TargetEndpoint, returnedReqCtx.TargetEndpoint, "reqCtx.TargetEndpoint mismatch")
 			}
 
+			if test.wantKind != "" {
+				assert.Equal(t, test.wantKind, returnedReqCtx.SchedulingRequest.Kind, "reqCtx.SchedulingRequest.Kind mismatch")
+			}
+
 			if test.wantMutatedBodyModel != "" {
 				assert.NotNil(t, returnedReqCtx.Request.Body, "Expected mutated body, but reqCtx.Request.Body is nil")
 				assert.Equal(t, test.wantMutatedBodyModel, returnedReqCtx.Request.Body["model"],
@@ -562,7 +629,7 @@ func TestGetCandidatePodsForScheduling(t *testing.T) {
 
 			diff := cmp.Diff(test.output, got, cmpopts.SortSlices(func(a, b schedulingtypes.Pod) bool {
 				return a.GetPod().NamespacedName.String() < b.GetPod().NamespacedName.String()
-			}))
+			}), cmpopts.IgnoreFields(schedulingtypes.PodMetrics{}, "AttributeMap"))
 			if diff != "" {
 				t.Errorf("Unexpected output (-want +got): %v", diff)
 			}
@@ -570,6 +637,195 @@ func TestGetCandidatePodsForScheduling(t *testing.T) {
 	}
 }
 
+// TestDirector_DataParallelRanks tests the scheduling of the data parallel ranks of the pods of a
+// pool with several target ports.
+func TestDirector_DataParallelRanks(t *testing.T) {
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+	pmf := backendmetrics.NewPodMetricsFactory(&backendmetrics.FakePodMetricsClient{}, time.Second)
+	ds := datastore.NewDatastore(t.Context(), pmf)
+	pool := testutil.MakeInferencePool("pool").Namespace("default").Selector(map[string]string{"app": "inference"}).
+		TargetPorts(8000, 8001).ObjRef()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	if err := ds.PoolSet(ctx, fake.NewClientBuilder().WithScheme(scheme).Build(), pool); err != nil {
+		t.Fatalf("Error while setting inference pool: %v", err)
+	}
+	ds.PodUpdateOrAddIfNotExist(testutil.MakePod("pod1").Namespace("default").IP("10.0.0.1").ReadyCondition().ObjRef())
+	director := NewDirectorWithConfig(ds, &mockScheduler{}, &mockSaturationDetector{}, NewConfig())
+
+	candidateNames := func(data []any) []string {
+		names := []string{}
+		for _, pod := range director.getCandidatePodsForScheduling(ctx, map[string]any{
+			metadata.SubsetFilterNamespace: map[string]any{metadata.SubsetFilterKey: data},
+		}) {
+			names = append(names, pod.GetPod().NamespacedName.Name)
+		}
+		slices.Sort(names)
+		return names
+	}
+	assert.Equal(t, []string{"pod1:8000", "pod1:8001"}, candidateNames([]any{"10.0.0.1"}))
+	assert.Equal(t, []string{"pod1:8001"}, candidateNames([]any{"10.0.0.1:8001"}))
+	assert.Equal(t, []string{}, candidateNames([]any{"10.0.0.1:8002"}))
+
+	result := &schedulingtypes.SchedulingResult{
+		ProfileResults: map[string]*schedulingtypes.ProfileRunResult{
+			"testProfile": {
+				TargetPods: []schedulingtypes.Pod{
+					&schedulingtypes.PodMetrics{Pod: &backend.Pod{Address: "10.0.0.1", Port: 8001}},
+					&schedulingtypes.PodMetrics{Pod: &backend.Pod{Address: "10.0.0.1", Port: 8000}},
+					// The endpoints whose port isn't known are routed to the first target port.
+					&schedulingtypes.PodMetrics{Pod: &backend.Pod{Address: "10.0.0.2"}},
+				},
+			},
+		},
+		PrimaryProfileName: "testProfile",
+	}
+	reqCtx, err := director.prepareRequest(ctx, &handlers.RequestContext{}, result)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1:8001,10.0.0.1:8000,10.0.0.2:8000", reqCtx.TargetEndpoint)
+	assert.Equal(t, int32(8001), reqCtx.TargetPod.Port)
+}
+
+type mockOutlierDetector struct {
+	ejected   map[types.NamespacedName]bool
+	responses map[types.NamespacedName][]int
+	aborted   map[types.NamespacedName][]bool
+}
+
+func (m *mockOutlierDetector) RecordResponse(pod types.NamespacedName, statusCode int) {
+	if m.responses == nil {
+		m.responses = make(map[types.NamespacedName][]int)
+	}
+	m.responses[pod] = append(m.responses[pod], statusCode)
+}
+
+func (m *mockOutlierDetector) RecordAbortedResponse(pod types.NamespacedName, statusRecorded bool) {
+	if m.aborted == nil {
+		m.aborted = make(map[types.NamespacedName][]bool)
+	}
+	m.aborted[pod] = append(m.aborted[pod], statusRecorded)
+}
+
+func (m *mockOutlierDetector) IsEjected(pod types.NamespacedName) bool {
+	return m.ejected[pod]
+}
+
+func TestGetCandidatePodsForSchedulingSkipsEjectedPods(t *testing.T) {
+	pmf := backendmetrics.NewPodMetricsFactory(&backendmetrics.FakePodMetricsClient{}, time.Second)
+	ds := datastore.NewDatastore(t.Context(), pmf)
+	ds.PodUpdateOrAddIfNotExist(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1"}, Status: corev1.PodStatus{PodIP: "10.0.0.1"}})
+	ds.PodUpdateOrAddIfNotExist(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod2"}, Status: corev1.PodStatus{PodIP: "10.0.0.2"}})
+
+	detector := &mockOutlierDetector{ejected: map[types.NamespacedName]bool{{Name: "pod1"}: true}}
+	director := NewDirectorWithConfig(ds, &mockScheduler{}, &mockSaturationDetector{}, NewConfig().WithOutlierDetector(detector))
+
+	for name, md := range map[string]map[string]any{
+		"no subset filter": {},
+		"subset filter": {
+			metadata.SubsetFilterNamespace: map[string]any{metadata.SubsetFilterKey: []any{"10.0.0.1", "10.0.0.2"}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got := director.getCandidatePodsForScheduling(context.Background(), md)
+			if len(got) != 1 {
+				t.Fatalf("expected a single candidate pod, got %d", len(got))
+			}
+			if diff := cmp.Diff("pod2", got[0].GetPod().NamespacedName.Name); diff != "" {
+				t.Errorf("Unexpected candidate pod (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestDirector_HandleResponseRecordsStatusCode(t *testing.T) {
+	detector := &mockOutlierDetector{}
+	director := NewDirectorWithConfig(nil, &mockScheduler{}, nil, NewConfig().WithOutlierDetector(detector))
+	pod := types.NamespacedName{Namespace: "namespace1", Name: "test-pod-name"}
+
+	for _, headers := range []map[string]string{
+		{":status": "503"},
+		{"status": "200"},
+		{"status": "invalid"},
+		{},
+	} {
+		reqCtx := &handlers.RequestContext{
+			Request:   &handlers.Request{Headers: map[string]string{}},
+			Response:  &handlers.Response{Headers: headers},
+			TargetPod: &backend.Pod{NamespacedName: pod},
+		}
+		if _, err := director.HandleResponse(context.Background(), reqCtx); err != nil {
+			t.Fatalf("HandleResponse() returned unexpected error: %v", err)
+		}
+	}
+
+	if diff := cmp.Diff([]int{503, 200}, detector.responses[pod]); diff != "" {
+		t.Errorf("Unexpected recorded status codes (-want +got): %v", diff)
+	}
+}
+
+func TestDirector_HandleStreamEndRecordsAbortedResponses(t *testing.T) {
+	detector := &mockOutlierDetector{}
+	director := NewDirectorWithConfig(nil, &mockScheduler{}, nil, NewConfig().WithOutlierDetector(detector))
+	pod := types.NamespacedName{Namespace: "namespace1", Name: "test-pod-name"}
+
+	for _, test := range []struct {
+		headers map[string]string
+		aborted bool
+	}{
+		{headers: map[string]string{":status": "200"}, aborted: false},
+		{headers: map[string]string{}, aborted: true},
+		{headers: map[string]string{":status": "200"}, aborted: true},
+		{headers: map[string]string{":status": "503"}, aborted: true}, // already recorded as an error
+	} {
+		reqCtx := &handlers.RequestContext{
+			Request:   &handlers.Request{Headers: map[string]string{}},
+			Response:  &handlers.Response{Headers: test.headers},
+			TargetPod: &backend.Pod{NamespacedName: pod},
+		}
+		director.HandleStreamEnd(context.Background(), reqCtx, test.aborted)
+	}
+
+	if diff := cmp.Diff([]bool{false, true}, detector.aborted[pod]); diff != "" {
+		t.Errorf("Unexpected recorded aborted responses (-want +got): %v", diff)
+	}
+}
+
+type testAttribute struct {
+	value string
+}
+
+func (a *testAttribute) Clone() datalayer.Cloneable {
+	return &testAttribute{value: a.value}
+}
+
+func TestToSchedulerPodMetricsCopiesAttributes(t *testing.T) {
+	pod := &backendmetrics.FakePodMetrics{
+		Pod:     &backend.Pod{NamespacedName: types.NamespacedName{Name: "pod1"}},
+		Metrics: backendmetrics.NewMetricsState(),
+	}
+	pod.Put("custom", &testAttribute{value: "v1"})
+
+	director := NewDirectorWithConfig(nil, &mockScheduler{}, &mockSaturationDetector{}, NewConfig())
+	got := director.toSchedulerPodMetrics([]backendmetrics.PodMetrics{pod})
+	if len(got) != 1 {
+		t.Fatalf("expected a single pod, got %d", len(got))
+	}
+
+	// Updates to the endpoint after the snapshot was taken must not be visible to the scheduler.
+	pod.Put("custom", &testAttribute{value: "v2"})
+
+	value, ok := got[0].Get("custom")
+	if !ok {
+		t.Fatal("expected custom attribute to be copied to the scheduler pod")
+	}
+	if diff := cmp.Diff("v1", value.(*testAttribute).value); diff != "" {
+		t.Errorf("Unexpected attribute value (-want +got): %v", diff)
+	}
+	if _, ok := got[0].Get("missing"); ok {
+		t.Error("expected missing attribute to not be found")
+	}
+}
+
 func TestGetRandomPod(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -680,3 +936,543 @@ func (p *testPostResponse) PostResponse(_ context.Context, _ *schedulingtypes.LL
 	p.lastRespOnResponse = response
 	p.lastTargetPodOnResponse = targetPod.NamespacedName.String()
 }
+
+func TestDirector_RunRequestMutators(t *testing.T) {
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+
+	systemPrompt := newTestRequestMutator("system-prompt", func(mutation *RequestMutation, _ *backend.Pod) error {
+		messages := mutation.Body["messages"].([]any)
+		mutation.Body["messages"] = append([]any{map[string]any{"role": "system", "content": "be concise"}}, messages...)
+		return nil
+	})
+	clampMaxTokens := newTestRequestMutator("clamp-max-tokens", func(mutation *RequestMutation, targetPod *backend.Pod) error {
+		if targetPod.Labels["max-tokens"] == "256" && mutation.Body["max_tokens"].(float64) > 256 {
+			mutation.Body["max_tokens"] = float64(256)
+		}
+		mutation.SetHeader("X-Served-By", targetPod.NamespacedName.Name)
+		mutation.SetHeader("Content-Length", "1")
+		mutation.RemoveHeader("X-Api-Key")
+		return nil
+	})
+	director := NewDirectorWithConfig(nil, nil, nil, NewConfig().WithRequestMutators(systemPrompt, clampMaxTokens))
+
+	reqCtx := &handlers.RequestContext{
+		Request: &handlers.Request{
+			Body: map[string]any{
+				"model":      "food-review",
+				"messages":   []any{map[string]any{"role": "user", "content": "review"}},
+				"max_tokens": float64(1024),
+			},
+			Headers: map[string]string{
+				"x-api-key":      "secret",
+				"content-length": "42",
+			},
+		},
+		SchedulingRequest: &schedulingtypes.LLMRequest{TargetModel: "food-review"},
+		TargetPod: &backend.Pod{
+			NamespacedName: types.NamespacedName{Namespace: "default", Name: "pod1"},
+			Labels:         map[string]string{"max-tokens": "256"},
+		},
+	}
+	if err := director.runRequestMutators(ctx, reqCtx); err != nil {
+		t.Fatalf("runRequestMutators() returned unexpected error: %v", err)
+	}
+
+	wantBody := map[string]any{
+		"model": "food-review",
+		"messages": []any{
+			map[string]any{"role": "system", "content": "be concise"},
+			map[string]any{"role": "user", "content": "review"},
+		},
+		"max_tokens": float64(256),
+	}
+	if diff := cmp.Diff(wantBody, reqCtx.Request.Body); diff != "" {
+		t.Errorf("Request body mismatch (-want +got):\n%s", diff)
+	}
+	wantHeaders := map[string]string{"x-served-by": "pod1", "content-length": "42"}
+	if diff := cmp.Diff(wantHeaders, reqCtx.Request.Headers); diff != "" {
+		t.Errorf("Request headers mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"x-api-key"}, reqCtx.Request.RemovedHeaders); diff != "" {
+		t.Errorf("Removed headers mismatch (-want +got):\n%s", diff)
+	}
+
+	// Errors fail the request, with an internal error unless the plugin returns an errutil.Error.
+	failing := newTestRequestMutator("failing", func(_ *RequestMutation, _ *backend.Pod) error {
+		return errors.New("boom")
+	})
+	rejecting := newTestRequestMutator("rejecting", func(_ *RequestMutation, _ *backend.Pod) error {
+		return errutil.Error{Code: errutil.BadRequest, Msg: "max_tokens too large"}
+	})
+	for mutator, wantCode := range map[*testRequestMutator]string{failing: errutil.Internal, rejecting: errutil.BadRequest} {
+		director := NewDirectorWithConfig(nil, nil, nil, NewConfig().WithRequestMutators(mutator))
+		err := director.runRequestMutators(ctx, reqCtx)
+		var e errutil.Error
+		if assert.ErrorAs(t, err, &e, "Error should be of type errutil.Error") {
+			assert.Equal(t, wantCode, e.Code, "Error code mismatch")
+		}
+	}
+}
+
+func TestDirector_RunRequestMutatorsSkipsHeadersOnlyRequests(t *testing.T) {
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+	called := false
+	mutator := newTestRequestMutator("system-prompt", func(mutation *RequestMutation, _ *backend.Pod) error {
+		called = true
+		mutation.Body["messages"] = []any{}
+		return nil
+	})
+	director := NewDirectorWithConfig(nil, nil, nil, NewConfig().WithRequestMutators(mutator))
+	reqCtx := &handlers.RequestContext{
+		Request: &handlers.Request{
+			Headers:     map[string]string{},
+			Body:        map[string]any{"model": "food-review"},
+			HeadersOnly: true,
+		},
+	}
+
+	require.NoError(t, director.runRequestMutators(ctx, reqCtx))
+	assert.False(t, called, "request mutators must not run without the request body")
+	assert.Equal(t, map[string]any{"model": "food-review"}, reqCtx.Request.Body)
+}
+
+const (
+	testRequestMutatorType = "test-request-mutator"
+)
+
+type testRequestMutator struct {
+	tn     plugins.TypedName
+	mutate func(mutation *RequestMutation, targetPod *backend.Pod) error
+}
+
+func newTestRequestMutator(name string, mutate func(mutation *RequestMutation, targetPod *backend.Pod) error) *testRequestMutator {
+	return &testRequestMutator{
+		tn:     plugins.TypedName{Type: testRequestMutatorType, Name: name},
+		mutate: mutate,
+	}
+}
+
+func (p *testRequestMutator) TypedName() plugins.TypedName {
+	return p.tn
+}
+
+func (p *testRequestMutator) MutateRequest(_ context.Context, _ *schedulingtypes.LLMRequest, mutation *RequestMutation, targetPod *backend.Pod) error {
+	return p.mutate(mutation, targetPod)
+}
+
+func TestDirector_ResponseMutators(t *testing.T) {
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+
+	headerMutator := &testResponseMutator{
+		tn: plugins.TypedName{Type: testResponseMutatorType, Name: "headers"},
+		mutateHeaders: func(response *ResponseMutation, targetPod *backend.Pod) {
+			response.SetHeader("X-Served-By", targetPod.NamespacedName.Name)
+			response.SetHeader("X-Queue-Time", response.QueueTime.String())
+			response.RemoveHeader("Server")
+		},
+	}
+	bodyMutator := &testResponseMutator{
+		tn: plugins.TypedName{Type: testResponseMutatorType, Name: "body"},
+		mutateBody: func(chunk *ResponseBodyChunk) {
+			if !chunk.IsStreaming && chunk.EndOfStream {
+				chunk.Body = []byte(strings.ToUpper(string(chunk.Body)))
+			}
+		},
+	}
+	director := NewDirectorWithConfig(nil, nil, nil, NewConfig().WithResponseHeaderMutators(headerMutator).WithResponseBodyMutators(bodyMutator))
+
+	reqCtx := &handlers.RequestContext{
+		Request:           &handlers.Request{Headers: map[string]string{}},
+		SchedulingRequest: &schedulingtypes.LLMRequest{TargetModel: "food-review"},
+		TargetPod:         &backend.Pod{NamespacedName: types.NamespacedName{Namespace: "default", Name: "pod1"}},
+		QueueTime:         2 * time.Second,
+		Response: &handlers.Response{
+			Headers: map[string]string{
+				"content-type":   "application/json",
+				"content-length": "11",
+				"server":         "uvicorn",
+			},
+		},
+	}
+	if _, err := director.HandleResponse(ctx, reqCtx); err != nil {
+		t.Fatalf("HandleResponse() returned unexpected error: %v", err)
+	}
+
+	wantHeaders := map[string]string{"content-type": "application/json", "x-served-by": "pod1", "x-queue-time": "2s"}
+	if diff := cmp.Diff(wantHeaders, reqCtx.Response.Headers); diff != "" {
+		t.Errorf("Response headers mismatch (-want +got):\n%s", diff)
+	}
+	// The content length is removed since the body may change.
+	if diff := cmp.Diff([]string{"server", "content-length"}, reqCtx.Response.RemovedHeaders); diff != "" {
+		t.Errorf("Removed headers mismatch (-want +got):\n%s", diff)
+	}
+
+	assert.Equal(t, `{"a": "b"}`, string(director.HandleResponseBodyChunk(ctx, reqCtx, []byte(`{"a": "b"}`), false)))
+	assert.Equal(t, `{"A": "B"}`, string(director.HandleResponseBodyChunk(ctx, reqCtx, []byte(`{"a": "b"}`), true)))
+
+	reqCtx.Response.Headers["content-type"] = "text/event-stream"
+	assert.Equal(t, `data: {"a": "b"}`, string(director.HandleResponseBodyChunk(ctx, reqCtx, []byte(`data: {"a": "b"}`), true)))
+}
+
+func TestDirector_TrackLatency(t *testing.T) {
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+	tracker := &testLatencyTracker{}
+	director := NewDirectorWithConfig(nil, nil, nil, NewConfig().WithObjectiveTracker(tracker))
+	latency := &schedulingtypes.LatencyObjective{TTFT: time.Second, TPOT: 10 * time.Millisecond, Percentile: 90}
+
+	tests := []struct {
+		name        string
+		latency     *schedulingtypes.LatencyObjective
+		headers     map[string]string
+		usage       handlers.Usage
+		wantRecords int
+		wantTPOT    bool
+	}{
+		{
+			name:        "streamed response",
+			latency:     latency,
+			headers:     map[string]string{":status": "200", "content-type": "text/event-stream"},
+			usage:       handlers.Usage{CompletionTokens: 10},
+			wantRecords: 1,
+			wantTPOT:    true,
+		},
+		{
+			name:        "non-streamed response",
+			latency:     latency,
+			headers:     map[string]string{":status": "200", "content-type": "application/json"},
+			usage:       handlers.Usage{CompletionTokens: 10},
+			wantRecords: 1,
+		},
+		{
+			name:    "error response",
+			latency: latency,
+			headers: map[string]string{":status": "503"},
+		},
+		{
+			name:    "no latency targets",
+			headers: map[string]string{":status": "200"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tracker.records = nil
+			reqCtx := &handlers.RequestContext{
+				ObjectiveKey:             "chat",
+				RequestReceivedTimestamp: time.Now().Add(-time.Second),
+				Request:                  &handlers.Request{Headers: map[string]string{}},
+				SchedulingRequest:        &schedulingtypes.LLMRequest{Latency: test.latency},
+				Response:                 &handlers.Response{Headers: test.headers},
+				Usage:                    test.usage,
+			}
+			director.HandleResponseBodyChunk(ctx, reqCtx, []byte("a"), false)
+			director.HandleResponseBodyChunk(ctx, reqCtx, []byte("b"), true)
+
+			if len(tracker.records) != test.wantRecords {
+				t.Fatalf("Expected %d recorded requests, got %d", test.wantRecords, len(tracker.records))
+			}
+			if test.wantRecords == 0 {
+				return
+			}
+			record := tracker.records[0]
+			assert.Equal(t, "chat", record.objective)
+			assert.GreaterOrEqual(t, record.ttft, time.Second)
+			assert.Equal(t, test.wantTPOT, record.tpot > 0)
+		})
+	}
+}
+
+func TestDirector_RateLimit(t *testing.T) {
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+	ds := newRateLimitTestDatastore(t, true)
+	ds.ObjectiveSet(testutil.MakeInferenceObjective("unlimited").Namespace("ns").ObjRef())
+
+	limiter := &testRateLimiter{backend: ratelimit.NewLimiter(ratelimit.NewMemoryBackend())}
+	director := NewDirectorWithConfig(ds, &mockScheduler{scheduleResults: rateLimitTestScheduleResult()}, &mockSaturationDetector{}, NewConfig().WithRateLimiter(limiter))
+
+	reqCtx, err := handleRateLimitTestRequest(ctx, director, "limited", "tenant-a")
+	require.NoError(t, err)
+	assert.NotNil(t, reqCtx.RateLimitReservation)
+	assert.Equal(t, []string{"ns/limited/tenant-a"}, limiter.admitted)
+	assert.Equal(t, []int64{3}, limiter.estimates)
+
+	_, err = handleRateLimitTestRequest(ctx, director, "limited", "tenant-a")
+	assert.Equal(t, errutil.RateLimited, errorCode(err))
+	abortedCtx, err := handleRateLimitTestRequest(ctx, director, "limited", "tenant-b")
+	require.NoError(t, err)
+	_, err = handleRateLimitTestRequest(ctx, director, "unlimited", "tenant-a")
+	require.NoError(t, err)
+	assert.Len(t, limiter.admitted, 3)
+	assert.Empty(t, limiter.released)
+
+	// The tokens are charged once the response is complete.
+	reqCtx.Usage = handlers.Usage{PromptTokens: 4, CompletionTokens: 20}
+	director.HandleResponseBodyChunk(ctx, reqCtx, []byte("a"), false)
+	assert.Empty(t, limiter.completed)
+	director.HandleResponseBodyChunk(ctx, reqCtx, []byte("b"), true)
+	assert.Equal(t, [][2]int64{{4, 20}}, limiter.completed)
+	assert.Nil(t, reqCtx.RateLimitReservation)
+	director.HandleStreamEnd(ctx, reqCtx, false)
+	assert.Len(t, limiter.completed, 1, "the tokens of a complete response are charged once")
+
+	// The tokens of an aborted response are charged with the usage seen so far.
+	abortedCtx.Usage = handlers.Usage{CompletionTokens: 5}
+	director.HandleResponseBodyChunk(ctx, abortedCtx, []byte("a"), false)
+	director.HandleStreamEnd(ctx, abortedCtx, true)
+	assert.Equal(t, [][2]int64{{4, 20}, {0, 5}}, limiter.completed)
+	assert.Nil(t, abortedCtx.RateLimitReservation)
+}
+
+func TestDirector_RateLimitReleasesUnservedRequests(t *testing.T) {
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+
+	for _, test := range []struct {
+		name       string
+		withPod    bool
+		saturated  bool
+		scheduler  *mockScheduler
+		wantCode   string
+		wantAdmits int
+	}{
+		{
+			name:      "saturated",
+			withPod:   true,
+			saturated: true,
+			scheduler: &mockScheduler{scheduleResults: rateLimitTestScheduleResult()},
+			wantCode:  errutil.InferencePoolResourceExhausted,
+		},
+		{
+			name:       "no candidate pods",
+			scheduler:  &mockScheduler{scheduleResults: rateLimitTestScheduleResult()},
+			wantCode:   errutil.ServiceUnavailable,
+			wantAdmits: 2,
+		},
+		{
+			name:       "scheduling failure",
+			withPod:    true,
+			scheduler:  &mockScheduler{scheduleErr: errors.New("no pod fits")},
+			wantCode:   errutil.InferencePoolResourceExhausted,
+			wantAdmits: 2,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			limiter := &testRateLimiter{backend: ratelimit.NewLimiter(ratelimit.NewMemoryBackend())}
+			director := NewDirectorWithConfig(newRateLimitTestDatastore(t, test.withPod), test.scheduler,
+				&mockSaturationDetector{isSaturated: test.saturated}, NewConfig().WithRateLimiter(limiter))
+
+			// The limit admits a single request per minute, the second request isn't rate limited
+			// as the first one was refunded.
+			for range 2 {
+				reqCtx, err := handleRateLimitTestRequest(ctx, director, "limited", "tenant-a")
+				assert.Equal(t, test.wantCode, errorCode(err))
+				assert.Nil(t, reqCtx.RateLimitReservation)
+			}
+			assert.Len(t, limiter.admitted, test.wantAdmits)
+			assert.Len(t, limiter.released, test.wantAdmits)
+		})
+	}
+}
+
+// newRateLimitTestDatastore returns a datastore with a pool, an objective limited to one request
+// and 1000 tokens per minute per fairness ID, and optionally a pod.
+func newRateLimitTestDatastore(t *testing.T, withPod bool) datastore.Datastore {
+	pmf := backendmetrics.NewPodMetricsFactory(&backendmetrics.FakePodMetricsClient{}, time.Second)
+	ds := datastore.NewDatastore(t.Context(), pmf)
+	pool := &v1.InferencePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pool", Namespace: "ns"},
+		Spec: v1.InferencePoolSpec{
+			TargetPortNumber: int32(8000),
+			Selector:         map[v1.LabelKey]v1.LabelValue{"app": "inference"},
+		},
+	}
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	if err := ds.PoolSet(t.Context(), fake.NewClientBuilder().WithScheme(scheme).Build(), pool); err != nil {
+		t.Fatalf("Error while setting inference pool: %v", err)
+	}
+	if withPod {
+		ds.PodUpdateOrAddIfNotExist(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "ns", Labels: map[string]string{"app": "inference"}},
+			Status:     corev1.PodStatus{PodIP: "10.0.0.1"},
+		})
+	}
+	ds.ObjectiveSet(testutil.MakeInferenceObjective("limited").Namespace("ns").RateLimit(1, 1000, v1alpha2.RateLimitKeyFairnessID).ObjRef())
+	return ds
+}
+
+func rateLimitTestScheduleResult() *schedulingtypes.SchedulingResult {
+	return &schedulingtypes.SchedulingResult{
+		ProfileResults: map[string]*schedulingtypes.ProfileRunResult{
+			"testProfile": {
+				TargetPods: []schedulingtypes.Pod{
+					&schedulingtypes.ScoredPod{
+						Pod: &schedulingtypes.PodMetrics{
+							Pod: &backend.Pod{Address: "10.0.0.1", NamespacedName: types.NamespacedName{Name: "pod1", Namespace: "ns"}},
+						},
+					},
+				},
+			},
+		},
+		PrimaryProfileName: "testProfile",
+	}
+}
+
+func handleRateLimitTestRequest(ctx context.Context, director *Director, objective, fairnessID string) (*handlers.RequestContext, error) {
+	reqCtx := &handlers.RequestContext{
+		ObjectiveKey: objective,
+		FairnessID:   fairnessID,
+		Request: &handlers.Request{
+			Headers: map[string]string{},
+			Body:    map[string]any{"model": "m", "prompt": "hello, world"},
+		},
+		Response: &handlers.Response{Headers: map[string]string{}},
+	}
+	return director.HandleRequest(ctx, reqCtx)
+}
+
+func errorCode(err error) string {
+	var e errutil.Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return ""
+}
+
+type testRateLimiter struct {
+	backend   *ratelimit.Limiter
+	admitted  []string
+	estimates []int64
+	completed [][2]int64
+	released  []*ratelimit.Reservation
+}
+
+func (l *testRateLimiter) Admit(ctx context.Context, key string, limit ratelimit.Limit, estimatedPromptTokens int64) (*ratelimit.Reservation, error) {
+	l.admitted = append(l.admitted, key)
+	l.estimates = append(l.estimates, estimatedPromptTokens)
+	return l.backend.Admit(ctx, key, limit, estimatedPromptTokens)
+}
+
+func (l *testRateLimiter) Complete(ctx context.Context, reservation *ratelimit.Reservation, promptTokens, completionTokens int64) {
+	l.completed = append(l.completed, [2]int64{promptTokens, completionTokens})
+	l.backend.Complete(ctx, reservation, promptTokens, completionTokens)
+}
+
+func (l *testRateLimiter) Release(ctx context.Context, reservation *ratelimit.Reservation) {
+	l.released = append(l.released, reservation)
+	l.backend.Release(ctx, reservation)
+}
+
+type testLatencyRecord struct {
+	objective  string
+	ttft, tpot time.Duration
+}
+
+type testLatencyTracker struct {
+	records []testLatencyRecord
+}
+
+func (t *testLatencyTracker) RecordRequest(string) {}
+
+func (t *testLatencyTracker) RecordLatency(objective string, ttft, tpot time.Duration) {
+	t.records = append(t.records, testLatencyRecord{objective: objective, ttft: ttft, tpot: tpot})
+}
+
+const (
+	testResponseMutatorType = "test-response-mutator"
+)
+
+type testResponseMutator struct {
+	tn            plugins.TypedName
+	mutateHeaders func(response *ResponseMutation, targetPod *backend.Pod)
+	mutateBody    func(chunk *ResponseBodyChunk)
+}
+
+func (p *testResponseMutator) TypedName() plugins.TypedName {
+	return p.tn
+}
+
+func (p *testResponseMutator) MutateResponseHeaders(_ context.Context, _ *schedulingtypes.LLMRequest, response *ResponseMutation, targetPod *backend.Pod) {
+	p.mutateHeaders(response, targetPod)
+}
+
+func (p *testResponseMutator) MutateResponseBody(_ context.Context, _ *schedulingtypes.LLMRequest, chunk *ResponseBodyChunk, _ *backend.Pod) {
+	p.mutateBody(chunk)
+}
+
+func TestDirector_SaturationBypassCriticality(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *Config
+		criticality int
+		wantErr     bool
+	}{
+		{
+			name:        "default bypass criticality",
+			config:      NewConfig(),
+			criticality: 2,
+		},
+		{
+			name:        "below the default bypass criticality",
+			config:      NewConfig(),
+			criticality: 1,
+			wantErr:     true,
+		},
+		{
+			name:        "configured bypass criticality",
+			config:      NewConfig().WithSaturationBypassCriticality(1),
+			criticality: 1,
+		},
+		{
+			name:        "below the configured bypass criticality",
+			config:      NewConfig().WithSaturationBypassCriticality(1),
+			criticality: 0,
+			wantErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			director := NewDirectorWithConfig(nil, &mockScheduler{}, &mockSaturationDetector{isSaturated: true}, test.config)
+			err := director.admitRequest(context.Background(), test.criticality, "")
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDirector_HandleRequestTracing(t *testing.T) {
+	recorder, restore := testutil.NewTestSpanRecorder()
+	defer restore()
+
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+	pmf := backendmetrics.NewPodMetricsFactory(&backendmetrics.FakePodMetricsClient{}, time.Second)
+	ds := datastore.NewDatastore(t.Context(), pmf)
+	ds.ObjectiveSet(testutil.MakeInferenceObjective("sheddable").Namespace("ns").ObjRef())
+	// The saturated pool drops the request at admission.
+	director := NewDirectorWithConfig(ds, &mockScheduler{}, &mockSaturationDetector{isSaturated: true}, NewConfig())
+
+	_, err := director.HandleRequest(ctx, &handlers.RequestContext{
+		ObjectiveKey: "sheddable",
+		Request: &handlers.Request{
+			Headers: map[string]string{},
+			Body:    map[string]any{"model": "m", "prompt": "hello"},
+		},
+		Response: &handlers.Response{Headers: map[string]string{}},
+	})
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	admit, request := spans[0], spans[1]
+	assert.Equal(t, "Director.admit", admit.Name())
+	assert.Equal(t, request.SpanContext().SpanID(), admit.Parent().SpanID())
+	assert.Contains(t, admit.Attributes(), tracing.CriticalityKey.Int(0))
+	assert.Equal(t, codes.Error, admit.Status().Code)
+	assert.Equal(t, "Director.HandleRequest", request.Name())
+	assert.Contains(t, request.Attributes(), tracing.ObjectiveKey.String("sheddable"))
+	assert.Contains(t, request.Attributes(), tracing.IncomingModelKey.String("m"))
+	assert.Equal(t, codes.Error, request.Status().Code)
+}
@@ -25,10 +25,22 @@ import (
 )
 
 const (
-	PreRequestExtensionPoint   = "PreRequest"
-	PostResponseExtensionPoint = "PostResponse"
+	RequestMutatorExtensionPoint        = "RequestMutator"
+	PreRequestExtensionPoint            = "PreRequest"
+	PostResponseExtensionPoint          = "PostResponse"
+	ResponseHeaderMutatorExtensionPoint = "ResponseHeaderMutator"
+	ResponseBodyMutatorExtensionPoint   = "ResponseBodyMutator"
 )
 
+// RequestMutator is called by the director after a getting result from scheduling layer and
+// before the PreRequest plugins. It can rewrite the body and headers of the request sent to the
+// selected model server. The given pod argument is the pod selected by the primary profile.
+// Returning an error fails the request.
+type RequestMutator interface {
+	plugins.Plugin
+	MutateRequest(ctx context.Context, request *types.LLMRequest, mutation *RequestMutation, targetPod *backend.Pod) error
+}
+
 // PreRequest is called by the director after a getting result from scheduling layer and
 // before a request is sent to the selected model server.
 type PreRequest interface {
@@ -42,3 +54,19 @@ type PostResponse interface {
 	plugins.Plugin
 	PostResponse(ctx context.Context, request *types.LLMRequest, response *Response, targetPod *backend.Pod)
 }
+
+// ResponseHeaderMutator is called by the director when the response headers are received from the
+// model server, after the PostResponse plugins. It can set and remove the response headers sent to
+// the client. The given pod argument is the pod that served the request.
+type ResponseHeaderMutator interface {
+	plugins.Plugin
+	MutateResponseHeaders(ctx context.Context, request *types.LLMRequest, response *ResponseMutation, targetPod *backend.Pod)
+}
+
+// ResponseBodyMutator is called by the director for each chunk of the response body received from
+// the model server. It can replace the chunk sent to the client. When response body mutators are
+// configured, the Content-Length header is removed from the response.
+type ResponseBodyMutator interface {
+	plugins.Plugin
+	MutateResponseBody(ctx context.Context, request *types.LLMRequest, chunk *ResponseBodyChunk, targetPod *backend.Pod)
+}
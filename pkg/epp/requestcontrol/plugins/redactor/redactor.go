@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package redactor implements a response body mutator, which removes fields from non-streaming
+// JSON responses before they reach the client.
+package redactor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	ResponseRedactorType = "response-redactor"
+)
+
+// compile-time type assertion
+var _ requestcontrol.ResponseBodyMutator = &ResponseRedactor{}
+
+// ResponseRedactorParameters are the parameters of the ResponseRedactor.
+type ResponseRedactorParameters struct {
+	// Fields are the dot separated paths of the fields to remove, e.g. "choices.logprobs". A path
+	// traversing an array applies to all of its elements.
+	Fields []string `json:"fields"`
+}
+
+// ResponseRedactorFactory defines the factory function for ResponseRedactor.
+func ResponseRedactorFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	parameters := ResponseRedactorParameters{}
+	if rawParameters != nil {
+		if err := json.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the %s plugin. Error: %s", ResponseRedactorType, err)
+		}
+	}
+	redactor, err := New(parameters.Fields)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters of the %s plugin - %w", ResponseRedactorType, err)
+	}
+	return redactor.WithName(name), nil
+}
+
+// New initializes a new ResponseRedactor removing the given fields and returns its pointer.
+func New(fields []string) (*ResponseRedactor, error) {
+	if len(fields) == 0 {
+		return nil, errors.New("at least one field must be set")
+	}
+	paths := make([][]string, 0, len(fields))
+	for _, field := range fields {
+		path := strings.Split(field, ".")
+		for _, key := range path {
+			if key == "" {
+				return nil, fmt.Errorf("invalid field %q", field)
+			}
+		}
+		paths = append(paths, path)
+	}
+	return &ResponseRedactor{
+		typedName: plugins.TypedName{Type: ResponseRedactorType, Name: ResponseRedactorType},
+		paths:     paths,
+	}, nil
+}
+
+// ResponseRedactor removes the configured fields from non-streaming JSON responses. Streaming
+// responses and bodies which are not JSON objects are left untouched.
+type ResponseRedactor struct {
+	typedName plugins.TypedName
+	paths     [][]string
+}
+
+// TypedName returns the type and name tuple of this plugin instance.
+func (r *ResponseRedactor) TypedName() plugins.TypedName {
+	return r.typedName
+}
+
+// WithName sets the name of the plugin.
+func (r *ResponseRedactor) WithName(name string) *ResponseRedactor {
+	r.typedName.Name = name
+	return r
+}
+
+// MutateResponseBody removes the configured fields from the response body.
+func (r *ResponseRedactor) MutateResponseBody(ctx context.Context, _ *types.LLMRequest, chunk *requestcontrol.ResponseBodyChunk, _ *backend.Pod) {
+	if chunk.IsStreaming || !chunk.EndOfStream {
+		return
+	}
+	body := map[string]any{}
+	if err := json.Unmarshal(chunk.Body, &body); err != nil {
+		return
+	}
+	redacted := false
+	for _, path := range r.paths {
+		redacted = remove(body, path) || redacted
+	}
+	if !redacted {
+		return
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		log.FromContext(ctx).V(logutil.DEFAULT).Error(err, "Failed to encode the redacted response body")
+		return
+	}
+	chunk.Body = data
+}
+
+// remove removes the field at path from value, and returns whether or not it was found.
+func remove(value any, path []string) bool {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(path) == 1 {
+			_, ok := v[path[0]]
+			delete(v, path[0])
+			return ok
+		}
+		return remove(v[path[0]], path[1:])
+	case []any:
+		removed := false
+		for _, element := range v {
+			removed = remove(element, path) || removed
+		}
+		return removed
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redactor
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+)
+
+func TestMutateResponseBody(t *testing.T) {
+	redactor, err := New([]string{"usage", "choices.logprobs", "choices.message.reasoning_content"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		chunk    requestcontrol.ResponseBodyChunk
+		wantBody string
+	}{
+		{
+			name: "redact fields",
+			chunk: requestcontrol.ResponseBodyChunk{
+				Body:        []byte(`{"id":"1","usage":{"total_tokens":3},"choices":[{"index":0,"logprobs":null,"message":{"content":"hi","reasoning_content":"hmm"}},{"index":1,"message":{"content":"ho"}}]}`),
+				EndOfStream: true,
+			},
+			wantBody: `{"id":"1","choices":[{"index":0,"message":{"content":"hi"}},{"index":1,"message":{"content":"ho"}}]}`,
+		},
+		{
+			name: "no redacted field",
+			chunk: requestcontrol.ResponseBodyChunk{
+				Body:        []byte(`{"id": "1"}`),
+				EndOfStream: true,
+			},
+			wantBody: `{"id": "1"}`,
+		},
+		{
+			name: "streaming response",
+			chunk: requestcontrol.ResponseBodyChunk{
+				Body:        []byte(`data: {"id":"1","usage":{"total_tokens":3}}`),
+				IsStreaming: true,
+				EndOfStream: true,
+			},
+			wantBody: `data: {"id":"1","usage":{"total_tokens":3}}`,
+		},
+		{
+			name: "not json",
+			chunk: requestcontrol.ResponseBodyChunk{
+				Body:        []byte(`internal error`),
+				EndOfStream: true,
+			},
+			wantBody: `internal error`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			chunk := test.chunk
+			redactor.MutateResponseBody(context.Background(), nil, &chunk, nil)
+			if json.Valid([]byte(test.wantBody)) {
+				assert.JSONEq(t, test.wantBody, string(chunk.Body))
+			} else {
+				assert.Equal(t, test.wantBody, string(chunk.Body))
+			}
+		})
+	}
+}
+
+func TestResponseRedactorFactory(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters string
+		wantErr    bool
+	}{
+		{
+			name:       "valid",
+			parameters: `{"fields": ["usage", "choices.logprobs"]}`,
+		},
+		{
+			name:    "no fields",
+			wantErr: true,
+		},
+		{
+			name:       "empty path element",
+			parameters: `{"fields": ["choices..logprobs"]}`,
+			wantErr:    true,
+		},
+		{
+			name:       "invalid json",
+			parameters: `{"fields": "usage"}`,
+			wantErr:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var raw json.RawMessage
+			if test.parameters != "" {
+				raw = json.RawMessage(test.parameters)
+			}
+			plugin, err := ResponseRedactorFactory("redactor", raw, nil)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "redactor", plugin.TypedName().Name)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package servedby implements a response header mutator, which tells the client which model
+// server served its request and how long the request spent in the EPP.
+package servedby
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+)
+
+const (
+	ServedByType = "served-by"
+
+	// ServedByHeader is the name of the pod which served the request.
+	ServedByHeader = "x-gateway-served-by"
+	// SchedulingLatencyHeader is the time spent scheduling the request, in milliseconds.
+	SchedulingLatencyHeader = "x-gateway-scheduling-latency-ms"
+	// QueueTimeHeader is the time the request spent in the EPP before being scheduled, in milliseconds.
+	QueueTimeHeader = "x-gateway-queue-time-ms"
+)
+
+// compile-time type assertion
+var _ requestcontrol.ResponseHeaderMutator = &ServedBy{}
+
+// ServedByFactory defines the factory function for ServedBy.
+func ServedByFactory(name string, _ json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	return New().WithName(name), nil
+}
+
+// New initializes a new ServedBy and returns its pointer.
+func New() *ServedBy {
+	return &ServedBy{
+		typedName: plugins.TypedName{Type: ServedByType, Name: ServedByType},
+	}
+}
+
+// ServedBy sets response headers with the pod which served the request, the scheduling latency
+// and the queue time.
+type ServedBy struct {
+	typedName plugins.TypedName
+}
+
+// TypedName returns the type and name tuple of this plugin instance.
+func (s *ServedBy) TypedName() plugins.TypedName {
+	return s.typedName
+}
+
+// WithName sets the name of the plugin.
+func (s *ServedBy) WithName(name string) *ServedBy {
+	s.typedName.Name = name
+	return s
+}
+
+// MutateResponseHeaders sets the served-by, scheduling latency and queue time headers.
+func (s *ServedBy) MutateResponseHeaders(_ context.Context, _ *types.LLMRequest, response *requestcontrol.ResponseMutation, targetPod *backend.Pod) {
+	if targetPod != nil {
+		response.SetHeader(ServedByHeader, targetPod.NamespacedName.Name)
+	}
+	response.SetHeader(SchedulingLatencyHeader, milliseconds(response.SchedulingLatency))
+	response.SetHeader(QueueTimeHeader, milliseconds(response.QueueTime))
+}
+
+func milliseconds(d time.Duration) string {
+	return strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', 3, 64)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servedby
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+)
+
+func TestMutateResponseHeaders(t *testing.T) {
+	tests := []struct {
+		name        string
+		targetPod   *backend.Pod
+		wantHeaders map[string]string
+	}{
+		{
+			name:      "served by pod",
+			targetPod: &backend.Pod{NamespacedName: k8stypes.NamespacedName{Namespace: "default", Name: "pod1"}},
+			wantHeaders: map[string]string{
+				"content-type":          "application/json",
+				ServedByHeader:          "pod1",
+				SchedulingLatencyHeader: "1.500",
+				QueueTimeHeader:         "20.000",
+			},
+		},
+		{
+			name: "no target pod",
+			wantHeaders: map[string]string{
+				"content-type":          "application/json",
+				SchedulingLatencyHeader: "1.500",
+				QueueTimeHeader:         "20.000",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			headers := map[string]string{"content-type": "application/json"}
+			response := requestcontrol.NewResponseMutation(headers)
+			response.SchedulingLatency = 1500 * time.Microsecond
+			response.QueueTime = 20 * time.Millisecond
+
+			New().MutateResponseHeaders(context.Background(), nil, response, test.targetPod)
+
+			if diff := cmp.Diff(test.wantHeaders, headers); diff != "" {
+				t.Errorf("Unexpected headers (-want +got): %s", diff)
+			}
+		})
+	}
+}
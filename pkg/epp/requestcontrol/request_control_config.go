@@ -17,21 +17,42 @@ limitations under the License.
 package requestcontrol
 
 import (
+	"slices"
+
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/saturationdetector"
 )
 
 // NewConfig creates a new Config object and returns its pointer.
 func NewConfig() *Config {
 	return &Config{
-		preRequestPlugins:   []PreRequest{},
-		postResponsePlugins: []PostResponse{},
+		requestMutators:        []RequestMutator{},
+		preRequestPlugins:      []PreRequest{},
+		postResponsePlugins:    []PostResponse{},
+		responseHeaderMutators: []ResponseHeaderMutator{},
+		responseBodyMutators:   []ResponseBodyMutator{},
+		bypassCriticality:      saturationdetector.DefaultBypassCriticality,
 	}
 }
 
 // Config provides a configuration for the requestcontrol plugins.
 type Config struct {
-	preRequestPlugins   []PreRequest
-	postResponsePlugins []PostResponse
+	requestMutators        []RequestMutator
+	preRequestPlugins      []PreRequest
+	postResponsePlugins    []PostResponse
+	responseHeaderMutators []ResponseHeaderMutator
+	responseBodyMutators   []ResponseBodyMutator
+	outlierDetector        OutlierDetector
+	objectiveTracker       ObjectiveTracker
+	rateLimiter            RateLimiter
+	bypassCriticality      int
+}
+
+// WithRequestMutators sets the given plugins as the RequestMutator plugins.
+// If the Config has RequestMutator plugins already, this call replaces the existing plugins with the given ones.
+func (c *Config) WithRequestMutators(plugins ...RequestMutator) *Config {
+	c.requestMutators = plugins
+	return c
 }
 
 // WithPreRequestPlugins sets the given plugins as the PreRequest plugins.
@@ -48,13 +69,74 @@ func (c *Config) WithPostResponsePlugins(plugins ...PostResponse) *Config {
 	return c
 }
 
+// WithResponseHeaderMutators sets the given plugins as the ResponseHeaderMutator plugins.
+// If the Config has ResponseHeaderMutator plugins already, this call replaces the existing plugins with the given ones.
+func (c *Config) WithResponseHeaderMutators(plugins ...ResponseHeaderMutator) *Config {
+	c.responseHeaderMutators = plugins
+	return c
+}
+
+// WithResponseBodyMutators sets the given plugins as the ResponseBodyMutator plugins.
+// If the Config has ResponseBodyMutator plugins already, this call replaces the existing plugins with the given ones.
+func (c *Config) WithResponseBodyMutators(plugins ...ResponseBodyMutator) *Config {
+	c.responseBodyMutators = plugins
+	return c
+}
+
+// WithOutlierDetector sets the outlier detector used to exclude ejected pods from scheduling.
+// If no outlier detector is set, all pods are candidates for scheduling.
+func (c *Config) WithOutlierDetector(detector OutlierDetector) *Config {
+	c.outlierDetector = detector
+	return c
+}
+
+// WithObjectiveTracker sets the tracker recording the traffic of the InferenceObjectives.
+func (c *Config) WithObjectiveTracker(tracker ObjectiveTracker) *Config {
+	c.objectiveTracker = tracker
+	return c
+}
+
+// WithRateLimiter sets the rate limiter enforcing the rate limits of the InferenceObjectives.
+// If no rate limiter is set, the rate limits are not enforced.
+func (c *Config) WithRateLimiter(limiter RateLimiter) *Config {
+	c.rateLimiter = limiter
+	return c
+}
+
+// Clone returns a copy of the Config. Adding plugins to the copy doesn't change the Config.
+func (c *Config) Clone() *Config {
+	clone := *c
+	clone.requestMutators = slices.Clone(c.requestMutators)
+	clone.preRequestPlugins = slices.Clone(c.preRequestPlugins)
+	clone.postResponsePlugins = slices.Clone(c.postResponsePlugins)
+	clone.responseHeaderMutators = slices.Clone(c.responseHeaderMutators)
+	clone.responseBodyMutators = slices.Clone(c.responseBodyMutators)
+	return &clone
+}
+
+// WithSaturationBypassCriticality sets the criticality from which the requests are admitted even if
+// the pool is saturated.
+func (c *Config) WithSaturationBypassCriticality(criticality int) *Config {
+	c.bypassCriticality = criticality
+	return c
+}
+
 func (c *Config) AddPlugins(pluginObjects ...plugins.Plugin) {
 	for _, plugin := range pluginObjects {
+		if requestMutator, ok := plugin.(RequestMutator); ok {
+			c.requestMutators = append(c.requestMutators, requestMutator)
+		}
 		if preRequestPlugin, ok := plugin.(PreRequest); ok {
 			c.preRequestPlugins = append(c.preRequestPlugins, preRequestPlugin)
 		}
 		if postResponsePlugin, ok := plugin.(PostResponse); ok {
 			c.postResponsePlugins = append(c.postResponsePlugins, postResponsePlugin)
 		}
+		if responseHeaderMutator, ok := plugin.(ResponseHeaderMutator); ok {
+			c.responseHeaderMutators = append(c.responseHeaderMutators, responseHeaderMutator)
+		}
+		if responseBodyMutator, ok := plugin.(ResponseBodyMutator); ok {
+			c.responseBodyMutators = append(c.responseBodyMutators, responseBodyMutator)
+		}
 	}
 }
@@ -16,6 +16,96 @@ limitations under the License.
 
 package requestcontrol
 
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// RequestMutation is the request sent to the selected model server, as edited by the
+// RequestMutator plugins.
+type RequestMutation struct {
+	headerMutation
+	// Body is the parsed request body, which the mutators may edit. It is re-encoded once all the
+	// mutators ran, and the Content-Length header is updated accordingly.
+	Body map[string]any
+}
+
+// NewRequestMutation creates a RequestMutation editing the given body and headers in place.
+func NewRequestMutation(body map[string]any, headers map[string]string) *RequestMutation {
+	return &RequestMutation{headerMutation: headerMutation{headers: headers}, Body: body}
+}
+
+// ResponseMutation is the response sent to the client, as edited by the ResponseHeaderMutator
+// plugins.
+type ResponseMutation struct {
+	headerMutation
+	// QueueTime is the time the request spent in the EPP before being scheduled, from the
+	// reception of its headers.
+	QueueTime time.Duration
+	// SchedulingLatency is the time spent scheduling the request.
+	SchedulingLatency time.Duration
+}
+
+// NewResponseMutation creates a ResponseMutation editing the given headers in place.
+func NewResponseMutation(headers map[string]string) *ResponseMutation {
+	return &ResponseMutation{headerMutation: headerMutation{headers: headers}}
+}
+
+// ResponseBodyChunk is a chunk of the response body sent to the client, as edited by the
+// ResponseBodyMutator plugins.
+type ResponseBodyChunk struct {
+	// Body is the chunk, which the mutators may replace. Non-streaming responses are passed in a
+	// single chunk.
+	Body []byte
+	// IsStreaming indicates whether or not the response is being streamed by the model
+	IsStreaming bool
+	// EndOfStream when true indicates that this invocation contains the last chunk of the response
+	EndOfStream bool
+}
+
+// headerMutation edits headers in place and records the removed ones. Header names are lower
+// case, as received from Envoy. The Content-Length header is managed by the EPP.
+type headerMutation struct {
+	headers        map[string]string
+	removedHeaders []string
+}
+
+// Header returns the value of a header. Header names are case insensitive.
+func (m *headerMutation) Header(key string) (string, bool) {
+	value, ok := m.headers[strings.ToLower(key)]
+	return value, ok
+}
+
+// SetHeader adds or replaces a header. The Content-Length header can't be set.
+func (m *headerMutation) SetHeader(key, value string) {
+	key = strings.ToLower(key)
+	if key == contentLengthHeader {
+		return
+	}
+	m.headers[key] = value
+	m.removedHeaders = slices.DeleteFunc(m.removedHeaders, func(removed string) bool { return removed == key })
+}
+
+// RemoveHeader removes a header, so that it doesn't reach its destination.
+func (m *headerMutation) RemoveHeader(key string) {
+	key = strings.ToLower(key)
+	if key == contentLengthHeader {
+		return
+	}
+	delete(m.headers, key)
+	if !slices.Contains(m.removedHeaders, key) {
+		m.removedHeaders = append(m.removedHeaders, key)
+	}
+}
+
+// RemovedHeaders returns the headers removed by the mutators.
+func (m *headerMutation) RemovedHeaders() []string {
+	return m.removedHeaders
+}
+
+const contentLengthHeader = "content-length"
+
 // Response contains information from the response received to be passed to PostResponse plugins
 type Response struct {
 	// RequestId is the Envoy generated Id for the request being processed
@@ -35,6 +35,9 @@ const (
 	// Given the pod metrics refresh interval is 50ms, a threshold slightly above
 	// that should be fine.
 	DefaultMetricsStalenessThreshold = 200 * time.Millisecond
+	// DefaultBypassCriticality is the default criticality from which the
+	// requests bypass the saturation check.
+	DefaultBypassCriticality = 2
 )
 
 // Environment variable names for SaturationDetector configuration
@@ -45,6 +48,8 @@ const (
 )
 
 // LoadConfigFromEnv loads SaturationDetector Config from environment variables.
+// It's used when the EndpointPickerConfig doesn't have a saturationDetector
+// section, which takes precedence over the environment variables.
 func LoadConfigFromEnv() *Config {
 	// Use a default logger for initial configuration loading.
 	logger := log.Log.WithName("saturation-detector-config")
@@ -66,6 +71,8 @@ func LoadConfigFromEnv() *Config {
 		cfg.MetricsStalenessThreshold = DefaultMetricsStalenessThreshold
 	}
 
+	cfg.BypassCriticality = DefaultBypassCriticality
+
 	// NewDetector validates the config and assigns defaults.
 	logger.Info("SaturationDetector configuration loaded from env", "config", fmt.Sprintf("%+v", cfg))
 	return cfg
@@ -59,6 +59,9 @@ type Config struct {
 	// "good capacity" considerations or treated as having no capacity for
 	// safety.
 	MetricsStalenessThreshold time.Duration
+	// BypassCriticality defines the criticality from which the requests are
+	// admitted regardless of the saturation. It's enforced by the Director.
+	BypassCriticality int
 }
 
 // Datastore provides an interface to access backend pod metrics.
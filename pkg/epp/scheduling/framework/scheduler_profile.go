@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
 	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/tracing"
 )
 
 // NewSchedulerProfile creates a new SchedulerProfile object and returns its pointer.
@@ -152,8 +153,10 @@ func (p *SchedulerProfile) runFilterPlugins(ctx context.Context, request *types.
 
 	for _, filter := range p.filters {
 		loggerDebug.Info("Running filter plugin", "plugin", filter.TypedName())
+		pluginCtx, span := tracing.StartPluginSpan(ctx, FilterExtensionPoint, filter.TypedName().Type, filter.TypedName().Name)
 		before := time.Now()
-		filteredPods = filter.Filter(ctx, cycleState, request, filteredPods)
+		filteredPods = filter.Filter(pluginCtx, cycleState, request, filteredPods)
+		span.End()
 		metrics.RecordPluginProcessingLatency(FilterExtensionPoint, filter.TypedName().Type, filter.TypedName().Name, time.Since(before))
 		loggerDebug.Info("Completed running filter plugin successfully", "plugin", filter.TypedName(), "pods", filteredPods)
 		if len(filteredPods) == 0 {
@@ -176,8 +179,10 @@ func (p *SchedulerProfile) runScorerPlugins(ctx context.Context, request *types.
 	// Iterate through each scorer in the chain and accumulate the weighted scores.
 	for _, scorer := range p.scorers {
 		loggerDebug.Info("Running scorer plugin", "plugin", scorer.TypedName())
+		pluginCtx, span := tracing.StartPluginSpan(ctx, ScorerExtensionPoint, scorer.TypedName().Type, scorer.TypedName().Name)
 		before := time.Now()
-		scores := scorer.Score(ctx, cycleState, request, pods)
+		scores := scorer.Score(pluginCtx, cycleState, request, pods)
+		span.End()
 		metrics.RecordPluginProcessingLatency(ScorerExtensionPoint, scorer.TypedName().Type, scorer.TypedName().Name, time.Since(before))
 		for pod, score := range scores { // weight is relative to the sum of weights
 			weightedScorePerPod[pod] += enforceScoreRange(score) * float64(scorer.Weight())
@@ -199,8 +204,10 @@ func (p *SchedulerProfile) runPickerPlugin(ctx context.Context, cycleState *type
 	}
 
 	loggerDebug.Info("Running picker plugin", "plugin", p.picker.TypedName(), "pods-weighted-score", fmt.Sprint(weightedScorePerPod))
+	pluginCtx, span := tracing.StartPluginSpan(ctx, PickerExtensionPoint, p.picker.TypedName().Type, p.picker.TypedName().Name)
 	before := time.Now()
-	result := p.picker.Pick(ctx, cycleState, scoredPods)
+	result := p.picker.Pick(pluginCtx, cycleState, scoredPods)
+	span.End()
 	metrics.RecordPluginProcessingLatency(PickerExtensionPoint, p.picker.TypedName().Type, p.picker.TypedName().Name, time.Since(before))
 	loggerDebug.Info("Completed running picker plugin successfully", "plugin", p.picker.TypedName(), "result", result)
 
@@ -211,8 +218,10 @@ func (p *SchedulerProfile) runPostCyclePlugins(ctx context.Context, cycleState *
 	loggerDebug := log.FromContext(ctx).V(logutil.DEBUG)
 	for _, plugin := range p.postCyclePlugins {
 		loggerDebug.Info("Running post-cycle plugin", "plugin", plugin.TypedName())
+		pluginCtx, span := tracing.StartPluginSpan(ctx, PostCycleExtensionPoint, plugin.TypedName().Type, plugin.TypedName().Name)
 		before := time.Now()
-		plugin.PostCycle(ctx, cycleState, result)
+		plugin.PostCycle(pluginCtx, cycleState, result)
+		span.End()
 		metrics.RecordPluginProcessingLatency(PostCycleExtensionPoint, plugin.TypedName().Type, plugin.TypedName().Name, time.Since(before))
 		loggerDebug.Info("Completed running post-cycle plugin successfully", "plugin", plugin.TypedName())
 	}
@@ -22,12 +22,14 @@ import (
 	"fmt"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/tracing"
 )
 
 // NewSchedulerWithConfig returns a new scheduler with the given scheduler plugins configuration.
@@ -44,7 +46,10 @@ type Scheduler struct {
 }
 
 // Schedule finds the target pod based on metrics and the requested lora adapter.
-func (s *Scheduler) Schedule(ctx context.Context, request *types.LLMRequest, candidatePods []types.Pod) (*types.SchedulingResult, error) {
+func (s *Scheduler) Schedule(ctx context.Context, request *types.LLMRequest, candidatePods []types.Pod) (result *types.SchedulingResult, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "Scheduler.Schedule", trace.WithAttributes(tracing.CandidateCountKey.Int(len(candidatePods))))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	logger := log.FromContext(ctx).WithValues("requestId", request.RequestId, "targetModel", request.TargetModel)
 	loggerDebug := logger.V(logutil.DEBUG)
 
@@ -58,8 +63,10 @@ func (s *Scheduler) Schedule(ctx context.Context, request *types.LLMRequest, can
 
 	for { // get the next set of profiles to run iteratively based on the request and the previous execution results
 		loggerDebug.Info("Running profile handler, Pick profiles", "plugin", s.profileHandler.TypedName())
+		pluginCtx, pluginSpan := tracing.StartPluginSpan(ctx, framework.ProfilePickerExtensionPoint, s.profileHandler.TypedName().Type, s.profileHandler.TypedName().Name)
 		before := time.Now()
-		profiles := s.profileHandler.Pick(ctx, cycleState, request, s.profiles, profileRunResults)
+		profiles := s.profileHandler.Pick(pluginCtx, cycleState, request, s.profiles, profileRunResults)
+		pluginSpan.End()
 		metrics.RecordPluginProcessingLatency(framework.ProfilePickerExtensionPoint, s.profileHandler.TypedName().Type, s.profileHandler.TypedName().Name, time.Since(before))
 		loggerDebug.Info("Completed running profile handler Pick profiles successfully", "plugin", s.profileHandler.TypedName(), "result", profiles)
 		if len(profiles) == 0 { // profile picker didn't pick any profile to run
@@ -69,7 +76,9 @@ func (s *Scheduler) Schedule(ctx context.Context, request *types.LLMRequest, can
 		for name, profile := range profiles {
 			loggerDebug.Info("Running scheduler profile", "name", name)
 			// run the selected profiles and collect results (current code runs all profiles)
-			profileRunResult, err := profile.Run(ctx, request, cycleState, candidatePods)
+			profileCtx, profileSpan := tracing.Tracer().Start(ctx, "SchedulerProfile.Run", trace.WithAttributes(tracing.ProfileKey.String(name)))
+			profileRunResult, err := profile.Run(profileCtx, request, cycleState, candidatePods)
+			tracing.EndSpan(profileSpan, err)
 			if err != nil {
 				loggerDebug.Info("failed to run scheduler profile", "profile", name, "error", err.Error())
 			} else {
@@ -85,8 +94,10 @@ func (s *Scheduler) Schedule(ctx context.Context, request *types.LLMRequest, can
 	}
 
 	loggerDebug.Info("Running profile handler, ProcessResults", "plugin", s.profileHandler.TypedName())
+	pluginCtx, pluginSpan := tracing.StartPluginSpan(ctx, framework.ProcessProfilesResultsExtensionPoint, s.profileHandler.TypedName().Type, s.profileHandler.TypedName().Name)
 	before := time.Now()
-	result, err := s.profileHandler.ProcessResults(ctx, cycleState, request, profileRunResults)
+	result, err = s.profileHandler.ProcessResults(pluginCtx, cycleState, request, profileRunResults)
+	pluginSpan.End()
 	metrics.RecordPluginProcessingLatency(framework.ProcessProfilesResultsExtensionPoint, s.profileHandler.TypedName().Type, s.profileHandler.TypedName().Name, time.Since(before))
 	loggerDebug.Info("Completed running profile handler ProcessResults successfully", "plugin", s.profileHandler.TypedName())
 
@@ -22,6 +22,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
@@ -32,6 +33,7 @@ import (
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/profile"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/scorer"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	testutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/testing"
 )
 
 // Tests the default scheduler configuration and expected behavior.
@@ -156,3 +158,43 @@ func TestSchedule(t *testing.T) {
 		})
 	}
 }
+
+func TestScheduleTracing(t *testing.T) {
+	recorder, restore := testutil.NewTestSpanRecorder()
+	defer restore()
+
+	defaultProfile := framework.NewSchedulerProfile().
+		WithScorers(framework.NewWeightedScorer(scorer.NewQueueScorer(), 1)).
+		WithPicker(picker.NewMaxScorePicker(picker.DefaultMaxNumOfEndpoints))
+	scheduler := NewSchedulerWithConfig(NewSchedulerConfig(profile.NewSingleProfileHandler(),
+		map[string]*framework.SchedulerProfile{"default": defaultProfile}))
+	pods := []types.Pod{&types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod1"}},
+		MetricsState: backendmetrics.NewMetricsState(),
+	}}
+
+	if _, err := scheduler.Schedule(context.Background(), &types.LLMRequest{RequestId: uuid.NewString()}, pods); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The plugins are children of their profile, which is a child of the scheduling.
+	parents := map[string]string{}
+	names := map[trace.SpanID]string{}
+	for _, span := range recorder.Ended() {
+		names[span.SpanContext().SpanID()] = span.Name()
+	}
+	for _, span := range recorder.Ended() {
+		parents[span.Name()] = names[span.Parent().SpanID()]
+	}
+	wantParents := map[string]string{
+		"Scheduler.Schedule":                            "",
+		"ProfilePicker single-profile-handler":          "Scheduler.Schedule",
+		"SchedulerProfile.Run":                          "Scheduler.Schedule",
+		"Scorer queue-scorer":                           "SchedulerProfile.Run",
+		"Picker max-score-picker":                       "SchedulerProfile.Run",
+		"ProcessProfilesResults single-profile-handler": "Scheduler.Schedule",
+	}
+	if diff := cmp.Diff(wantParents, parents); diff != "" {
+		t.Errorf("Unexpected spans (-want +got): %v", diff)
+	}
+}
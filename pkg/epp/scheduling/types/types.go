@@ -18,31 +18,68 @@ package types
 
 import (
 	"fmt"
+	"time"
 
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
 	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datalayer"
 )
 
+// RequestKind is the kind of API request, determined from the request path or body.
+type RequestKind string
+
+const (
+	RequestKindCompletions     RequestKind = "completions"
+	RequestKindChatCompletions RequestKind = "chat-completions"
+	RequestKindEmbeddings      RequestKind = "embeddings"
+	RequestKindRerank          RequestKind = "rerank"
+	RequestKindResponses       RequestKind = "responses"
+)
+
+// IsGenerative returns true if the request generates tokens, as opposed to embeddings and
+// rerank requests which only process their input.
+func (k RequestKind) IsGenerative() bool {
+	return k != RequestKindEmbeddings && k != RequestKindRerank
+}
+
 // LLMRequest is a structured representation of the fields we parse out of the LLMRequest body.
 type LLMRequest struct {
 	// RequestId is the Envoy generated Id for the request being processed
 	RequestId string
 	// TargetModel is the final target model after traffic split.
 	TargetModel string
-	// Prompt is the prompt that was sent in the request body.
+	// Kind is the kind of API request.
+	Kind RequestKind
+	// Prompt is the prompt that was sent in the request body. For embeddings and rerank
+	// requests, it is the text of the input.
 	Prompt string
 	// Headers is a map of the request headers.
 	Headers map[string]string
+	// Latency holds the latency targets of the InferenceObjective of the request, nil if it has none.
+	Latency *LatencyObjective
+}
+
+// LatencyObjective holds the latency targets of an InferenceObjective. Unset targets are zero.
+type LatencyObjective struct {
+	// TTFT is the target time to first token.
+	TTFT time.Duration
+	// TPOT is the target time per output token.
+	TPOT time.Duration
+	// Percentile is the percentage of requests expected to meet the targets.
+	Percentile int
 }
 
 func (r *LLMRequest) String() string {
-	return fmt.Sprintf("RequestID: %s, TargetModel: %s, PromptLength: %d, Headers: %v", r.RequestId, r.TargetModel, len(r.Prompt), r.Headers)
+	return fmt.Sprintf("RequestID: %s, TargetModel: %s, Kind: %s, PromptLength: %d, Headers: %v", r.RequestId, r.TargetModel, r.Kind, len(r.Prompt), r.Headers)
 }
 
 type Pod interface {
 	GetPod() *backend.Pod
 	GetMetrics() *backendmetrics.MetricsState
 	String() string
+	// Get returns a copy of the extended attribute stored under key, as written by the data layer extractors.
+	Get(key string) (datalayer.Cloneable, bool)
+	Keys() []string
 }
 
 type ScoredPod struct {
@@ -65,9 +102,24 @@ func (pm *PodMetrics) GetMetrics() *backendmetrics.MetricsState {
 	return pm.MetricsState
 }
 
+func (pm *PodMetrics) Get(key string) (datalayer.Cloneable, bool) {
+	if pm.AttributeMap == nil {
+		return nil, false
+	}
+	return pm.AttributeMap.Get(key)
+}
+
+func (pm *PodMetrics) Keys() []string {
+	if pm.AttributeMap == nil {
+		return nil
+	}
+	return pm.AttributeMap.Keys()
+}
+
 type PodMetrics struct {
 	*backend.Pod
 	*backendmetrics.MetricsState
+	datalayer.AttributeMap
 }
 
 // ProfileRunResult captures the profile run result.
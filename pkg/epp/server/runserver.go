@@ -36,6 +36,7 @@ import (
 	"sigs.k8s.io/gateway-api-inference-extension/internal/runnable"
 	tlsutil "sigs.k8s.io/gateway-api-inference-extension/internal/tls"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/common"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/common/validation"
 	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/controller"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
@@ -56,6 +57,12 @@ type ExtProcServerRunner struct {
 	MetricsStalenessThreshold        time.Duration
 	Director                         *requestcontrol.Director
 	SaturationDetector               requestcontrol.SaturationDetector
+	LoadReportParser                 *backendmetrics.LoadReportParser
+	RequestValidator                 *validation.Validator
+	ProcessingMode                   handlers.ProcessingMode
+	ModelsOptions                    *handlers.ModelsOptions
+	Drainer                          *handlers.Drainer
+	DrainTimeout                     time.Duration
 
 	// This should only be used in tests. We won't need this once we do not inject metrics in the tests.
 	// TODO:(https://github.com/kubernetes-sigs/gateway-api-inference-extension/issues/432) Cleanup
@@ -71,15 +78,44 @@ const (
 	DefaultPoolNamespace                    = "default"                     // default for --pool-namespace
 	DefaultRefreshMetricsInterval           = 50 * time.Millisecond         // default for --refresh-metrics-interval
 	DefaultRefreshPrometheusMetricsInterval = 5 * time.Second               // default for --refresh-prometheus-metrics-interval
+	DefaultMetricsScrapeTimeout             = 5 * time.Second               // default for --metrics-scrape-timeout
+	DefaultMetricsScrapeWorkers             = 64                            // default for --metrics-scrape-workers
+	DefaultMetricsScrapeJitter              = 0.1                           // default for --metrics-scrape-jitter
+	DefaultMetricsScrapeMaxBackoff          = 5 * time.Second               // default for --metrics-scrape-max-backoff
+	DefaultMetricsScrapeMaxInterval         = time.Second                   // default for --metrics-scrape-max-interval
+	DefaultMetricsScrapeAllowConcurrent     = false                         // default for --metrics-scrape-allow-concurrent
+	DefaultLoadReportFormat                 = "none"                        // default for --load-report-format
+	DefaultLoadReportHeader                 = "endpoint-load-metrics"       // default for --load-report-header
+	DefaultLoadReportQueuedRequestsMetric   = "num_requests_waiting"        // default for --load-report-queued-requests-metric
+	DefaultLoadReportRunningRequestsMetric  = "num_requests_running"        // default for --load-report-running-requests-metric
+	DefaultLoadReportKVCacheUsageMetric     = "kv_cache_usage_perc"         // default for --load-report-kv-cache-usage-metric
+	DefaultProcessingMode                   = "FULL_DUPLEX_STREAMED"        // default for --processing-mode
+	DefaultServeModels                      = true                          // default for --serve-models
+	DefaultModelsReadiness                  = false                         // default for --models-readiness
+	DefaultObjectiveStatusSyncInterval      = 30 * time.Second              // default for --objective-status-sync-interval
+	DefaultDrainTimeout                     = 20 * time.Second              // default for --drain-timeout
+	DefaultRequestValidation                = false                         // default for --request-validation
+	DefaultMaxRequestBodyBytes              = 0                             // default for --max-request-body-bytes
+	DefaultMaxRequestMessages               = 0                             // default for --max-request-messages
+	DefaultMaxRequestTokens                 = 0                             // default for --max-request-tokens
 	DefaultSecureServing                    = true                          // default for --secure-serving
 	DefaultHealthChecking                   = false                         // default for --health-checking
 	DefaultEnablePprof                      = true                          // default for --enable-pprof
 	DefaultTotalQueuedRequestsMetric        = "vllm:num_requests_waiting"   // default for --total-queued-requests-metric
 	DefaultKvCacheUsagePercentageMetric     = "vllm:gpu_cache_usage_perc"   // default for --kv-cache-usage-percentage-metric
 	DefaultLoraInfoMetric                   = "vllm:lora_requests_info"     // default for --lora-info-metric
+	DefaultTotalRunningRequestsMetric       = "vllm:num_requests_running"   // default for --total-running-requests-metric
+	DefaultCacheInfoMetric                  = "vllm:cache_config_info"      // default for --cache-info-metric
+	DefaultModelServerType                  = ""                            // default for --model-server-type
 	DefaultCertPath                         = ""                            // default for --cert-path
 	DefaultConfigFile                       = ""                            // default for --config-file
 	DefaultConfigText                       = ""                            // default for --config-text
+	DefaultStandaloneConfigFile             = ""                            // default for --standalone-config-file
+	DefaultFileWatchInterval                = 5 * time.Second               // default for --file-watch-interval
+	DefaultTracingExporter                  = "none"                        // default for --tracing-exporter
+	DefaultTracingOTLPEndpoint              = ""                            // default for --tracing-otlp-endpoint
+	DefaultTracingOTLPInsecure              = false                         // default for --tracing-otlp-insecure
+	DefaultTracingSamplingRatio             = 0.1                           // default for --tracing-sampling-ratio
 	DefaultPoolGroup                        = "inference.networking.k8s.io" // default for --pool-group
 	DefaultMetricsStalenessThreshold        = 2 * time.Second
 )
@@ -102,6 +138,8 @@ func NewDefaultExtProcServerRunner() *ExtProcServerRunner {
 		HealthChecking:                   DefaultHealthChecking,
 		RefreshPrometheusMetricsInterval: DefaultRefreshPrometheusMetricsInterval,
 		MetricsStalenessThreshold:        DefaultMetricsStalenessThreshold,
+		ProcessingMode:                   DefaultProcessingMode,
+		DrainTimeout:                     DefaultDrainTimeout,
 		// Dependencies can be assigned later.
 	}
 }
@@ -163,7 +201,12 @@ func (r *ExtProcServerRunner) AsRunnable(logger logr.Logger) manager.Runnable {
 			srv = grpc.NewServer()
 		}
 
-		extProcServer := handlers.NewStreamingServer(r.Datastore, r.Director)
+		extProcServer := handlers.NewStreamingServer(r.Datastore, r.Director).
+			WithLoadReportParser(r.LoadReportParser).
+			WithRequestValidator(r.RequestValidator).
+			WithProcessingMode(r.ProcessingMode).
+			WithModels(r.ModelsOptions).
+			WithDrainer(r.Drainer)
 		extProcPb.RegisterExternalProcessorServer(srv, extProcServer)
 
 		if r.HealthChecking {
@@ -177,6 +220,16 @@ func (r *ExtProcServerRunner) AsRunnable(logger logr.Logger) manager.Runnable {
 		}
 
 		// Forward to the gRPC runnable.
-		return runnable.GRPCServer("ext-proc", srv, r.GrpcPort).Start(ctx)
+		if r.Drainer == nil {
+			return runnable.GRPCServer("ext-proc", srv, r.GrpcPort).Start(ctx)
+		}
+		return runnable.GRPCServerWithDrain("ext-proc", srv, r.GrpcPort, r.drain, r.DrainTimeout).Start(ctx)
 	}))
 }
+
+// drain refuses new streams and waits for the in-flight ones to complete, or for the drain
+// timeout to expire.
+func (r *ExtProcServerRunner) drain(ctx context.Context) {
+	r.Drainer.Start()
+	_ = r.Drainer.Wait(ctx)
+}
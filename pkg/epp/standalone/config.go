@@ -0,0 +1,196 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package standalone runs the EPP without Kubernetes.
+//
+// In the standalone mode, the InferencePool, its endpoints and the InferenceObjectives are read
+// from a local YAML file instead of the API server, e.g.:
+//
+//	pool:
+//	  metadata:
+//	    name: vllm
+//	  spec:
+//	    targetPortNumber: 8000
+//	    selector:
+//	      app: vllm
+//	endpoints:
+//	- name: vllm-0
+//	  address: 10.0.0.1
+//	- name: vllm-1
+//	  address: 10.0.0.2
+//	- name: local
+//	  address: 127.0.0.1
+//	  ports: [8001, 8002]
+//	objectives:
+//	- metadata:
+//	    name: chat
+//	  spec:
+//	    criticality: 1
+//
+// The endpoints are fed to the datastore as ready pods, so that the ext-proc server, the scheduler
+// and the metrics scraping work as they do in Kubernetes.
+package standalone
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	"sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/common"
+)
+
+// defaultNamespace is the namespace of the pool when the file doesn't set one.
+const defaultNamespace = "default"
+
+// Config is the content of the standalone configuration file.
+type Config struct {
+	// Pool is the InferencePool served by the EPP. Its status is ignored.
+	Pool v1.InferencePool `json:"pool"`
+	// Endpoints are the model servers of the pool.
+	Endpoints []Endpoint `json:"endpoints"`
+	// Objectives are the InferenceObjectives of the pool. They are in the namespace of the pool.
+	Objectives []v1alpha2.InferenceObjective `json:"objectives,omitempty"`
+}
+
+// Endpoint is a model server of the pool. It serves the target ports of the pool, like the pods
+// selected by a pool do in Kubernetes, unless it has its own ports, e.g. to run several model
+// servers on the same host.
+type Endpoint struct {
+	// Name is the name of the endpoint, unique within the pool.
+	Name string `json:"name"`
+	// Address is the IP address of the endpoint.
+	Address string `json:"address"`
+	// Port is the port of the endpoint, overriding the target ports of the pool.
+	Port int32 `json:"port,omitempty"`
+	// Ports are the ports of the data parallel ranks of the endpoint, overriding the target ports
+	// of the pool. Only one of Port and Ports can be set.
+	Ports []int32 `json:"ports,omitempty"`
+	// Labels are the labels of the endpoint, matched by the selector of the pool. They default to
+	// the selector of the pool.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// Parse parses and validates the content of a configuration file, and sets its defaults.
+func Parse(content []byte) (*Config, error) {
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(content, config); err != nil {
+		return nil, fmt.Errorf("failed to parse the standalone configuration: %w", err)
+	}
+	setDefaults(config)
+	if err := validate(config); err != nil {
+		return nil, fmt.Errorf("invalid standalone configuration: %w", err)
+	}
+	return config, nil
+}
+
+func setDefaults(config *Config) {
+	pool := &config.Pool
+	if pool.Namespace == "" {
+		pool.Namespace = defaultNamespace
+	}
+	for i := range config.Endpoints {
+		endpoint := &config.Endpoints[i]
+		if endpoint.Labels == nil {
+			endpoint.Labels = make(map[string]string, len(pool.Spec.Selector))
+			for key, value := range pool.Spec.Selector {
+				endpoint.Labels[string(key)] = string(value)
+			}
+		}
+	}
+	for i := range config.Objectives {
+		objective := &config.Objectives[i]
+		objective.Namespace = pool.Namespace
+		if objective.Spec.PoolRef.Name == "" {
+			objective.Spec.PoolRef.Name = v1alpha2.ObjectName(pool.Name)
+		}
+		if objective.Spec.PoolRef.Group == "" {
+			objective.Spec.PoolRef.Group = v1.GroupName
+		}
+		if objective.Spec.PoolRef.Kind == "" {
+			objective.Spec.PoolRef.Kind = "InferencePool"
+		}
+	}
+}
+
+func validate(config *Config) error {
+	var errs []error
+	pool := &config.Pool
+	if pool.Name == "" {
+		errs = append(errs, errors.New("pool.metadata.name is required"))
+	}
+	if pool.Spec.TargetPortNumber != 0 && len(pool.Spec.TargetPorts) > 0 {
+		errs = append(errs, errors.New("only one of pool.spec.targetPortNumber and pool.spec.targetPorts can be set"))
+	}
+	ports := common.TargetPorts(&pool.Spec)
+	if len(ports) == 0 {
+		errs = append(errs, errors.New("one of pool.spec.targetPortNumber and pool.spec.targetPorts is required"))
+	}
+	errs = append(errs, validatePorts("target port", ports)...)
+
+	names := make(map[string]bool, len(config.Endpoints))
+	for i, endpoint := range config.Endpoints {
+		for _, msg := range validation.IsDNS1123Subdomain(endpoint.Name) {
+			errs = append(errs, fmt.Errorf("endpoints[%d].name %q: %s", i, endpoint.Name, msg))
+		}
+		if names[endpoint.Name] {
+			errs = append(errs, fmt.Errorf("endpoints[%d].name %q is duplicated", i, endpoint.Name))
+		}
+		names[endpoint.Name] = true
+		if net.ParseIP(endpoint.Address) == nil {
+			errs = append(errs, fmt.Errorf("endpoints[%d].address %q must be an IP address", i, endpoint.Address))
+		}
+		if endpoint.Port != 0 && len(endpoint.Ports) > 0 {
+			errs = append(errs, fmt.Errorf("only one of endpoints[%d].port and endpoints[%d].ports can be set", i, i))
+		}
+		errs = append(errs, validatePorts(fmt.Sprintf("endpoints[%d] port", i), endpoint.targetPorts())...)
+	}
+
+	objectives := make(map[string]bool, len(config.Objectives))
+	for i, objective := range config.Objectives {
+		if objective.Name == "" {
+			errs = append(errs, fmt.Errorf("objectives[%d].metadata.name is required", i))
+		}
+		if objectives[objective.Name] {
+			errs = append(errs, fmt.Errorf("objectives[%d].metadata.name %q is duplicated", i, objective.Name))
+		}
+		objectives[objective.Name] = true
+	}
+	return errors.Join(errs...)
+}
+
+// validatePorts validates that the ports are in the range of the TCP ports.
+func validatePorts(kind string, ports []int32) []error {
+	var errs []error
+	for _, port := range ports {
+		if port < 1 || port > 65535 {
+			errs = append(errs, fmt.Errorf("%s %d must be in the range 1 to 65535", kind, port))
+		}
+	}
+	return errs
+}
+
+// targetPorts returns the ports of the endpoint, or nil if it serves the target ports of the pool.
+func (e *Endpoint) targetPorts() []int32 {
+	if e.Port != 0 {
+		return []int32{e.Port}
+	}
+	return e.Ports
+}
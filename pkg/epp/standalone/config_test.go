@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	"sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
+)
+
+func TestParse(t *testing.T) {
+	config, err := Parse([]byte(`
+pool:
+  metadata:
+    name: vllm
+  spec:
+    targetPortNumber: 8000
+    selector:
+      app: vllm
+endpoints:
+- name: vllm-0
+  address: 10.0.0.1
+- name: vllm-1
+  address: fd00::1
+  labels:
+    app: other
+objectives:
+- metadata:
+    name: chat
+  spec:
+    criticality: 1
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "default", config.Pool.Namespace)
+	assert.Equal(t, map[string]string{"app": "vllm"}, config.Endpoints[0].Labels)
+	assert.Equal(t, map[string]string{"app": "other"}, config.Endpoints[1].Labels)
+	require.Len(t, config.Objectives, 1)
+	assert.Equal(t, "default", config.Objectives[0].Namespace)
+	assert.Equal(t, v1alpha2.PoolObjectReference{Group: v1.GroupName, Kind: "InferencePool", Name: "vllm"},
+		config.Objectives[0].Spec.PoolRef)
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "invalid YAML",
+			content: "pool: [",
+			wantErr: "failed to parse",
+		},
+		{
+			name:    "unknown field",
+			content: "pool:\n  metadata:\n    name: vllm\n  spec:\n    targetPortNumber: 8000\nendpoint: []",
+			wantErr: `unknown field "endpoint"`,
+		},
+		{
+			name:    "missing pool name",
+			content: "pool:\n  spec:\n    targetPortNumber: 8000",
+			wantErr: "pool.metadata.name is required",
+		},
+		{
+			name:    "missing target port",
+			content: "pool:\n  metadata:\n    name: vllm",
+			wantErr: "one of pool.spec.targetPortNumber and pool.spec.targetPorts is required",
+		},
+		{
+			name:    "both target port forms",
+			content: "pool:\n  metadata:\n    name: vllm\n  spec:\n    targetPortNumber: 8000\n    targetPorts: [8000]",
+			wantErr: "only one of pool.spec.targetPortNumber and pool.spec.targetPorts can be set",
+		},
+		{
+			name:    "target port out of range",
+			content: "pool:\n  metadata:\n    name: vllm\n  spec:\n    targetPorts: [8000, 70000]",
+			wantErr: "target port 70000 must be in the range 1 to 65535",
+		},
+		{
+			name: "invalid endpoint name",
+			content: "pool:\n  metadata:\n    name: vllm\n  spec:\n    targetPortNumber: 8000\n" +
+				"endpoints:\n- name: Not_A_Name\n  address: 10.0.0.1",
+			wantErr: `endpoints[0].name "Not_A_Name"`,
+		},
+		{
+			name: "duplicated endpoint",
+			content: "pool:\n  metadata:\n    name: vllm\n  spec:\n    targetPortNumber: 8000\n" +
+				"endpoints:\n- name: vllm-0\n  address: 10.0.0.1\n- name: vllm-0\n  address: 10.0.0.2",
+			wantErr: `endpoints[1].name "vllm-0" is duplicated`,
+		},
+		{
+			name: "host name address",
+			content: "pool:\n  metadata:\n    name: vllm\n  spec:\n    targetPortNumber: 8000\n" +
+				"endpoints:\n- name: vllm-0\n  address: localhost",
+			wantErr: `endpoints[0].address "localhost" must be an IP address`,
+		},
+		{
+			name: "endpoint port and ports",
+			content: "pool:\n  metadata:\n    name: vllm\n  spec:\n    targetPortNumber: 8000\n" +
+				"endpoints:\n- name: vllm-0\n  address: 127.0.0.1\n  port: 8001\n  ports: [8001, 8002]",
+			wantErr: "only one of endpoints[0].port and endpoints[0].ports can be set",
+		},
+		{
+			name: "invalid endpoint port",
+			content: "pool:\n  metadata:\n    name: vllm\n  spec:\n    targetPortNumber: 8000\n" +
+				"endpoints:\n- name: vllm-0\n  address: 127.0.0.1\n  ports: [8001, 0]",
+			wantErr: "endpoints[0] port 0 must be in the range 1 to 65535",
+		},
+		{
+			name: "duplicated objective",
+			content: "pool:\n  metadata:\n    name: vllm\n  spec:\n    targetPortNumber: 8000\n" +
+				"objectives:\n- metadata:\n    name: chat\n- metadata:\n    name: chat",
+			wantErr: `objectives[1].metadata.name "chat" is duplicated`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse([]byte(test.content))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.wantErr)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datalayer"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// Provider feeds the datastore with the pool, the endpoints and the objectives of the standalone
+// configuration file, in lieu of the reconcilers of the Kubernetes mode.
+type Provider struct {
+	datastore datastore.Datastore
+
+	mu sync.Mutex
+	// pods are the endpoints of the current configuration, as pods.
+	pods map[types.NamespacedName]*corev1.Pod
+	// objectives are the names of the objectives of the current configuration.
+	objectives map[types.NamespacedName]bool
+}
+
+// NewProvider returns a Provider feeding the given datastore.
+func NewProvider(ds datastore.Datastore) *Provider {
+	return &Provider{
+		datastore:  ds,
+		pods:       make(map[types.NamespacedName]*corev1.Pod),
+		objectives: make(map[types.NamespacedName]bool),
+	}
+}
+
+// Update parses the content of a configuration file and applies it to the datastore. An invalid
+// content is rejected, and the datastore keeps the previous configuration. Its signature is the one
+// of filewatch.Handler.
+func (p *Provider) Update(ctx context.Context, content []byte) error {
+	config, err := Parse(content)
+	if err != nil {
+		return err
+	}
+	return p.apply(ctx, config)
+}
+
+func (p *Provider) apply(ctx context.Context, config *Config) error {
+	logger := log.FromContext(ctx)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pods := make(map[types.NamespacedName]*corev1.Pod, len(config.Endpoints))
+	for _, endpoint := range config.Endpoints {
+		pod := toPod(endpoint, config.Pool.Namespace)
+		pods[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}] = pod
+	}
+
+	// The datastore resyncs the pods from the reader when the selector or the ports of the pool
+	// change, like it lists them from the API server in Kubernetes.
+	if err := p.datastore.PoolSet(ctx, podLister(pods), config.Pool.DeepCopy()); err != nil {
+		return fmt.Errorf("failed to set the pool: %w", err)
+	}
+	for namespacedName := range p.pods {
+		if _, ok := pods[namespacedName]; !ok {
+			logger.V(logutil.DEFAULT).Info("Removing endpoint", "name", namespacedName)
+			p.datastore.PodDelete(namespacedName)
+		}
+	}
+	for namespacedName, pod := range pods {
+		if !p.datastore.PoolLabelsMatch(pod.Labels) {
+			p.datastore.PodDelete(namespacedName)
+			continue
+		}
+		if old, ok := p.pods[namespacedName]; ok &&
+			old.Annotations[datalayer.TargetPortsAnnotation] != pod.Annotations[datalayer.TargetPortsAnnotation] {
+			// The endpoints of the previous ports are replaced, like on a change of the pool's ports.
+			p.datastore.PodDelete(namespacedName)
+		}
+		if added := p.datastore.PodUpdateOrAddIfNotExist(pod); len(added) > 0 {
+			logger.V(logutil.DEFAULT).Info("Endpoint added", "name", namespacedName, "endpoints", added)
+		}
+	}
+	p.pods = pods
+
+	objectives := make(map[types.NamespacedName]bool, len(config.Objectives))
+	for i := range config.Objectives {
+		objective := config.Objectives[i].DeepCopy()
+		namespacedName := types.NamespacedName{Namespace: objective.Namespace, Name: objective.Name}
+		objectives[namespacedName] = true
+		p.datastore.ObjectiveSet(objective)
+	}
+	for namespacedName := range p.objectives {
+		if !objectives[namespacedName] {
+			p.datastore.ObjectiveDelete(namespacedName)
+		}
+	}
+	p.objectives = objectives
+	return nil
+}
+
+// toPod returns a ready pod standing for the endpoint. The ports of the endpoint, if any, are set
+// in the TargetPortsAnnotation of the pod.
+func toPod(endpoint Endpoint, namespace string) *corev1.Pod {
+	var annotations map[string]string
+	if ports := endpoint.targetPorts(); len(ports) > 0 {
+		values := make([]string, len(ports))
+		for i, port := range ports {
+			values[i] = strconv.Itoa(int(port))
+		}
+		annotations = map[string]string{datalayer.TargetPortsAnnotation: strings.Join(values, ",")}
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        endpoint.Name,
+			Namespace:   namespace,
+			Labels:      endpoint.Labels,
+			Annotations: annotations,
+		},
+		Status: corev1.PodStatus{
+			PodIP:      endpoint.Address,
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+// podLister is a client.Reader listing the endpoints of the configuration as pods.
+type podLister map[types.NamespacedName]*corev1.Pod
+
+// Get implements client.Reader.
+func (l podLister) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return fmt.Errorf("unsupported object %T", obj)
+	}
+	found, ok := l[key]
+	if !ok {
+		return apierrors.NewNotFound(corev1.Resource("pods"), key.Name)
+	}
+	found.DeepCopyInto(pod)
+	return nil
+}
+
+// List implements client.Reader.
+func (l podLister) List(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	podList, ok := list.(*corev1.PodList)
+	if !ok {
+		return fmt.Errorf("unsupported list %T", list)
+	}
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	selector := listOpts.LabelSelector
+	if selector == nil {
+		selector = labels.Everything()
+	}
+	podList.Items = nil
+	for _, pod := range l {
+		if listOpts.Namespace != "" && pod.Namespace != listOpts.Namespace {
+			continue
+		}
+		if selector.Matches(labels.Set(pod.Labels)) {
+			podList.Items = append(podList.Items, *pod.DeepCopy())
+		}
+	}
+	return nil
+}
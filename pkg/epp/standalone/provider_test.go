@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+)
+
+func TestProvider(t *testing.T) {
+	pmf := backendmetrics.NewPodMetricsFactory(&backendmetrics.FakePodMetricsClient{}, time.Second)
+	ds := datastore.NewDatastore(t.Context(), pmf)
+	provider := NewProvider(ds)
+	ctx := context.Background()
+
+	endpoints := func() []string {
+		got := []string{}
+		for _, pm := range ds.PodList(backendmetrics.AllPodsPredicate) {
+			pod := pm.GetPod()
+			got = append(got, fmt.Sprintf("%s=%s:%d", pod.NamespacedName.Name, pod.Address, pod.Port))
+		}
+		slices.Sort(got)
+		return got
+	}
+	objectives := func() []string {
+		got := []string{}
+		for _, objective := range ds.ObjectiveGetAll() {
+			got = append(got, objective.Name)
+		}
+		slices.Sort(got)
+		return got
+	}
+
+	require.NoError(t, provider.Update(ctx, []byte(`
+pool:
+  metadata:
+    name: vllm
+  spec:
+    targetPortNumber: 8000
+    selector:
+      app: vllm
+endpoints:
+- name: vllm-0
+  address: 10.0.0.1
+- name: vllm-1
+  address: 10.0.0.2
+- name: other
+  address: 10.0.0.3
+  labels:
+    app: other
+objectives:
+- metadata:
+    name: chat
+- metadata:
+    name: batch
+`)))
+	pool, err := ds.PoolGet()
+	require.NoError(t, err)
+	assert.Equal(t, "vllm", pool.Name)
+	assert.Equal(t, []string{"vllm-0=10.0.0.1:8000", "vllm-1=10.0.0.2:8000"}, endpoints())
+	assert.Equal(t, []string{"batch", "chat"}, objectives())
+
+	// The endpoints and the objectives follow the updates of the file.
+	require.NoError(t, provider.Update(ctx, []byte(`
+pool:
+  metadata:
+    name: vllm
+  spec:
+    targetPortNumber: 8000
+    selector:
+      app: vllm
+endpoints:
+- name: vllm-0
+  address: 10.0.0.10
+- name: other
+  address: 10.0.0.3
+objectives:
+- metadata:
+    name: chat
+`)))
+	assert.Equal(t, []string{"other=10.0.0.3:8000", "vllm-0=10.0.0.10:8000"}, endpoints())
+	assert.Equal(t, []string{"chat"}, objectives())
+
+	// Changing the target ports of the pool resyncs the endpoints.
+	require.NoError(t, provider.Update(ctx, []byte(`
+pool:
+  metadata:
+    name: vllm
+  spec:
+    targetPorts: [8000, 8001]
+    selector:
+      app: vllm
+endpoints:
+- name: vllm-0
+  address: 10.0.0.10
+`)))
+	assert.Equal(t, []string{"vllm-0:8000=10.0.0.10:8000", "vllm-0:8001=10.0.0.10:8001"}, endpoints())
+	assert.Equal(t, []string{}, objectives())
+
+	// An invalid file is rejected, and the previous configuration is kept.
+	assert.Error(t, provider.Update(ctx, []byte("pool: {}")))
+	assert.Equal(t, []string{"vllm-0:8000=10.0.0.10:8000", "vllm-0:8001=10.0.0.10:8001"}, endpoints())
+
+	// The ports of an endpoint override the target ports of the pool.
+	require.NoError(t, provider.Update(ctx, []byte(`
+pool:
+  metadata:
+    name: vllm
+  spec:
+    targetPorts: [8000, 8001]
+    selector:
+      app: vllm
+endpoints:
+- name: vllm-0
+  address: 127.0.0.1
+  port: 8002
+- name: vllm-1
+  address: 127.0.0.1
+  ports: [8003, 8004]
+`)))
+	assert.Equal(t, []string{"vllm-0=127.0.0.1:8002", "vllm-1:8003=127.0.0.1:8003", "vllm-1:8004=127.0.0.1:8004"}, endpoints())
+}
@@ -18,12 +18,16 @@ package error
 
 import (
 	"fmt"
+	"time"
 )
 
 // Error is an error struct for errors returned by the epp server.
 type Error struct {
 	Code string
 	Msg  string
+	// RetryAfter is the time after which the request can be retried, sent in the Retry-After header
+	// of RateLimited errors.
+	RetryAfter time.Duration
 }
 
 const (
@@ -34,6 +38,7 @@ const (
 	ModelServerError               = "ModelServerError"
 	BadConfiguration               = "BadConfiguration"
 	InferencePoolResourceExhausted = "InferencePoolResourceExhausted"
+	RateLimited                    = "RateLimited"
 )
 
 // Error returns a string version of the error.
@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package filewatch watches the content of files.
+//
+// The files are polled rather than watched with inotify, since the files mounted from a ConfigMap
+// are replaced by swapping a symbolic link of their directory, which is missed by the watches of
+// the file itself.
+package filewatch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// Handler is called with the new content of a watched file. The content is only handed again to
+// the handler once it changes, even if the handler returned an error.
+type Handler func(ctx context.Context, content []byte) error
+
+// Watcher polls a file and calls its handler when the content of the file changes. A missing or
+// empty file is skipped, since it's usually being written.
+type Watcher struct {
+	path     string
+	interval time.Duration
+	handler  Handler
+	last     []byte
+}
+
+// New returns a Watcher polling the file at the given interval.
+func New(path string, interval time.Duration, handler Handler) *Watcher {
+	return &Watcher{
+		path:     path,
+		interval: interval,
+		handler:  handler,
+	}
+}
+
+// Load reads the file and calls the handler, returning its error. It's called once before Start,
+// so that an invalid file fails the startup rather than being logged.
+func (w *Watcher) Load(ctx context.Context) error {
+	content, err := os.ReadFile(w.path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", w.path, err)
+	}
+	w.last = content
+	return w.handler(ctx, content)
+}
+
+// Skip records the content as handled, without calling the handler. It's used when the file was
+// read and applied at startup by other means, so that only its later changes are handled.
+func (w *Watcher) Skip(content []byte) {
+	w.last = content
+}
+
+// Start polls the file until the context is done. It implements manager.Runnable.
+func (w *Watcher) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithValues("path", w.path)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		content, err := os.ReadFile(w.path)
+		if err != nil {
+			// The file may be missing while it's being replaced.
+			logger.V(logutil.DEFAULT).Info("Failed to read the watched file", "error", err)
+			continue
+		}
+		if len(content) == 0 {
+			// The file is being written, e.g. it was truncated before its new content is written.
+			logger.V(logutil.DEFAULT).Info("Skipping the empty watched file")
+			continue
+		}
+		if bytes.Equal(content, w.last) {
+			continue
+		}
+		w.last = content
+		logger.V(logutil.DEFAULT).Info("Watched file changed")
+		if err := w.handler(ctx, content); err != nil {
+			logger.Error(err, "Failed to handle the change of the watched file, keeping the previous content")
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Every replica watches the file.
+func (w *Watcher) NeedLeaderElection() bool {
+	return false
+}
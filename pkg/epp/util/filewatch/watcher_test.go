@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filewatch
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("v1"), 0o600))
+
+	var mu sync.Mutex
+	var contents []string
+	watcher := New(path, time.Millisecond, func(_ context.Context, content []byte) error {
+		mu.Lock()
+		defer mu.Unlock()
+		contents = append(contents, string(content))
+		if string(content) == "invalid" {
+			return errors.New("invalid content")
+		}
+		return nil
+	})
+	handled := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string{}, contents...)
+	}
+
+	require.NoError(t, watcher.Load(context.Background()))
+	assert.Equal(t, []string{"v1"}, handled())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- watcher.Start(ctx) }()
+
+	// An unchanged file isn't handled again.
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, []string{"v1"}, handled())
+
+	// A missing or empty file is skipped until it's written again.
+	require.NoError(t, os.Remove(path))
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, os.WriteFile(path, []byte("invalid"), 0o600))
+	assert.Eventually(t, func() bool { return len(handled()) == 2 }, time.Second, time.Millisecond)
+
+	// An error of the handler doesn't stop the watcher, nor replays the content.
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, os.WriteFile(path, []byte("v2"), 0o600))
+	assert.Eventually(t, func() bool { return len(handled()) == 3 }, time.Second, time.Millisecond)
+	assert.Equal(t, []string{"v1", "invalid", "v2"}, handled())
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestWatcherLoadMissingFile(t *testing.T) {
+	watcher := New(filepath.Join(t.TempDir(), "missing.yaml"), time.Second, func(context.Context, []byte) error {
+		t.Fatal("unexpected call of the handler")
+		return nil
+	})
+	assert.Error(t, watcher.Load(context.Background()))
+}
+
+func TestWatcherSkip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("v1"), 0o600))
+
+	changed := make(chan string, 1)
+	watcher := New(path, time.Millisecond, func(_ context.Context, content []byte) error {
+		changed <- string(content)
+		return nil
+	})
+	watcher.Skip([]byte("v1"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = watcher.Start(ctx) }()
+
+	// The skipped content isn't handled, while the next one is.
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, os.WriteFile(path, []byte("v2"), 0o600))
+	select {
+	case content := <-changed:
+		assert.Equal(t, "v2", content)
+	case <-time.After(time.Second):
+		t.Fatal("the change of the file wasn't handled")
+	}
+}
@@ -18,10 +18,51 @@ package request
 
 import (
 	"fmt"
+	"strings"
 
+	schedulingtypes "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
 	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
 )
 
+// requestKindPathSuffixes maps the suffixes of the API paths to the request kinds. The suffixes
+// are matched in order, so that proxies can serve the API under any prefix.
+var requestKindPathSuffixes = []struct {
+	suffix string
+	kind   schedulingtypes.RequestKind
+}{
+	{suffix: "/chat/completions", kind: schedulingtypes.RequestKindChatCompletions},
+	{suffix: "/completions", kind: schedulingtypes.RequestKindCompletions},
+	{suffix: "/embeddings", kind: schedulingtypes.RequestKindEmbeddings},
+	{suffix: "/rerank", kind: schedulingtypes.RequestKindRerank},
+	{suffix: "/responses", kind: schedulingtypes.RequestKindResponses},
+}
+
+// ExtractRequestKind returns the kind of the request. It is determined from the request path
+// (the :path header) when it is a known API path, and from the body fields otherwise.
+func ExtractRequestKind(path string, body map[string]any) schedulingtypes.RequestKind {
+	path, _, _ = strings.Cut(path, "?")
+	path = strings.TrimSuffix(path, "/")
+	for _, entry := range requestKindPathSuffixes {
+		if strings.HasSuffix(path, entry.suffix) {
+			return entry.kind
+		}
+	}
+
+	switch {
+	case body["messages"] != nil:
+		return schedulingtypes.RequestKindChatCompletions
+	case body["query"] != nil && body["documents"] != nil:
+		return schedulingtypes.RequestKindRerank
+	case body["input"] != nil && body["instructions"] != nil:
+		return schedulingtypes.RequestKindResponses
+	case body["input"] != nil:
+		return schedulingtypes.RequestKindEmbeddings
+	}
+	return schedulingtypes.RequestKindCompletions
+}
+
+// ExtractPromptFromRequestBody extracts the prompt of a completions or chat completions
+// request, depending on the body fields.
 func ExtractPromptFromRequestBody(body map[string]any) (string, error) {
 	if _, ok := body["messages"]; ok {
 		return extractPromptFromMessagesField(body)
@@ -29,6 +70,23 @@ func ExtractPromptFromRequestBody(body map[string]any) (string, error) {
 	return extractPromptField(body)
 }
 
+// ExtractPrompt extracts the prompt of a request of the given kind. For embeddings and rerank
+// requests, the prompt is the text of the input.
+func ExtractPrompt(kind schedulingtypes.RequestKind, body map[string]any) (string, error) {
+	switch kind {
+	case schedulingtypes.RequestKindChatCompletions:
+		return extractPromptFromMessagesField(body)
+	case schedulingtypes.RequestKindEmbeddings:
+		return extractEmbeddingsInput(body)
+	case schedulingtypes.RequestKindRerank:
+		return extractRerankInput(body)
+	case schedulingtypes.RequestKindResponses:
+		return extractResponsesInput(body)
+	default:
+		return extractPromptField(body)
+	}
+}
+
 func extractPromptField(body map[string]any) (string, error) {
 	prompt, ok := body["prompt"]
 	if !ok {
@@ -81,6 +139,109 @@ func extractPromptFromMessagesField(body map[string]any) (string, error) {
 	return prompt, nil
 }
 
+// extractEmbeddingsInput returns the input of an embeddings request, a string or a list of
+// strings joined by new lines. Token id inputs don't have a text prompt.
+func extractEmbeddingsInput(body map[string]any) (string, error) {
+	input, ok := body["input"]
+	if !ok {
+		return "", errutil.Error{Code: errutil.BadRequest, Msg: "input not found in request"}
+	}
+	switch input := input.(type) {
+	case string:
+		return input, nil
+	case []any:
+		if len(input) == 0 {
+			return "", errutil.Error{Code: errutil.BadRequest, Msg: "input is empty"}
+		}
+		texts := make([]string, 0, len(input))
+		for _, item := range input {
+			if text, ok := item.(string); ok {
+				texts = append(texts, text)
+			}
+		}
+		return strings.Join(texts, "\n"), nil
+	}
+	return "", errutil.Error{Code: errutil.BadRequest, Msg: "input is not a string or a list"}
+}
+
+// extractRerankInput returns the query of a rerank request followed by its documents, which
+// are strings or objects with a text field.
+func extractRerankInput(body map[string]any) (string, error) {
+	query, ok := body["query"].(string)
+	if !ok {
+		return "", errutil.Error{Code: errutil.BadRequest, Msg: "query not found in request or not a string"}
+	}
+	documents, ok := body["documents"].([]any)
+	if !ok {
+		return "", errutil.Error{Code: errutil.BadRequest, Msg: "documents not found in request or not a list"}
+	}
+	texts := []string{query}
+	for _, document := range documents {
+		switch document := document.(type) {
+		case string:
+			texts = append(texts, document)
+		case map[string]any:
+			if text, ok := document["text"].(string); ok {
+				texts = append(texts, text)
+			}
+		}
+	}
+	return strings.Join(texts, "\n"), nil
+}
+
+// extractResponsesInput returns the prompt of a Responses API request: the instructions as a
+// system message, followed by the input, a string or a list of messages whose content is a
+// string or a list of text parts.
+func extractResponsesInput(body map[string]any) (string, error) {
+	prompt := ""
+	if instructions, ok := body["instructions"].(string); ok && instructions != "" {
+		prompt += constructChatMessage("system", instructions)
+	}
+	switch input := body["input"].(type) {
+	case string:
+		return prompt + constructChatMessage("user", input), nil
+	case []any:
+		for _, item := range input {
+			itemMap, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			role, ok := itemMap["role"].(string)
+			if !ok {
+				continue
+			}
+			if content := extractResponsesContent(itemMap["content"]); content != "" {
+				prompt += constructChatMessage(role, content)
+			}
+		}
+		return prompt, nil
+	case nil:
+		if prompt == "" {
+			return "", errutil.Error{Code: errutil.BadRequest, Msg: "input not found in request"}
+		}
+		return prompt, nil
+	}
+	return "", errutil.Error{Code: errutil.BadRequest, Msg: "input is not a string or a list"}
+}
+
+func extractResponsesContent(content any) string {
+	switch content := content.(type) {
+	case string:
+		return content
+	case []any:
+		texts := []string{}
+		for _, part := range content {
+			if partMap, ok := part.(map[string]any); ok {
+				if text, ok := partMap["text"].(string); ok {
+					texts = append(texts, text)
+				}
+			}
+		}
+		return strings.Join(texts, "\n")
+	}
+	return ""
+}
+
 func constructChatMessage(role string, content string) string {
 	return fmt.Sprintf("<|im_start|>%s\n%s<|im_end|>\n", role, content)
 }
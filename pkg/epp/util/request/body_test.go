@@ -18,6 +18,8 @@ package request
 
 import (
 	"testing"
+
+	schedulingtypes "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
 )
 
 func TestExtractPromptFromRequestBody(t *testing.T) {
@@ -205,3 +207,119 @@ func TestConstructChatMessage(t *testing.T) {
 		}
 	}
 }
+
+func TestExtractRequestKind(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		body map[string]any
+		want schedulingtypes.RequestKind
+	}{
+		{name: "chat completions path", path: "/v1/chat/completions", want: schedulingtypes.RequestKindChatCompletions},
+		{name: "completions path", path: "/v1/completions", body: map[string]any{"messages": []any{}}, want: schedulingtypes.RequestKindCompletions},
+		{name: "embeddings path with query", path: "/openai/v1/embeddings?api-version=1", want: schedulingtypes.RequestKindEmbeddings},
+		{name: "rerank path with trailing slash", path: "/v2/rerank/", want: schedulingtypes.RequestKindRerank},
+		{name: "responses path", path: "/v1/responses", body: map[string]any{"input": "hi"}, want: schedulingtypes.RequestKindResponses},
+		{name: "unknown path, messages", path: "/generate", body: map[string]any{"messages": []any{}}, want: schedulingtypes.RequestKindChatCompletions},
+		{name: "no path, query and documents", body: map[string]any{"query": "q", "documents": []any{}}, want: schedulingtypes.RequestKindRerank},
+		{name: "no path, input and instructions", body: map[string]any{"input": "hi", "instructions": "be nice"}, want: schedulingtypes.RequestKindResponses},
+		{name: "no path, input", body: map[string]any{"input": "hi"}, want: schedulingtypes.RequestKindEmbeddings},
+		{name: "no path, prompt", body: map[string]any{"prompt": "hi"}, want: schedulingtypes.RequestKindCompletions},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractRequestKind(tt.path, tt.body); got != tt.want {
+				t.Errorf("ExtractRequestKind() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractPrompt(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    schedulingtypes.RequestKind
+		body    map[string]any
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "embeddings string input",
+			kind: schedulingtypes.RequestKindEmbeddings,
+			body: map[string]any{"input": "embed me"},
+			want: "embed me",
+		},
+		{
+			name: "embeddings list input",
+			kind: schedulingtypes.RequestKindEmbeddings,
+			body: map[string]any{"input": []any{"first", "second"}},
+			want: "first\nsecond",
+		},
+		{
+			name: "embeddings token input",
+			kind: schedulingtypes.RequestKindEmbeddings,
+			body: map[string]any{"input": []any{[]any{1.0, 2.0}}},
+			want: "",
+		},
+		{
+			name:    "embeddings without input",
+			kind:    schedulingtypes.RequestKindEmbeddings,
+			body:    map[string]any{"prompt": "embed me"},
+			wantErr: true,
+		},
+		{
+			name: "rerank",
+			kind: schedulingtypes.RequestKindRerank,
+			body: map[string]any{"query": "which?", "documents": []any{"a", map[string]any{"text": "b"}}},
+			want: "which?\na\nb",
+		},
+		{
+			name:    "rerank without documents",
+			kind:    schedulingtypes.RequestKindRerank,
+			body:    map[string]any{"query": "which?"},
+			wantErr: true,
+		},
+		{
+			name: "responses string input",
+			kind: schedulingtypes.RequestKindResponses,
+			body: map[string]any{"instructions": "be nice", "input": "hello"},
+			want: "<|im_start|>system\nbe nice<|im_end|>\n" +
+				"<|im_start|>user\nhello<|im_end|>\n",
+		},
+		{
+			name: "responses message input",
+			kind: schedulingtypes.RequestKindResponses,
+			body: map[string]any{"input": []any{
+				map[string]any{"role": "user", "content": []any{map[string]any{"type": "input_text", "text": "hello"}}},
+				map[string]any{"role": "assistant", "content": "hi"},
+				map[string]any{"type": "function_call_output", "output": "42"},
+			}},
+			want: "<|im_start|>user\nhello<|im_end|>\n" +
+				"<|im_start|>assistant\nhi<|im_end|>\n",
+		},
+		{
+			name:    "responses without input",
+			kind:    schedulingtypes.RequestKindResponses,
+			body:    map[string]any{"model": "test"},
+			wantErr: true,
+		},
+		{
+			name: "completions",
+			kind: schedulingtypes.RequestKindCompletions,
+			body: map[string]any{"prompt": "test prompt"},
+			want: "test prompt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExtractPrompt(tt.kind, tt.body)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExtractPrompt() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ExtractPrompt() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -24,6 +24,8 @@ import (
 
 const (
 	RequestIdHeaderKey = "x-request-id"
+	// PathHeaderKey is the pseudo-header holding the request path.
+	PathHeaderKey = ":path"
 )
 
 func ExtractHeaderValue(req *extProcPb.ProcessingRequest_RequestHeaders, headerKey string) string {
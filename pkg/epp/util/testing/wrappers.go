@@ -17,6 +17,8 @@ limitations under the License.
 package testing
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
@@ -151,6 +153,26 @@ func (m *InferenceObjectiveWrapper) Criticality(criticality int) *InferenceObjec
 	return m
 }
 
+func (m *InferenceObjectiveWrapper) Latency(ttft, tpot time.Duration, percentile int32) *InferenceObjectiveWrapper {
+	m.Spec.Latency = &v1alpha2.LatencyObjective{
+		TargetTTFT: &metav1.Duration{Duration: ttft},
+		TargetTPOT: &metav1.Duration{Duration: tpot},
+		Percentile: &percentile,
+	}
+	return m
+}
+
+func (m *InferenceObjectiveWrapper) RateLimit(requestsPerMinute, tokensPerMinute int64, key v1alpha2.RateLimitKey) *InferenceObjectiveWrapper {
+	m.Spec.RateLimit = &v1alpha2.RateLimit{Key: key}
+	if requestsPerMinute > 0 {
+		m.Spec.RateLimit.RequestsPerMinute = &requestsPerMinute
+	}
+	if tokensPerMinute > 0 {
+		m.Spec.RateLimit.TokensPerMinute = &tokensPerMinute
+	}
+	return m
+}
+
 func (m *InferenceObjectiveWrapper) DeletionTimestamp() *InferenceObjectiveWrapper {
 	now := metav1.Now()
 	m.ObjectMeta.DeletionTimestamp = &now
@@ -203,6 +225,14 @@ func (m *InferencePoolWrapper) TargetPortNumber(p int32) *InferencePoolWrapper {
 	return m
 }
 
+func (m *InferencePoolWrapper) TargetPorts(ports ...int32) *InferencePoolWrapper {
+	m.Spec.TargetPorts = make([]v1.PortNumber, len(ports))
+	for i, port := range ports {
+		m.Spec.TargetPorts[i] = v1.PortNumber(port)
+	}
+	return m
+}
+
 func (m *InferencePoolWrapper) ExtensionRef(name string) *InferencePoolWrapper {
 	m.Spec.ExtensionRef = &v1.Extension{ExtensionReference: v1.ExtensionReference{Name: v1.ObjectName(name)}}
 	return m
@@ -249,6 +279,14 @@ func (m *XInferencePoolWrapper) TargetPortNumber(p int32) *XInferencePoolWrapper
 	return m
 }
 
+func (m *XInferencePoolWrapper) TargetPorts(ports ...int32) *XInferencePoolWrapper {
+	m.Spec.TargetPorts = make([]v1alpha2.PortNumber, len(ports))
+	for i, port := range ports {
+		m.Spec.TargetPorts[i] = v1alpha2.PortNumber(port)
+	}
+	return m
+}
+
 func (m *XInferencePoolWrapper) ExtensionRef(name string) *XInferencePoolWrapper {
 	m.Spec.ExtensionRef = &v1alpha2.Extension{ExtensionReference: v1alpha2.ExtensionReference{Name: v1alpha2.ObjectName(name)}}
 	return m
@@ -0,0 +1,179 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing sets up the OpenTelemetry tracing of the EPP, and provides the helpers creating
+// the spans of the request path. Until Setup is called, the spans are not recorded.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.32.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// TracerName is the name of the tracer creating the spans of the EPP.
+	TracerName = "sigs.k8s.io/gateway-api-inference-extension/epp"
+	// ServiceName is the default service name of the spans, which is overridden by the
+	// OTEL_SERVICE_NAME environment variable.
+	ServiceName = "endpoint-picker"
+)
+
+// Exporter is the destination of the spans.
+type Exporter string
+
+const (
+	// ExporterNone disables tracing.
+	ExporterNone Exporter = "none"
+	// ExporterOTLP exports the spans to an OpenTelemetry collector over OTLP/gRPC.
+	ExporterOTLP Exporter = "otlp"
+	// ExporterStdout writes the spans to the standard output, for debugging.
+	ExporterStdout Exporter = "stdout"
+)
+
+// Exporters returns the supported exporters.
+func Exporters() []Exporter {
+	return []Exporter{ExporterNone, ExporterOTLP, ExporterStdout}
+}
+
+// Attributes of the spans.
+const (
+	IncomingModelKey     = attribute.Key("epp.model.incoming")
+	TargetModelKey       = attribute.Key("epp.model.target")
+	ObjectiveKey         = attribute.Key("epp.objective")
+	CriticalityKey       = attribute.Key("epp.criticality")
+	EndpointKey          = attribute.Key("epp.endpoint")
+	ProfileKey           = attribute.Key("epp.scheduling.profile")
+	ExtensionPointKey    = attribute.Key("epp.plugin.extension_point")
+	PluginTypeKey        = attribute.Key("epp.plugin.type")
+	PluginNameKey        = attribute.Key("epp.plugin.name")
+	CandidateCountKey    = attribute.Key("epp.scheduling.candidates")
+	ResponseStatusKey    = attribute.Key("http.response.status_code")
+	ResponseStreamingKey = attribute.Key("epp.response.streaming")
+)
+
+// Options configures the export of the spans.
+type Options struct {
+	Exporter Exporter
+	// OTLPEndpoint is the host:port of the OTLP/gRPC collector. If empty, the endpoint is read from
+	// the OTEL_EXPORTER_OTLP_ENDPOINT environment variable, and defaults to localhost:4317.
+	OTLPEndpoint string
+	// OTLPInsecure disables the TLS of the connection to the collector.
+	OTLPInsecure bool
+	// SamplingRatio is the ratio of the traces started by the EPP which are sampled. The requests
+	// whose trace context is propagated by the gateway follow the sampling decision of their parent.
+	SamplingRatio float64
+}
+
+// Setup registers the global tracer provider exporting the spans as configured, and the W3C trace
+// context propagator. It returns the function flushing the pending spans on shutdown. Nothing is
+// registered if tracing is disabled.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	if opts.Exporter == ExporterNone || opts.Exporter == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	if opts.SamplingRatio < 0 || opts.SamplingRatio > 1 {
+		return nil, fmt.Errorf("the tracing sampling ratio must be between 0 and 1, got %g", opts.SamplingRatio)
+	}
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch opts.Exporter {
+	case ExporterOTLP:
+		exporterOpts := []otlptracegrpc.Option{}
+		if opts.OTLPEndpoint != "" {
+			exporterOpts = append(exporterOpts, otlptracegrpc.WithEndpoint(opts.OTLPEndpoint))
+		}
+		if opts.OTLPInsecure {
+			exporterOpts = append(exporterOpts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, exporterOpts...)
+	case ExporterStdout:
+		exporter, err = stdouttrace.New()
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q, must be one of %v", opts.Exporter, Exporters())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the %s trace exporter - %w", opts.Exporter, err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(ServiceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the trace resource - %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SamplingRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(Propagator())
+	return provider.Shutdown, nil
+}
+
+// Propagator returns the propagator of the W3C trace context and baggage.
+func Propagator() propagation.TextMapPropagator {
+	return propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+}
+
+// Tracer returns the tracer of the EPP, from the global tracer provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// Extract returns the context with the trace context of the request headers, e.g. the traceparent
+// header set by the gateway.
+func Extract(ctx context.Context, headers map[string]string) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(headers))
+}
+
+// Inject sets the trace context of the context in the headers, e.g. the traceparent header of the
+// request sent to the model server.
+func Inject(ctx context.Context, headers map[string]string) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(headers))
+}
+
+// StartPluginSpan starts the span of a plugin running at an extension point.
+func StartPluginSpan(ctx context.Context, extensionPoint, pluginType, pluginName string) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, extensionPoint+" "+pluginType, trace.WithAttributes(
+		ExtensionPointKey.String(extensionPoint),
+		PluginTypeKey.String(pluginType),
+		PluginNameKey.String(pluginName),
+	))
+}
+
+// EndSpan records the error, if any, on the span and ends it.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	testutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/testing"
+)
+
+func TestSetup(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr string
+	}{
+		{
+			name: "disabled",
+			opts: Options{Exporter: ExporterNone},
+		},
+		{
+			name: "stdout",
+			opts: Options{Exporter: ExporterStdout, SamplingRatio: 1},
+		},
+		{
+			name: "otlp",
+			opts: Options{Exporter: ExporterOTLP, OTLPEndpoint: "localhost:4317", OTLPInsecure: true, SamplingRatio: 0.1},
+		},
+		{
+			name:    "unknown exporter",
+			opts:    Options{Exporter: "zipkin"},
+			wantErr: `unknown tracing exporter "zipkin", must be one of [none otlp stdout]`,
+		},
+		{
+			name:    "invalid sampling ratio",
+			opts:    Options{Exporter: ExporterStdout, SamplingRatio: 2},
+			wantErr: "the tracing sampling ratio must be between 0 and 1, got 2",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+			defer func() {
+				otel.SetTracerProvider(previousProvider)
+				otel.SetTextMapPropagator(previousPropagator)
+			}()
+
+			shutdown, err := Setup(context.Background(), test.opts)
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NoError(t, shutdown(context.Background()))
+		})
+	}
+}
+
+func TestPropagation(t *testing.T) {
+	recorder, restore := testutil.NewTestSpanRecorder()
+	defer restore()
+
+	const traceparent = "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+	ctx := Extract(context.Background(), map[string]string{"traceparent": traceparent})
+	ctx, span := Tracer().Start(ctx, "request")
+	headers := map[string]string{}
+	Inject(ctx, headers)
+	EndSpan(span, errors.New("failed"))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", spans[0].SpanContext().TraceID().String())
+	assert.Equal(t, "b7ad6b7169203331", spans[0].Parent().SpanID().String())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	// The headers carry the span of the request as the parent of the model server.
+	assert.Equal(t, "00-0af7651916cd43dd8448eb211c80319c-"+spans[0].SpanContext().SpanID().String()+"-01", headers["traceparent"])
+}
+
+func TestStartPluginSpan(t *testing.T) {
+	recorder, restore := testutil.NewTestSpanRecorder()
+	defer restore()
+
+	ctx, parent := Tracer().Start(context.Background(), "parent")
+	_, span := StartPluginSpan(ctx, "Scorer", "queue-scorer", "queue")
+	span.End()
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "Scorer queue-scorer", spans[0].Name())
+	assert.Equal(t, trace.SpanContextFromContext(ctx).SpanID(), spans[0].Parent().SpanID())
+	assert.ElementsMatch(t, []any{"Scorer", "queue-scorer", "queue"}, []any{
+		spans[0].Attributes()[0].Value.AsString(),
+		spans[0].Attributes()[1].Value.AsString(),
+		spans[0].Attributes()[2].Value.AsString(),
+	})
+}
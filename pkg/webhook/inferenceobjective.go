@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	"sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
+)
+
+// compile-time type assertion
+var _ admission.CustomValidator = &InferenceObjectiveValidator{}
+
+// MissingPoolPolicy is the action taken on an InferenceObjective referencing an InferencePool which
+// doesn't exist.
+type MissingPoolPolicy string
+
+const (
+	// MissingPoolDeny rejects the objective. It's the default.
+	MissingPoolDeny MissingPoolPolicy = "deny"
+	// MissingPoolWarn admits the objective with a warning, so that the objectives can be applied
+	// before their pool. The EPP reports them with the PoolNotFound reason meanwhile.
+	MissingPoolWarn MissingPoolPolicy = "warn"
+)
+
+// MissingPoolPolicies returns the supported missing pool policies.
+func MissingPoolPolicies() []MissingPoolPolicy {
+	return []MissingPoolPolicy{MissingPoolDeny, MissingPoolWarn}
+}
+
+// InferenceObjectiveValidator validates the InferenceObjectives.
+//
+// An objective must reference an InferencePool of a supported group. Referencing a pool which
+// doesn't exist is denied or only raises a warning, depending on the MissingPool policy.
+type InferenceObjectiveValidator struct {
+	// Reader reads the referenced pools.
+	Reader client.Reader
+	// MissingPool is the policy applied to the objectives referencing a missing pool. Empty means
+	// MissingPoolDeny.
+	MissingPool MissingPoolPolicy
+}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *InferenceObjectiveValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, obj)
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (v *InferenceObjectiveValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, newObj)
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (v *InferenceObjectiveValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *InferenceObjectiveValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	objective, ok := obj.(*v1alpha2.InferenceObjective)
+	if !ok {
+		return nil, fmt.Errorf("expected an InferenceObjective, got %T", obj)
+	}
+	refPath := field.NewPath("spec", "poolRef")
+	ref := objective.Spec.PoolRef
+	errs := field.ErrorList{}
+	var pool client.Object
+	switch string(ref.Group) {
+	case v1.GroupName:
+		pool = &v1.InferencePool{}
+	case v1alpha2.GroupName:
+		pool = &v1alpha2.InferencePool{}
+	default:
+		errs = append(errs, field.NotSupported(refPath.Child("group"), ref.Group, []string{v1.GroupName, v1alpha2.GroupName}))
+	}
+	if ref.Kind != "InferencePool" {
+		errs = append(errs, field.NotSupported(refPath.Child("kind"), ref.Kind, []string{"InferencePool"}))
+	}
+	if len(errs) > 0 {
+		return nil, invalidObjective(objective, errs)
+	}
+
+	err := v.Reader.Get(ctx, types.NamespacedName{Namespace: objective.Namespace, Name: string(ref.Name)}, pool)
+	switch {
+	case apierrors.IsNotFound(err):
+		detail := fmt.Sprintf("InferencePool of group %s not found in namespace %s", ref.Group, objective.Namespace)
+		if v.MissingPool == MissingPoolWarn {
+			return admission.Warnings{fmt.Sprintf("%s: %s %s", refPath.Child("name"), ref.Name, detail)}, nil
+		}
+		return nil, invalidObjective(objective, field.ErrorList{field.Invalid(refPath.Child("name"), ref.Name, detail)})
+	case err != nil:
+		return nil, apierrors.NewInternalError(fmt.Errorf("failed to get the InferencePool %s: %w", ref.Name, err))
+	}
+	return nil, nil
+}
+
+func invalidObjective(objective *v1alpha2.InferenceObjective, errs field.ErrorList) error {
+	return apierrors.NewInvalid(schema.GroupKind{Group: v1alpha2.GroupName, Kind: "InferenceObjective"}, objective.Name, errs)
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	"sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
+	utiltest "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/testing"
+)
+
+func TestInferenceObjectiveValidator(t *testing.T) {
+	objective := func(poolName, poolGroup string) *v1alpha2.InferenceObjective {
+		obj := utiltest.MakeInferenceObjective("chat").Namespace("ns").PoolName(poolName).PoolGroup(poolGroup).ObjRef()
+		obj.Spec.PoolRef.Kind = "InferencePool"
+		return obj
+	}
+	withKind := func(kind string) *v1alpha2.InferenceObjective {
+		obj := objective("pool", v1.GroupName)
+		obj.Spec.PoolRef.Kind = v1alpha2.Kind(kind)
+		return obj
+	}
+
+	tests := []struct {
+		name         string
+		objective    *v1alpha2.InferenceObjective
+		missingPool  MissingPoolPolicy
+		wantFields   []string
+		wantWarnings int
+	}{
+		{
+			name:      "v1 pool",
+			objective: objective("pool", v1.GroupName),
+		},
+		{
+			name:      "v1alpha2 pool",
+			objective: objective("xpool", v1alpha2.GroupName),
+		},
+		{
+			name:       "missing pool denied by default",
+			objective:  objective("missing", v1.GroupName),
+			wantFields: []string{"spec.poolRef.name"},
+		},
+		{
+			name:        "missing pool denied",
+			objective:   objective("missing", v1.GroupName),
+			missingPool: MissingPoolDeny,
+			wantFields:  []string{"spec.poolRef.name"},
+		},
+		{
+			name:        "pool of another group denied",
+			objective:   objective("xpool", v1.GroupName),
+			missingPool: MissingPoolDeny,
+			wantFields:  []string{"spec.poolRef.name"},
+		},
+		{
+			name:         "missing pool warned",
+			objective:    objective("missing", v1.GroupName),
+			missingPool:  MissingPoolWarn,
+			wantWarnings: 1,
+		},
+		{
+			name:         "pool of another group warned",
+			objective:    objective("xpool", v1.GroupName),
+			missingPool:  MissingPoolWarn,
+			wantWarnings: 1,
+		},
+		{
+			name:       "unsupported group",
+			objective:  objective("pool", "example.com"),
+			wantFields: []string{"spec.poolRef.group"},
+		},
+		{
+			name:       "unsupported kind",
+			objective:  withKind("Service"),
+			wantFields: []string{"spec.poolRef.kind"},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	_ = v1alpha2.Install(scheme)
+	_ = v1.Install(scheme)
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(utiltest.MakeInferencePool("pool").Namespace("ns").ObjRef(),
+			utiltest.MakeXInferencePool("xpool").Namespace("ns").ObjRef()).
+		Build()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			validator := &InferenceObjectiveValidator{Reader: fakeClient, MissingPool: test.missingPool}
+			warnings, err := validator.ValidateCreate(context.Background(), test.objective)
+			if len(test.wantFields) > 0 {
+				require.True(t, apierrors.IsInvalid(err), "expected an invalid error, got %v", err)
+				assert.Equal(t, test.wantFields, invalidFields(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, warnings, test.wantWarnings)
+
+			updateWarnings, err := validator.ValidateUpdate(context.Background(), objective("pool", v1.GroupName), test.objective)
+			require.NoError(t, err)
+			assert.Equal(t, warnings, updateWarnings)
+		})
+	}
+}
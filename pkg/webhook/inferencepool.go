@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	"sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/common"
+)
+
+// defaultExtensionPort is the port of the extension Services which don't set one.
+const defaultExtensionPort = 9002
+
+// compile-time type assertion
+var _ admission.CustomValidator = &InferencePoolValidator{}
+
+// InferencePoolValidator validates the v1 and v1alpha2 InferencePools.
+type InferencePoolValidator struct{}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *InferencePoolValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, validateInferencePool(obj)
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (v *InferencePoolValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return nil, validateInferencePool(newObj)
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (v *InferencePoolValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateInferencePool validates a v1 or v1alpha2 InferencePool. The v1alpha2 pools are converted
+// to v1 first.
+func validateInferencePool(obj runtime.Object) error {
+	var pool *v1.InferencePool
+	var groupKind schema.GroupKind
+	switch p := obj.(type) {
+	case *v1.InferencePool:
+		pool = p
+		groupKind = schema.GroupKind{Group: v1.GroupName, Kind: "InferencePool"}
+	case *v1alpha2.InferencePool:
+		pool = &v1.InferencePool{}
+		if err := p.ConvertTo(pool); err != nil {
+			return err
+		}
+		groupKind = schema.GroupKind{Group: v1alpha2.GroupName, Kind: "InferencePool"}
+	default:
+		return fmt.Errorf("expected an InferencePool, got %T", obj)
+	}
+	if errs := validateInferencePoolSpec(&pool.Spec, field.NewPath("spec")); len(errs) > 0 {
+		return apierrors.NewInvalid(groupKind, pool.Name, errs)
+	}
+	return nil
+}
+
+// validateInferencePoolSpec validates the spec of an InferencePool.
+func validateInferencePoolSpec(spec *v1.InferencePoolSpec, path *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	selectorPath := path.Child("selector")
+	if len(spec.Selector) == 0 {
+		// An empty selector would select all the pods of the namespace.
+		errs = append(errs, field.Required(selectorPath, "must select the model server pods by at least one label"))
+	} else {
+		labels := make(map[string]string, len(spec.Selector))
+		for key, value := range spec.Selector {
+			labels[string(key)] = string(value)
+		}
+		errs = append(errs, metav1validation.ValidateLabels(labels, selectorPath)...)
+	}
+
+	extensionPath := path.Child("extensionRef")
+	if spec.ExtensionRef == nil {
+		errs = append(errs, field.Required(extensionPath, "must reference the endpoint picker extension"))
+	} else if port, ok := extensionPort(spec.ExtensionRef); ok && slices.Contains(common.TargetPorts(spec), port) {
+		errs = append(errs, field.Invalid(extensionPath.Child("portNumber"), port,
+			"must be different from the target ports, which the model servers listen on"))
+	}
+	return errs
+}
+
+// extensionPort returns the port of the extension, defaulting to 9002 for Services. It returns
+// false if the port isn't known.
+func extensionPort(extension *v1.Extension) (int32, bool) {
+	if extension.PortNumber != nil {
+		return int32(*extension.PortNumber), true
+	}
+	if extension.Kind == nil || *extension.Kind == "Service" {
+		return defaultExtensionPort, true
+	}
+	return 0, false
+}
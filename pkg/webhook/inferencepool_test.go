@@ -0,0 +1,159 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	utiltest "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/testing"
+)
+
+func TestInferencePoolValidator(t *testing.T) {
+	selector := map[string]string{"app": "vllm"}
+	pool := func() *utiltest.InferencePoolWrapper {
+		return utiltest.MakeInferencePool("pool").Namespace("ns").Selector(selector).TargetPortNumber(8000).ExtensionRef("epp")
+	}
+	withExtensionPort := func(port int32) *v1.InferencePool {
+		p := pool().ObjRef()
+		p.Spec.ExtensionRef.PortNumber = ptr.To(v1.PortNumber(port))
+		return p
+	}
+	withExtensionKind := func(kind string, port int32) *v1.InferencePool {
+		p := pool().TargetPortNumber(port).ObjRef()
+		p.Spec.ExtensionRef.Kind = ptr.To(v1.Kind(kind))
+		return p
+	}
+
+	tests := []struct {
+		name       string
+		obj        runtime.Object
+		wantFields []string
+	}{
+		{
+			name: "valid pool",
+			obj:  pool().ObjRef(),
+		},
+		{
+			name: "valid v1alpha2 pool",
+			obj:  utiltest.MakeXInferencePool("pool").Namespace("ns").Selector(selector).TargetPortNumber(8000).ExtensionRef("epp").ObjRef(),
+		},
+		{
+			name:       "empty selector",
+			obj:        pool().Selector(map[string]string{}).ObjRef(),
+			wantFields: []string{"spec.selector"},
+		},
+		{
+			name:       "empty selector of a v1alpha2 pool",
+			obj:        utiltest.MakeXInferencePool("pool").Namespace("ns").TargetPortNumber(8000).ExtensionRef("epp").ObjRef(),
+			wantFields: []string{"spec.selector"},
+		},
+		{
+			name:       "invalid selector",
+			obj:        pool().Selector(map[string]string{"app": "not a label value"}).ObjRef(),
+			wantFields: []string{"spec.selector"},
+		},
+		{
+			name:       "missing extension",
+			obj:        utiltest.MakeInferencePool("pool").Namespace("ns").Selector(selector).TargetPortNumber(8000).ObjRef(),
+			wantFields: []string{"spec.extensionRef"},
+		},
+		{
+			name:       "extension port collides with the target port",
+			obj:        withExtensionPort(8000),
+			wantFields: []string{"spec.extensionRef.portNumber"},
+		},
+		{
+			name: "extension on another port",
+			obj:  withExtensionPort(9002),
+		},
+		{
+			name:       "default extension port collides with the target port",
+			obj:        pool().TargetPortNumber(9002).ObjRef(),
+			wantFields: []string{"spec.extensionRef.portNumber"},
+		},
+		{
+			name:       "extension port collides with a data parallel target port",
+			obj:        pool().TargetPortNumber(0).TargetPorts(8000, 8001, 9002).ObjRef(),
+			wantFields: []string{"spec.extensionRef.portNumber"},
+		},
+		{
+			name: "data parallel target ports",
+			obj:  pool().TargetPortNumber(0).TargetPorts(8000, 8001).ObjRef(),
+		},
+		{
+			name: "data parallel target ports of a v1alpha2 pool",
+			obj:  utiltest.MakeXInferencePool("pool").Namespace("ns").Selector(selector).TargetPorts(8000, 8001).ExtensionRef("epp").ObjRef(),
+		},
+		{
+			name: "unknown port of a non Service extension",
+			obj:  withExtensionKind("Backend", 9002),
+		},
+		{
+			name:       "several errors",
+			obj:        utiltest.MakeInferencePool("pool").Namespace("ns").TargetPortNumber(8000).ObjRef(),
+			wantFields: []string{"spec.selector", "spec.extensionRef"},
+		},
+	}
+
+	validator := &InferencePoolValidator{}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for operation, validate := range map[string]func() error{
+				"create": func() error {
+					_, err := validator.ValidateCreate(context.Background(), test.obj)
+					return err
+				},
+				"update": func() error {
+					_, err := validator.ValidateUpdate(context.Background(), pool().ObjRef(), test.obj)
+					return err
+				},
+			} {
+				err := validate()
+				if len(test.wantFields) == 0 {
+					assert.NoError(t, err, operation)
+					continue
+				}
+				require.True(t, apierrors.IsInvalid(err), "%s: expected an invalid error, got %v", operation, err)
+				assert.Equal(t, test.wantFields, invalidFields(err), operation)
+			}
+		})
+	}
+
+	_, err := validator.ValidateDelete(context.Background(), utiltest.MakeInferencePool("pool").ObjRef())
+	assert.NoError(t, err)
+}
+
+// invalidFields returns the fields of an invalid error.
+func invalidFields(err error) []string {
+	status, ok := err.(apierrors.APIStatus)
+	if !ok || status.Status().Details == nil {
+		return nil
+	}
+	fields := []string{}
+	for _, cause := range status.Status().Details.Causes {
+		fields = append(fields, cause.Field)
+	}
+	return fields
+}
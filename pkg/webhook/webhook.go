@@ -0,0 +1,45 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook implements the validating admission webhooks of the InferencePools and the
+// InferenceObjectives.
+//
+// The webhooks reject the objects which the CRD schemas accept but the EPP can't serve, so that
+// they are reported when applied rather than at runtime.
+package webhook
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	"sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
+)
+
+// SetupWithManager registers the validating webhooks of the v1 and v1alpha2 InferencePools, and of
+// the InferenceObjectives, on the webhook server of the manager. The objectives referencing a missing
+// pool are handled according to the missingPool policy.
+func SetupWithManager(mgr ctrl.Manager, missingPool MissingPoolPolicy) error {
+	poolValidator := &InferencePoolValidator{}
+	if err := ctrl.NewWebhookManagedBy(mgr).For(&v1.InferencePool{}).WithValidator(poolValidator).Complete(); err != nil {
+		return err
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr).For(&v1alpha2.InferencePool{}).WithValidator(poolValidator).Complete(); err != nil {
+		return err
+	}
+	// The pools are read from the API server, since the webhook doesn't cache them.
+	objectiveValidator := &InferenceObjectiveValidator{Reader: mgr.GetAPIReader(), MissingPool: missingPool}
+	return ctrl.NewWebhookManagedBy(mgr).For(&v1alpha2.InferenceObjective{}).WithValidator(objectiveValidator).Complete()
+}
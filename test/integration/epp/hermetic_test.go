@@ -490,12 +490,6 @@ func TestFullDuplexStreamed_KubeInferenceObjectiveRequest(t *testing.T) {
 			wantErr: false,
 			wantResponses: integrationutils.NewResponseBufferedResponse(
 				fmt.Sprintf(`{"max_tokens":100,"model":%q,"prompt":"test6","temperature":0}`, modelSheddable),
-				&configPb.HeaderValueOption{
-					Header: &configPb.HeaderValue{
-						Key:      "x-went-into-resp-headers",
-						RawValue: []byte("true"),
-					},
-				},
 				&configPb.HeaderValueOption{
 					Header: &configPb.HeaderValue{
 						Key:      "content-type",
@@ -538,12 +532,6 @@ func TestFullDuplexStreamed_KubeInferenceObjectiveRequest(t *testing.T) {
 			wantErr: false,
 			wantResponses: integrationutils.NewResponseBufferedResponse(
 				"no healthy upstream",
-				&configPb.HeaderValueOption{
-					Header: &configPb.HeaderValue{
-						Key:      "x-went-into-resp-headers",
-						RawValue: []byte("true"),
-					},
-				},
 				&configPb.HeaderValueOption{
 					Header: &configPb.HeaderValue{
 						Key:      "content-type",
@@ -591,12 +579,6 @@ func TestFullDuplexStreamed_KubeInferenceObjectiveRequest(t *testing.T) {
 			wantErr: false,
 			wantResponses: integrationutils.NewResponseBufferedResponse(
 				fmt.Sprintf(`{"max_tokens":100,"model":%q,"prompt":"test6","temperature":0}`, modelSheddable),
-				&configPb.HeaderValueOption{
-					Header: &configPb.HeaderValue{
-						Key:      "x-went-into-resp-headers",
-						RawValue: []byte("true"),
-					},
-				},
 				&configPb.HeaderValueOption{
 					Header: &configPb.HeaderValue{
 						Key:      "content-type",
@@ -705,12 +687,6 @@ func TestFullDuplexStreamed_KubeInferenceObjectiveRequest(t *testing.T) {
 					`},
 			wantResponses: []*extProcPb.ProcessingResponse{
 				integrationutils.NewResponseHeaders(
-					&configPb.HeaderValueOption{
-						Header: &configPb.HeaderValue{
-							Key:      "x-went-into-resp-headers",
-							RawValue: []byte("true"),
-						},
-					},
 					&configPb.HeaderValueOption{
 						Header: &configPb.HeaderValue{
 							Key:      "content-type",
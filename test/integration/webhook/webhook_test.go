@@ -0,0 +1,249 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook contains the integration tests of the admission webhooks, served to a local API
+// server started by envtest.
+package webhook
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	"sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+	utiltest "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/testing"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/webhook"
+)
+
+const testNamespace = "default"
+
+var (
+	k8sClient k8sclient.Client
+	testEnv   *envtest.Environment
+	scheme    = runtime.NewScheme()
+	logger    = logutil.NewTestLogger().V(logutil.VERBOSE)
+)
+
+func TestMain(m *testing.M) {
+	cleanup := BeforeSuite()
+	code := m.Run()
+	cleanup()
+	os.Exit(code)
+}
+
+// BeforeSuite starts an API server calling the admission webhooks served by a local manager.
+func BeforeSuite() func() {
+	testEnv = &envtest.Environment{
+		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "..", "config", "crd", "bases")},
+		ErrorIfCRDPathMissing: true,
+		WebhookInstallOptions: envtest.WebhookInstallOptions{
+			Paths: []string{filepath.Join("..", "..", "..", "config", "manifests", "webhook.yaml")},
+		},
+	}
+	cfg, err := testEnv.Start()
+	if err != nil {
+		logutil.Fatal(logger, err, "Failed to start test environment", "config", cfg)
+	}
+
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha2.Install(scheme))
+	utilruntime.Must(v1.Install(scheme))
+
+	k8sClient, err = k8sclient.New(cfg, k8sclient.Options{Scheme: scheme})
+	if err != nil {
+		logutil.Fatal(logger, err, "Failed to start k8s Client")
+	}
+
+	ctrl.SetLogger(logger)
+	webhookOptions := &testEnv.WebhookInstallOptions
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:  scheme,
+		Metrics: metricsserver.Options{BindAddress: "0"},
+		WebhookServer: ctrlwebhook.NewServer(ctrlwebhook.Options{
+			Host:    webhookOptions.LocalServingHost,
+			Port:    webhookOptions.LocalServingPort,
+			CertDir: webhookOptions.LocalServingCertDir,
+		}),
+	})
+	if err != nil {
+		logutil.Fatal(logger, err, "Failed to create controller manager")
+	}
+	if err := webhook.SetupWithManager(mgr, webhook.MissingPoolDeny); err != nil {
+		logutil.Fatal(logger, err, "Failed to setup the admission webhooks")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		if err := mgr.Start(ctx); err != nil {
+			logutil.Fatal(logger, err, "Failed to start manager")
+		}
+	}()
+
+	// Wait for the webhook server to serve.
+	address := net.JoinHostPort(webhookOptions.LocalServingHost, fmt.Sprint(webhookOptions.LocalServingPort))
+	assert.Eventually(nil, func() bool {
+		conn, err := tls.Dial("tcp", address, &tls.Config{InsecureSkipVerify: true})
+		if err != nil {
+			return false
+		}
+		_ = conn.Close()
+		return true
+	}, 10*time.Second, 10*time.Millisecond)
+
+	return func() {
+		cancel()
+		_ = testEnv.Stop()
+	}
+}
+
+func TestInferencePoolValidation(t *testing.T) {
+	pool := func(name string) *utiltest.InferencePoolWrapper {
+		return utiltest.MakeInferencePool(name).Namespace(testNamespace).
+			Selector(map[string]string{"app": "vllm"}).TargetPortNumber(8000).ExtensionRef("epp")
+	}
+	xpool := func(name string) *utiltest.XInferencePoolWrapper {
+		return utiltest.MakeXInferencePool(name).Namespace(testNamespace).
+			Selector(map[string]string{"app": "vllm"}).TargetPortNumber(8000).ExtensionRef("epp")
+	}
+
+	tests := []struct {
+		name    string
+		obj     k8sclient.Object
+		wantErr bool
+	}{
+		{
+			name: "valid v1 pool",
+			obj:  pool("valid").ObjRef(),
+		},
+		{
+			name: "valid v1alpha2 pool",
+			obj:  xpool("xvalid").ObjRef(),
+		},
+		{
+			name:    "v1 pool with an empty selector",
+			obj:     pool("empty-selector").Selector(map[string]string{}).ObjRef(),
+			wantErr: true,
+		},
+		{
+			name:    "v1alpha2 pool with an empty selector",
+			obj:     xpool("xempty-selector").Selector(map[string]string{}).ObjRef(),
+			wantErr: true,
+		},
+		{
+			name:    "v1 pool with the extension on the target port",
+			obj:     pool("port-collision").TargetPortNumber(9002).ObjRef(),
+			wantErr: true,
+		},
+		{
+			name:    "v1alpha2 pool with the extension on the target port",
+			obj:     xpool("xport-collision").TargetPortNumber(9002).ObjRef(),
+			wantErr: true,
+		},
+		{
+			name: "v1 pool with data parallel target ports",
+			obj:  pool("ranks").TargetPortNumber(0).TargetPorts(8000, 8001).ObjRef(),
+		},
+		{
+			name:    "v1 pool with the extension on a data parallel target port",
+			obj:     pool("ranks-port-collision").TargetPortNumber(0).TargetPorts(8000, 9002).ObjRef(),
+			wantErr: true,
+		},
+		{
+			name:    "v1 pool with both a target port and data parallel target ports",
+			obj:     pool("both-ports").TargetPorts(8000, 8001).ObjRef(),
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := k8sClient.Create(context.Background(), test.obj)
+			if !test.wantErr {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.True(t, apierrors.IsInvalid(err), "expected an invalid error, got %v", err)
+		})
+	}
+
+	// Updates are validated too.
+	valid := &v1.InferencePool{}
+	require.NoError(t, k8sClient.Get(context.Background(), k8sclient.ObjectKey{Namespace: testNamespace, Name: "valid"}, valid))
+	valid.Spec.Selector = map[v1.LabelKey]v1.LabelValue{}
+	err := k8sClient.Update(context.Background(), valid)
+	assert.True(t, apierrors.IsInvalid(err), "expected an invalid error, got %v", err)
+}
+
+func TestInferenceObjectiveValidation(t *testing.T) {
+	pool := utiltest.MakeInferencePool("objective-pool").Namespace(testNamespace).
+		Selector(map[string]string{"app": "vllm"}).TargetPortNumber(8000).ExtensionRef("epp").ObjRef()
+	require.NoError(t, k8sClient.Create(context.Background(), pool))
+
+	objective := func(name, poolName, poolGroup string) *v1alpha2.InferenceObjective {
+		return utiltest.MakeInferenceObjective(name).Namespace(testNamespace).PoolName(poolName).PoolGroup(poolGroup).ObjRef()
+	}
+
+	tests := []struct {
+		name    string
+		obj     *v1alpha2.InferenceObjective
+		wantErr bool
+	}{
+		{
+			name: "existing pool",
+			obj:  objective("chat", "objective-pool", v1.GroupName),
+		},
+		{
+			name:    "missing pool",
+			obj:     objective("missing-pool", "missing", v1.GroupName),
+			wantErr: true,
+		},
+		{
+			name:    "unsupported pool group",
+			obj:     objective("unsupported-group", "objective-pool", "example.com"),
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := k8sClient.Create(context.Background(), test.obj)
+			if !test.wantErr {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.True(t, apierrors.IsInvalid(err), "expected an invalid error, got %v", err)
+		})
+	}
+}